@@ -74,8 +74,14 @@ resource "keycloak_realm" "test" {
     }
   }
 
-  ssl_required    = "external"
-  password_policy = "upperCase(1) and length(8) and forceExpiredPasswordChange(365) and notUsername"
+  ssl_required = "external"
+
+  password_policies {
+    upper_case                    = 1
+    length                        = 8
+    force_expired_password_change = 365
+    not_username                  = true
+  }
 
   attributes = {
     mycustomAttribute  = "myCustomValue"
@@ -0,0 +1,104 @@
+package generator
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/keycloak/terraform-provider-keycloak/keycloak"
+)
+
+// Run implements the `generate` subcommand of the provider binary. Every primitive attribute of the provider schema
+// is available as a flag, so the generator connects to keycloak with exactly the same settings (and environment
+// variables) as the provider itself.
+func Run(ctx context.Context, provider *schema.Provider, args []string, stdout, stderr io.Writer) error {
+	flags := flag.NewFlagSet("generate", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+
+	outputDir := flags.String("output-dir", ".", "The directory the generated .tf files are written to")
+	includeBuiltIn := flags.Bool("include-built-in", false, "Also generate the clients, client scopes, roles and flows that keycloak creates for every realm")
+
+	providerFlags := providerSchemaFlags(flags, provider.Schema)
+
+	flags.Usage = func() {
+		fmt.Fprintf(stderr, "Usage: terraform-provider-keycloak generate [flags] <realm>\n\nFlags:\n")
+		flags.PrintDefaults()
+	}
+
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if flags.NArg() != 1 {
+		flags.Usage()
+		return fmt.Errorf("exactly one realm must be given")
+	}
+
+	realmId := flags.Arg(0)
+
+	// only pass along the flags that were set, so the provider's environment variable defaults still apply
+	config := map[string]interface{}{}
+	flags.Visit(func(f *flag.Flag) {
+		if key, ok := providerFlags[f.Name]; ok {
+			config[key] = f.Value.(flag.Getter).Get()
+		}
+	})
+
+	diags := provider.Configure(ctx, terraform.NewResourceConfigRaw(config))
+	if diags.HasError() {
+		return fmt.Errorf("error configuring provider: %s %s", diags[0].Summary, diags[0].Detail)
+	}
+
+	keycloakClient := provider.Meta().(*keycloak.KeycloakClient)
+
+	generator := NewGenerator(provider, keycloakClient, realmId, *includeBuiltIn, stderr)
+	if err := generator.Discover(ctx); err != nil {
+		return err
+	}
+
+	if err := generator.Write(*outputDir); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(stdout, "Generated %d resources for realm %s in %s\n", len(generator.resources), realmId, *outputDir)
+
+	return nil
+}
+
+// providerSchemaFlags registers a flag for every string, bool, int and float attribute of the provider schema, and returns the
+// schema key for each flag name
+func providerSchemaFlags(flags *flag.FlagSet, providerSchema map[string]*schema.Schema) map[string]string {
+	keys := make([]string, 0, len(providerSchema))
+	for key := range providerSchema {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	providerFlags := map[string]string{}
+	for _, key := range keys {
+		attributeSchema := providerSchema[key]
+		name := strings.ReplaceAll(key, "_", "-")
+
+		switch attributeSchema.Type {
+		case schema.TypeString:
+			flags.String(name, "", attributeSchema.Description)
+		case schema.TypeBool:
+			flags.Bool(name, false, attributeSchema.Description)
+		case schema.TypeInt:
+			flags.Int(name, 0, attributeSchema.Description)
+		case schema.TypeFloat:
+			flags.Float64(name, 0, attributeSchema.Description)
+		default:
+			continue
+		}
+
+		providerFlags[name] = key
+	}
+
+	return providerFlags
+}
@@ -0,0 +1,508 @@
+package generator
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/keycloak/terraform-provider-keycloak/keycloak"
+)
+
+// these objects are created by keycloak for every realm, so they are not generated unless they have been asked for
+var builtInClientIds = []string{
+	"account",
+	"account-console",
+	"admin-cli",
+	"broker",
+	"realm-management",
+	"security-admin-console",
+}
+
+var builtInClientScopeNames = []string{
+	"acr",
+	"address",
+	"basic",
+	"email",
+	"microprofile-jwt",
+	"offline_access",
+	"organization",
+	"phone",
+	"profile",
+	"role_list",
+	"roles",
+	"saml_organization",
+	"web-origins",
+}
+
+var builtInRealmRoleNames = []string{
+	"offline_access",
+	"uma_authorization",
+}
+
+var identityProviderResourceTypes = map[string]string{
+	"oidc":          "keycloak_oidc_identity_provider",
+	"keycloak-oidc": "keycloak_oidc_identity_provider",
+	"google":        "keycloak_oidc_google_identity_provider",
+	"saml":          "keycloak_saml_identity_provider",
+}
+
+var invalidLabelCharacters = regexp.MustCompile(`[^a-z0-9_]+`)
+
+type generatedResource struct {
+	resourceType string
+	name         string
+	importId     string
+	file         string
+	data         *schema.ResourceData
+}
+
+// Generator walks a realm using the provider's own importers and read functions, and writes the result as HCL.
+type Generator struct {
+	provider       *schema.Provider
+	keycloakClient *keycloak.KeycloakClient
+	realmId        string
+	includeBuiltIn bool
+	log            io.Writer
+
+	resources []*generatedResource
+	names     map[string]bool
+
+	// the values that other resources can reference, keyed by the value that keycloak returns
+	idReferences                    map[string]*generatedResource
+	flowAliasReferences             map[string]*generatedResource
+	identityProviderAliasReferences map[string]*generatedResource
+}
+
+func NewGenerator(provider *schema.Provider, keycloakClient *keycloak.KeycloakClient, realmId string, includeBuiltIn bool, log io.Writer) *Generator {
+	return &Generator{
+		provider:                        provider,
+		keycloakClient:                  keycloakClient,
+		realmId:                         realmId,
+		includeBuiltIn:                  includeBuiltIn,
+		log:                             log,
+		names:                           map[string]bool{},
+		idReferences:                    map[string]*generatedResource{},
+		flowAliasReferences:             map[string]*generatedResource{},
+		identityProviderAliasReferences: map[string]*generatedResource{},
+	}
+}
+
+// Discover reads every supported object within the realm. Objects that cannot be read are logged and skipped.
+func (g *Generator) Discover(ctx context.Context) error {
+	realm, err := g.keycloakClient.GetRealm(ctx, g.realmId)
+	if err != nil {
+		return err
+	}
+
+	realmResource, err := g.add(ctx, "keycloak_realm", realm.Realm, realm.Realm, "realm.tf")
+	if err != nil {
+		return err
+	}
+	if realmResource == nil {
+		return fmt.Errorf("realm %s could not be read", g.realmId)
+	}
+
+	discoverFuncs := []func(ctx context.Context, realm *keycloak.Realm) error{
+		g.discoverClientScopes,
+		g.discoverClients,
+		g.discoverRealmRoles,
+		g.discoverGroups,
+		g.discoverAuthenticationFlows,
+		g.discoverIdentityProviders,
+		g.discoverLdapUserFederations,
+	}
+
+	for _, discoverFunc := range discoverFuncs {
+		if err := discoverFunc(ctx, realm); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (g *Generator) discoverClientScopes(ctx context.Context, realm *keycloak.Realm) error {
+	clientScopes, err := g.keycloakClient.ListOpenidClientScopesWithFilter(ctx, realm.Realm, func(clientScope *keycloak.OpenidClientScope) bool {
+		return g.includeBuiltIn || !contains(builtInClientScopeNames, clientScope.Name)
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, clientScope := range clientScopes {
+		if _, err := g.add(ctx, "keycloak_openid_client_scope", clientScope.Name, fmt.Sprintf("%s/%s", realm.Realm, clientScope.Id), "client_scopes.tf"); err != nil {
+			return err
+		}
+	}
+
+	samlClientScopes, err := g.keycloakClient.ListSamlClientScopesWithFilter(ctx, realm.Realm, func(clientScope *keycloak.SamlClientScope) bool {
+		return g.includeBuiltIn || !contains(builtInClientScopeNames, clientScope.Name)
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, clientScope := range samlClientScopes {
+		if _, err := g.add(ctx, "keycloak_saml_client_scope", clientScope.Name, fmt.Sprintf("%s/%s", realm.Realm, clientScope.Id), "client_scopes.tf"); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (g *Generator) discoverClients(ctx context.Context, realm *keycloak.Realm) error {
+	clients, err := g.keycloakClient.GetOpenidClients(ctx, realm.Realm, false)
+	if err != nil {
+		return err
+	}
+
+	var generatedClients []*keycloak.OpenidClient
+	for _, client := range clients {
+		// the master realm also contains a client for every other realm
+		isBuiltIn := contains(builtInClientIds, client.ClientId) || (realm.Realm == "master" && strings.HasSuffix(client.ClientId, "-realm"))
+		if isBuiltIn && !g.includeBuiltIn {
+			continue
+		}
+
+		resourceType := "keycloak_openid_client"
+		if client.Protocol == "saml" {
+			resourceType = "keycloak_saml_client"
+		}
+
+		clientResource, err := g.add(ctx, resourceType, client.ClientId, fmt.Sprintf("%s/%s", realm.Realm, client.Id), "clients.tf")
+		if err != nil {
+			return err
+		}
+		if clientResource == nil {
+			continue
+		}
+
+		generatedClients = append(generatedClients, client)
+
+		clientWithProtocolMappers, err := g.keycloakClient.GetGenericProtocolMappers(ctx, realm.Realm, client.Id)
+		if err != nil {
+			return err
+		}
+
+		for _, protocolMapper := range clientWithProtocolMappers.ProtocolMappers {
+			name := fmt.Sprintf("%s_%s", client.ClientId, protocolMapper.Name)
+			importId := fmt.Sprintf("%s/client/%s/%s", realm.Realm, client.Id, protocolMapper.Id)
+			if _, err := g.add(ctx, "keycloak_generic_protocol_mapper", name, importId, "clients.tf"); err != nil {
+				return err
+			}
+		}
+	}
+
+	clientRoles, err := g.keycloakClient.GetClientRoles(ctx, realm.Realm, generatedClients)
+	if err != nil {
+		return err
+	}
+
+	for _, role := range clientRoles {
+		name := role.Name
+		if clientResource, ok := g.idReferences[role.ClientId]; ok {
+			name = fmt.Sprintf("%s_%s", clientResource.name, role.Name)
+		}
+
+		if _, err := g.add(ctx, "keycloak_role", name, fmt.Sprintf("%s/%s", realm.Realm, role.Id), "roles.tf"); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (g *Generator) discoverRealmRoles(ctx context.Context, realm *keycloak.Realm) error {
+	roles, err := g.keycloakClient.GetRealmRoles(ctx, realm.Realm)
+	if err != nil {
+		return err
+	}
+
+	for _, role := range roles {
+		if !g.includeBuiltIn && (contains(builtInRealmRoleNames, role.Name) || role.Name == fmt.Sprintf("default-roles-%s", strings.ToLower(realm.Realm))) {
+			continue
+		}
+
+		if _, err := g.add(ctx, "keycloak_role", role.Name, fmt.Sprintf("%s/%s", realm.Realm, role.Id), "roles.tf"); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (g *Generator) discoverGroups(ctx context.Context, realm *keycloak.Realm) error {
+	groups, err := g.keycloakClient.GetGroups(ctx, realm.Realm)
+	if err != nil {
+		return err
+	}
+
+	var discoverGroups func(groups []*keycloak.Group) error
+	discoverGroups = func(groups []*keycloak.Group) error {
+		for _, group := range groups {
+			if _, err := g.add(ctx, "keycloak_group", group.Path, fmt.Sprintf("%s/%s", realm.Realm, group.Id), "groups.tf"); err != nil {
+				return err
+			}
+
+			if err := discoverGroups(group.SubGroups); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	return discoverGroups(groups)
+}
+
+func (g *Generator) discoverAuthenticationFlows(ctx context.Context, realm *keycloak.Realm) error {
+	authenticationFlows, err := g.keycloakClient.ListAuthenticationFlows(ctx, realm.Realm)
+	if err != nil {
+		return err
+	}
+
+	for _, authenticationFlow := range authenticationFlows {
+		if !authenticationFlow.TopLevel || (authenticationFlow.BuiltIn && !g.includeBuiltIn) {
+			continue
+		}
+
+		flowResource, err := g.add(ctx, "keycloak_authentication_flow", authenticationFlow.Alias, fmt.Sprintf("%s/%s", realm.Realm, authenticationFlow.Id), "authentication.tf")
+		if err != nil {
+			return err
+		}
+		if flowResource == nil {
+			continue
+		}
+
+		executions, err := g.keycloakClient.ListAuthenticationExecutions(ctx, realm.Realm, authenticationFlow.Alias)
+		if err != nil {
+			return err
+		}
+
+		sort.Sort(executions)
+
+		// executions are returned as a flattened tree, the parent of an execution is the closest preceding subflow one level above it
+		parentFlowAliases := []string{authenticationFlow.Alias}
+		for _, execution := range executions {
+			if execution.Level+1 > len(parentFlowAliases) {
+				continue
+			}
+
+			parentFlowAliases = parentFlowAliases[:execution.Level+1]
+			parentFlowAlias := parentFlowAliases[execution.Level]
+
+			if execution.AuthenticationFlow {
+				subFlowResource, err := g.add(ctx, "keycloak_authentication_subflow", fmt.Sprintf("%s_%s", authenticationFlow.Alias, execution.Id), fmt.Sprintf("%s/%s/%s", realm.Realm, parentFlowAlias, execution.FlowId), "authentication.tf")
+				if err != nil {
+					return err
+				}
+				if subFlowResource == nil {
+					continue
+				}
+
+				subFlowAlias := subFlowResource.data.Get("alias").(string)
+				g.rename(subFlowResource, fmt.Sprintf("%s_%s", authenticationFlow.Alias, subFlowAlias))
+				parentFlowAliases = append(parentFlowAliases, subFlowAlias)
+
+				continue
+			}
+
+			name := fmt.Sprintf("%s_%s", parentFlowAlias, execution.ProviderId)
+			if _, err := g.add(ctx, "keycloak_authentication_execution", name, fmt.Sprintf("%s/%s/%s", realm.Realm, parentFlowAlias, execution.Id), "authentication.tf"); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (g *Generator) discoverIdentityProviders(ctx context.Context, realm *keycloak.Realm) error {
+	identityProviders, err := g.keycloakClient.GetIdentityProviders(ctx, realm.Realm)
+	if err != nil {
+		return err
+	}
+
+	for _, identityProvider := range identityProviders {
+		resourceType, ok := identityProviderResourceTypes[identityProvider.ProviderId]
+		if !ok {
+			fmt.Fprintf(g.log, "skipping identity provider %s: provider %s is not supported\n", identityProvider.Alias, identityProvider.ProviderId)
+			continue
+		}
+
+		identityProviderResource, err := g.add(ctx, resourceType, identityProvider.Alias, fmt.Sprintf("%s/%s", realm.Realm, identityProvider.Alias), "identity_providers.tf")
+		if err != nil {
+			return err
+		}
+		if identityProviderResource == nil {
+			continue
+		}
+
+		mappers, err := g.keycloakClient.GetIdentityProviderMappers(ctx, realm.Realm, identityProvider.Alias)
+		if err != nil {
+			return err
+		}
+
+		for _, mapper := range mappers {
+			name := fmt.Sprintf("%s_%s", identityProvider.Alias, mapper.Name)
+			importId := fmt.Sprintf("%s/%s/%s", realm.Realm, identityProvider.Alias, mapper.Id)
+			if _, err := g.add(ctx, "keycloak_custom_identity_provider_mapper", name, importId, "identity_providers.tf"); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (g *Generator) discoverLdapUserFederations(ctx context.Context, realm *keycloak.Realm) error {
+	ldapUserFederations, err := g.keycloakClient.GetLdapUserFederations(ctx, realm.Realm, realm.Id)
+	if err != nil {
+		return err
+	}
+
+	for _, ldapUserFederation := range ldapUserFederations {
+		ldapResource, err := g.add(ctx, "keycloak_ldap_user_federation", ldapUserFederation.Name, fmt.Sprintf("%s/%s", realm.Realm, ldapUserFederation.Id), "user_federation.tf")
+		if err != nil {
+			return err
+		}
+		if ldapResource == nil {
+			continue
+		}
+
+		mappers, err := g.keycloakClient.GetLdapUserFederationMappers(ctx, realm.Realm, ldapUserFederation.Id)
+		if err != nil {
+			return err
+		}
+
+		for _, mapper := range *mappers {
+			resourceType, id, name := ldapMapperResource(mapper)
+			if resourceType == "" {
+				continue
+			}
+
+			importId := fmt.Sprintf("%s/%s/%s", realm.Realm, ldapUserFederation.Id, id)
+			if _, err := g.add(ctx, resourceType, fmt.Sprintf("%s_%s", ldapUserFederation.Name, name), importId, "user_federation.tf"); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func ldapMapperResource(mapper interface{}) (string, string, string) {
+	switch m := mapper.(type) {
+	case *keycloak.LdapFullNameMapper:
+		return "keycloak_ldap_full_name_mapper", m.Id, m.Name
+	case *keycloak.LdapGroupMapper:
+		return "keycloak_ldap_group_mapper", m.Id, m.Name
+	case *keycloak.LdapHardcodedGroupMapper:
+		return "keycloak_ldap_hardcoded_group_mapper", m.Id, m.Name
+	case *keycloak.LdapHardcodedRoleMapper:
+		return "keycloak_ldap_hardcoded_role_mapper", m.Id, m.Name
+	case *keycloak.LdapHardcodedAttributeMapper:
+		return "keycloak_ldap_hardcoded_attribute_mapper", m.Id, m.Name
+	case *keycloak.LdapMsadLdsUserAccountControlMapper:
+		return "keycloak_ldap_msad_lds_user_account_control_mapper", m.Id, m.Name
+	case *keycloak.LdapMsadUserAccountControlMapper:
+		return "keycloak_ldap_msad_user_account_control_mapper", m.Id, m.Name
+	case *keycloak.LdapUserAttributeMapper:
+		return "keycloak_ldap_user_attribute_mapper", m.Id, m.Name
+	case *keycloak.LdapRoleMapper:
+		return "keycloak_ldap_role_mapper", m.Id, m.Name
+	}
+
+	return "", "", ""
+}
+
+// add imports and reads a single object using the provider's resource implementation, in the same way `terraform import` would.
+// nil is returned when the object could not be read.
+func (g *Generator) add(ctx context.Context, resourceType, name, importId, file string) (*generatedResource, error) {
+	resource, ok := g.provider.ResourcesMap[resourceType]
+	if !ok {
+		return nil, fmt.Errorf("resource %s is not supported by this provider", resourceType)
+	}
+
+	data := resource.Data(nil)
+	data.SetId(importId)
+
+	if resource.Importer != nil && resource.Importer.StateContext != nil {
+		imported, err := resource.Importer.StateContext(ctx, data, g.keycloakClient)
+		if err != nil {
+			fmt.Fprintf(g.log, "skipping %s %s: %s\n", resourceType, importId, err)
+			return nil, nil
+		}
+		data = imported[0]
+	}
+
+	diags := resource.ReadContext(ctx, data, g.keycloakClient)
+	if diags.HasError() {
+		fmt.Fprintf(g.log, "skipping %s %s: %s\n", resourceType, importId, diags[0].Summary)
+		return nil, nil
+	}
+	if data.Id() == "" {
+		fmt.Fprintf(g.log, "skipping %s %s: not found\n", resourceType, importId)
+		return nil, nil
+	}
+
+	generated := &generatedResource{
+		resourceType: resourceType,
+		importId:     importId,
+		file:         file,
+		data:         data,
+	}
+	g.rename(generated, name)
+
+	g.resources = append(g.resources, generated)
+
+	switch resourceType {
+	case "keycloak_realm":
+		// the realm is referenced by name, and every other resource uses it
+	case "keycloak_authentication_flow", "keycloak_authentication_subflow":
+		g.flowAliasReferences[data.Get("alias").(string)] = generated
+		g.idReferences[data.Id()] = generated
+	case "keycloak_oidc_identity_provider", "keycloak_oidc_google_identity_provider", "keycloak_saml_identity_provider":
+		g.identityProviderAliasReferences[data.Get("alias").(string)] = generated
+	default:
+		g.idReferences[data.Id()] = generated
+	}
+
+	return generated, nil
+}
+
+// rename gives the resource a unique label, based on the name of the object it was generated from
+func (g *Generator) rename(resource *generatedResource, name string) {
+	if resource.name != "" {
+		delete(g.names, resource.resourceType+"."+resource.name)
+	}
+
+	label := invalidLabelCharacters.ReplaceAllString(strings.ToLower(name), "_")
+	label = strings.Trim(label, "_")
+	if label == "" || (label[0] >= '0' && label[0] <= '9') {
+		label = "_" + label
+	}
+
+	unique := label
+	for i := 2; g.names[resource.resourceType+"."+unique]; i++ {
+		unique = fmt.Sprintf("%s_%d", label, i)
+	}
+
+	g.names[resource.resourceType+"."+unique] = true
+	resource.name = unique
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,288 @@
+package generator
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/zclconf/go-cty/cty"
+)
+
+const (
+	importsFile   = "imports.tf"
+	variablesFile = "variables.tf"
+)
+
+// Write renders every discovered resource into .tf files within outputDir, along with an import block for each of them.
+// Sensitive attributes cannot be read back from keycloak, so they are replaced with variables.
+func (g *Generator) Write(outputDir string) error {
+	files := map[string]*hclwrite.File{}
+	var fileNames []string
+
+	getFile := func(name string) *hclwrite.File {
+		if _, ok := files[name]; !ok {
+			files[name] = hclwrite.NewEmptyFile()
+			fileNames = append(fileNames, name)
+		}
+
+		return files[name]
+	}
+
+	var variables []string
+	for _, resource := range g.resources {
+		body := getFile(resource.file).Body()
+		if len(body.Blocks()) != 0 {
+			body.AppendNewline()
+		}
+
+		block := body.AppendNewBlock("resource", []string{resource.resourceType, resource.name})
+		variables = append(variables, g.writeBody(block.Body(), resource, g.provider.ResourcesMap[resource.resourceType].Schema, resourceDataValues(resource.data, g.provider.ResourcesMap[resource.resourceType].Schema))...)
+
+		importsBody := getFile(importsFile).Body()
+		if len(importsBody.Blocks()) != 0 {
+			importsBody.AppendNewline()
+		}
+
+		importBlock := importsBody.AppendNewBlock("import", nil)
+		importBlock.Body().SetAttributeTraversal("to", hcl.Traversal{
+			hcl.TraverseRoot{Name: resource.resourceType},
+			hcl.TraverseAttr{Name: resource.name},
+		})
+		importBlock.Body().SetAttributeValue("id", cty.StringVal(resource.importId))
+	}
+
+	for i, variable := range variables {
+		body := getFile(variablesFile).Body()
+		if i != 0 {
+			body.AppendNewline()
+		}
+
+		variableBody := body.AppendNewBlock("variable", []string{variable}).Body()
+		variableBody.SetAttributeTraversal("type", hcl.Traversal{hcl.TraverseRoot{Name: "string"}})
+		variableBody.SetAttributeValue("sensitive", cty.True)
+	}
+
+	if err := os.MkdirAll(outputDir, 0755); err != nil {
+		return err
+	}
+
+	for _, name := range fileNames {
+		if err := os.WriteFile(filepath.Join(outputDir, name), hclwrite.Format(files[name].Bytes()), 0644); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func resourceDataValues(data *schema.ResourceData, schemaMap map[string]*schema.Schema) map[string]interface{} {
+	values := make(map[string]interface{}, len(schemaMap))
+	for key := range schemaMap {
+		values[key] = data.Get(key)
+	}
+
+	return values
+}
+
+// writeBody writes every configurable attribute and nested block of the given schema, and returns the names of the
+// variables that were used in place of sensitive attributes
+func (g *Generator) writeBody(body *hclwrite.Body, resource *generatedResource, schemaMap map[string]*schema.Schema, values map[string]interface{}) []string {
+	var variables []string
+
+	keys := make([]string, 0, len(schemaMap))
+	for key := range schemaMap {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	// attributes are written before nested blocks, which is the order `terraform fmt` users expect
+	for _, key := range keys {
+		attributeSchema := schemaMap[key]
+		if _, isBlock := attributeSchema.Elem.(*schema.Resource); isBlock || !isConfigurable(attributeSchema, values[key]) {
+			continue
+		}
+
+		if attributeSchema.Sensitive {
+			variable := fmt.Sprintf("%s_%s", resource.name, key)
+			body.SetAttributeTraversal(key, hcl.Traversal{hcl.TraverseRoot{Name: "var"}, hcl.TraverseAttr{Name: variable}})
+			variables = append(variables, variable)
+			continue
+		}
+
+		body.SetAttributeRaw(key, g.tokensForValue(resource, key, attributeSchema, values[key]))
+	}
+
+	wroteAttributes := len(body.Attributes()) != 0
+
+	for _, key := range keys {
+		attributeSchema := schemaMap[key]
+		elem, isBlock := attributeSchema.Elem.(*schema.Resource)
+		if !isBlock || !isConfigurable(attributeSchema, values[key]) {
+			continue
+		}
+
+		for _, element := range listValue(values[key]) {
+			elementValues, ok := element.(map[string]interface{})
+			if !ok {
+				continue
+			}
+
+			if wroteAttributes {
+				body.AppendNewline()
+				wroteAttributes = false
+			}
+
+			nestedBlock := body.AppendNewBlock(key, nil)
+			variables = append(variables, g.writeBody(nestedBlock.Body(), resource, elem.Schema, elementValues)...)
+		}
+	}
+
+	return variables
+}
+
+// isConfigurable decides whether an attribute needs to be written. Computed only and deprecated attributes are never
+// written, and optional attributes are only written when they differ from their default.
+func isConfigurable(attributeSchema *schema.Schema, value interface{}) bool {
+	if attributeSchema.Computed && !attributeSchema.Optional {
+		return false
+	}
+
+	if attributeSchema.Deprecated != "" {
+		return false
+	}
+
+	if attributeSchema.Required {
+		return true
+	}
+
+	if attributeSchema.Default != nil {
+		return !reflect.DeepEqual(attributeSchema.Default, value)
+	}
+
+	return !isZero(value)
+}
+
+func isZero(value interface{}) bool {
+	switch v := value.(type) {
+	case nil:
+		return true
+	case string:
+		return v == ""
+	case int:
+		return v == 0
+	case float64:
+		return v == 0
+	case bool:
+		return !v
+	case *schema.Set:
+		return v.Len() == 0
+	case []interface{}:
+		return len(v) == 0
+	case map[string]interface{}:
+		return len(v) == 0
+	}
+
+	return false
+}
+
+func listValue(value interface{}) []interface{} {
+	switch v := value.(type) {
+	case *schema.Set:
+		return v.List()
+	case []interface{}:
+		return v
+	}
+
+	return nil
+}
+
+func (g *Generator) tokensForValue(resource *generatedResource, key string, attributeSchema *schema.Schema, value interface{}) hclwrite.Tokens {
+	switch attributeSchema.Type {
+	case schema.TypeString:
+		s, _ := value.(string)
+		if reference := g.reference(resource, key, s); reference != nil {
+			return hclwrite.TokensForTraversal(reference)
+		}
+
+		return hclwrite.TokensForValue(cty.StringVal(s))
+	case schema.TypeBool:
+		b, _ := value.(bool)
+		return hclwrite.TokensForValue(cty.BoolVal(b))
+	case schema.TypeInt:
+		i, _ := value.(int)
+		return hclwrite.TokensForValue(cty.NumberIntVal(int64(i)))
+	case schema.TypeFloat:
+		f, _ := value.(float64)
+		return hclwrite.TokensForValue(cty.NumberFloatVal(f))
+	case schema.TypeList, schema.TypeSet:
+		elemSchema, ok := attributeSchema.Elem.(*schema.Schema)
+		if !ok {
+			elemSchema = &schema.Schema{Type: schema.TypeString}
+		}
+
+		var elements []hclwrite.Tokens
+		for _, element := range listValue(value) {
+			elements = append(elements, g.tokensForValue(resource, key, elemSchema, element))
+		}
+
+		return hclwrite.TokensForTuple(elements)
+	case schema.TypeMap:
+		m, _ := value.(map[string]interface{})
+
+		keys := make([]string, 0, len(m))
+		for k := range m {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		var attributes []hclwrite.ObjectAttrTokens
+		for _, k := range keys {
+			attributes = append(attributes, hclwrite.ObjectAttrTokens{
+				Name:  hclwrite.TokensForValue(cty.StringVal(k)),
+				Value: hclwrite.TokensForValue(cty.StringVal(fmt.Sprint(m[k]))),
+			})
+		}
+
+		return hclwrite.TokensForObject(attributes)
+	}
+
+	return hclwrite.TokensForValue(cty.StringVal(fmt.Sprint(value)))
+}
+
+// reference returns a traversal to another generated resource when the value of an attribute is the ID or alias of
+// that resource, so the generated configuration doesn't contain hard-coded IDs.
+func (g *Generator) reference(resource *generatedResource, key, value string) hcl.Traversal {
+	var target *generatedResource
+	attribute := "id"
+
+	switch {
+	case key == "realm_id" || key == "realm":
+		if value == g.realmId && len(g.resources) != 0 {
+			target = g.resources[0]
+		}
+	case key == "parent_flow_alias":
+		target = g.flowAliasReferences[value]
+		attribute = "alias"
+	case key == "identity_provider_alias":
+		target = g.identityProviderAliasReferences[value]
+		attribute = "alias"
+	case strings.HasSuffix(key, "_id") || strings.HasSuffix(key, "_ids") || key == "composite_roles":
+		target = g.idReferences[value]
+	}
+
+	if target == nil || target == resource {
+		return nil
+	}
+
+	return hcl.Traversal{
+		hcl.TraverseRoot{Name: target.resourceType},
+		hcl.TraverseAttr{Name: target.name},
+		hcl.TraverseAttr{Name: attribute},
+	}
+}
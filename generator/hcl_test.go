@@ -0,0 +1,220 @@
+package generator
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func testProvider() *schema.Provider {
+	return &schema.Provider{
+		ResourcesMap: map[string]*schema.Resource{
+			"keycloak_realm": {
+				Schema: map[string]*schema.Schema{
+					"realm": {
+						Type:     schema.TypeString,
+						Required: true,
+					},
+					"enabled": {
+						Type:     schema.TypeBool,
+						Optional: true,
+						Default:  true,
+					},
+					"internal_id": {
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+				},
+			},
+			"keycloak_group": {
+				Schema: map[string]*schema.Schema{
+					"realm_id": {
+						Type:     schema.TypeString,
+						Required: true,
+					},
+					"parent_id": {
+						Type:     schema.TypeString,
+						Optional: true,
+					},
+					"name": {
+						Type:     schema.TypeString,
+						Required: true,
+					},
+					"attributes": {
+						Type:     schema.TypeMap,
+						Optional: true,
+					},
+				},
+			},
+			"keycloak_ldap_user_federation": {
+				Schema: map[string]*schema.Schema{
+					"realm_id": {
+						Type:     schema.TypeString,
+						Required: true,
+					},
+					"bind_credential": {
+						Type:      schema.TypeString,
+						Optional:  true,
+						Sensitive: true,
+					},
+					"kerberos": {
+						Type:     schema.TypeList,
+						Optional: true,
+						MaxItems: 1,
+						Elem: &schema.Resource{
+							Schema: map[string]*schema.Schema{
+								"server_principal": {
+									Type:     schema.TypeString,
+									Required: true,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func addTestResource(t *testing.T, g *Generator, resourceType, name, id, file string, values map[string]interface{}) *generatedResource {
+	data := g.provider.ResourcesMap[resourceType].Data(nil)
+	data.SetId(id)
+	for key, value := range values {
+		if err := data.Set(key, value); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	resource := &generatedResource{
+		resourceType: resourceType,
+		importId:     id,
+		file:         file,
+		data:         data,
+	}
+	g.rename(resource, name)
+	g.resources = append(g.resources, resource)
+
+	if resourceType != "keycloak_realm" {
+		g.idReferences[id] = resource
+	}
+
+	return resource
+}
+
+func readGeneratedFile(t *testing.T, dir, name string) string {
+	content, err := os.ReadFile(filepath.Join(dir, name))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return string(content)
+}
+
+func TestGeneratorWrite(t *testing.T) {
+	g := NewGenerator(testProvider(), nil, "my-realm", false, io.Discard)
+
+	addTestResource(t, g, "keycloak_realm", "my-realm", "my-realm", "realm.tf", map[string]interface{}{
+		"realm":       "my-realm",
+		"enabled":     true,
+		"internal_id": "8f3a",
+	})
+	addTestResource(t, g, "keycloak_group", "/parent", "a1", "groups.tf", map[string]interface{}{
+		"realm_id": "my-realm",
+		"name":     "parent",
+		"attributes": map[string]interface{}{
+			"team": "platform",
+		},
+	})
+	addTestResource(t, g, "keycloak_group", "/parent/child", "b2", "groups.tf", map[string]interface{}{
+		"realm_id":  "my-realm",
+		"parent_id": "a1",
+		"name":      "child",
+	})
+	addTestResource(t, g, "keycloak_ldap_user_federation", "corporate ldap", "c3", "user_federation.tf", map[string]interface{}{
+		"realm_id":        "my-realm",
+		"bind_credential": "**********",
+		"kerberos": []interface{}{
+			map[string]interface{}{"server_principal": "HTTP/host@EXAMPLE.COM"},
+		},
+	})
+
+	dir := t.TempDir()
+	if err := g.Write(dir); err != nil {
+		t.Fatal(err)
+	}
+
+	realm := readGeneratedFile(t, dir, "realm.tf")
+	if !strings.Contains(realm, `resource "keycloak_realm" "my_realm"`) || !strings.Contains(realm, `realm = "my-realm"`) {
+		t.Errorf("unexpected realm.tf:\n%s", realm)
+	}
+	if strings.Contains(realm, "enabled") || strings.Contains(realm, "internal_id") {
+		t.Errorf("expected default and computed attributes to be omitted from realm.tf:\n%s", realm)
+	}
+
+	groups := readGeneratedFile(t, dir, "groups.tf")
+	for _, expected := range []string{
+		`resource "keycloak_group" "parent_child"`,
+		"realm_id  = keycloak_realm.my_realm.id",
+		"parent_id = keycloak_group.parent.id",
+		`"team" = "platform"`,
+	} {
+		if !strings.Contains(groups, expected) {
+			t.Errorf("expected groups.tf to contain %q:\n%s", expected, groups)
+		}
+	}
+
+	userFederation := readGeneratedFile(t, dir, "user_federation.tf")
+	for _, expected := range []string{
+		"bind_credential = var.corporate_ldap_bind_credential",
+		"kerberos {",
+		`server_principal = "HTTP/host@EXAMPLE.COM"`,
+	} {
+		if !strings.Contains(userFederation, expected) {
+			t.Errorf("expected user_federation.tf to contain %q:\n%s", expected, userFederation)
+		}
+	}
+
+	variables := readGeneratedFile(t, dir, "variables.tf")
+	if !strings.Contains(variables, `variable "corporate_ldap_bind_credential"`) || !strings.Contains(variables, "sensitive = true") {
+		t.Errorf("unexpected variables.tf:\n%s", variables)
+	}
+
+	imports := readGeneratedFile(t, dir, "imports.tf")
+	for _, expected := range []string{
+		"to = keycloak_realm.my_realm",
+		`id = "my-realm"`,
+		"to = keycloak_group.parent_child",
+		`id = "b2"`,
+	} {
+		if !strings.Contains(imports, expected) {
+			t.Errorf("expected imports.tf to contain %q:\n%s", expected, imports)
+		}
+	}
+}
+
+func TestGeneratorRename(t *testing.T) {
+	g := NewGenerator(testProvider(), nil, "my-realm", false, io.Discard)
+
+	first := &generatedResource{resourceType: "keycloak_group"}
+	g.rename(first, "My Group")
+
+	second := &generatedResource{resourceType: "keycloak_group"}
+	g.rename(second, "my-group")
+
+	third := &generatedResource{resourceType: "keycloak_group"}
+	g.rename(third, "1st group")
+
+	if first.name != "my_group" {
+		t.Errorf("expected my_group, got %s", first.name)
+	}
+	if second.name != "my_group_2" {
+		t.Errorf("expected my_group_2, got %s", second.name)
+	}
+	if third.name != "_1st_group" {
+		t.Errorf("expected _1st_group, got %s", third.name)
+	}
+}
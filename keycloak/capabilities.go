@@ -0,0 +1,289 @@
+package keycloak
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/go-version"
+)
+
+const (
+	DistributionKeycloak               = "keycloak"
+	DistributionRedHatSSO              = "rh-sso"
+	DistributionRedHatBuildOfKeycloak  = "rhbk"
+	redHatBuildOfKeycloakVersionSuffix = `\.redhat-\w+`
+)
+
+// https://access.redhat.com/articles/2342881
+var redHatSSO7VersionMap = map[int]string{
+	6: "18.0.0",
+	5: "15.0.6",
+	4: "9.0.17",
+	3: "4.8.3",
+	2: "3.4.3",
+	1: "2.5.5",
+	0: "1.9.8",
+}
+
+var redHatBuildOfKeycloakVersionPattern = regexp.MustCompile(redHatBuildOfKeycloakVersionSuffix)
+
+// Feature is a capability of the server that a resource may depend on. Profile features are named as in the features
+// of serverinfo, and can be enabled or disabled with the --features and --features-disabled options of the server.
+type Feature string
+
+const (
+	FeatureAdminFineGrainedAuthz  Feature = "ADMIN_FINE_GRAINED_AUTHZ"
+	FeatureCiba                   Feature = "CIBA"
+	FeatureClientPolicies         Feature = "CLIENT_POLICIES"
+	FeatureDeclarativeUserProfile Feature = "DECLARATIVE_USER_PROFILE"
+	FeatureDeviceFlow             Feature = "DEVICE_FLOW"
+	FeatureDynamicScopes          Feature = "DYNAMIC_SCOPES"
+	FeatureOrganization           Feature = "ORGANIZATION"
+	FeaturePar                    Feature = "PAR"
+	FeatureRecoveryCodes          Feature = "RECOVERY_CODES"
+	FeatureTokenExchange          Feature = "TOKEN_EXCHANGE"
+	FeatureUpdateEmail            Feature = "UPDATE_EMAIL"
+
+	// capabilities that only depend on the version of the server
+
+	FeatureDefaultRoles   Feature = "DEFAULT_ROLES"
+	FeatureEcdhKeys       Feature = "ECDH_KEYS"
+	FeatureEddsaKeys      Feature = "EDDSA_KEYS"
+	FeatureLdapGroupsPath Feature = "LDAP_GROUPS_PATH"
+	FeatureSmtpTokenAuth  Feature = "SMTP_TOKEN_AUTH"
+)
+
+type featureDefinition struct {
+	minVersion Version
+	// profile features can be turned off, so they also have to be enabled on the server
+	profile bool
+}
+
+var featureDefinitions = map[Feature]featureDefinition{
+	FeatureAdminFineGrainedAuthz:  {minVersion: Version_6, profile: true},
+	FeatureCiba:                   {minVersion: Version_12, profile: true},
+	FeatureClientPolicies:         {minVersion: Version_13, profile: true},
+	FeatureDeclarativeUserProfile: {minVersion: Version_14, profile: true},
+	FeatureDeviceFlow:             {minVersion: Version_13, profile: true},
+	FeatureDynamicScopes:          {minVersion: Version_18, profile: true},
+	FeatureOrganization:           {minVersion: Version_25, profile: true},
+	FeaturePar:                    {minVersion: Version_13, profile: true},
+	FeatureRecoveryCodes:          {minVersion: Version_24, profile: true},
+	FeatureTokenExchange:          {minVersion: Version_6, profile: true},
+	FeatureUpdateEmail:            {minVersion: Version_18, profile: true},
+	FeatureDefaultRoles:           {minVersion: Version_13},
+	FeatureEcdhKeys:               {minVersion: Version_25},
+	FeatureEddsaKeys:              {minVersion: Version_23},
+	FeatureLdapGroupsPath:         {minVersion: Version_11},
+	FeatureSmtpTokenAuth:          {minVersion: Version_26_1},
+}
+
+// ServerCapabilities describes what the server the provider talks to supports. It is built from serverinfo once per
+// client, so resources can check for the features they need without making more requests.
+type ServerCapabilities struct {
+	// Version is the version of upstream keycloak the server corresponds to, also for Red Hat distributions
+	Version *version.Version
+	// ServerVersion is the version as reported by the server
+	ServerVersion string
+	// Distribution is one of DistributionKeycloak, DistributionRedHatSSO or DistributionRedHatBuildOfKeycloak
+	Distribution string
+
+	ServerInfo *ServerInfo
+
+	// features holds the profile features reported by the server, and whether they are enabled
+	features         map[Feature]bool
+	disabledFeatures map[Feature]bool
+}
+
+// UnsupportedFeatureError is returned when a feature is missing from the server, either because the server is too old
+// or because the feature has been disabled
+type UnsupportedFeatureError struct {
+	Feature       Feature
+	MinVersion    Version
+	ServerVersion string
+	Disabled      bool
+}
+
+func (e *UnsupportedFeatureError) Error() string {
+	if e.Disabled {
+		return fmt.Sprintf("feature %s is disabled on the server, it can be enabled with --features=%s", e.Feature, e.Feature.flag())
+	}
+
+	if e.MinVersion != "" {
+		return fmt.Sprintf("feature %s requires Keycloak %s or higher, but the server is running %s", e.Feature, e.MinVersion, e.ServerVersion)
+	}
+
+	return fmt.Sprintf("feature %s is not supported by the server, which is running %s", e.Feature, e.ServerVersion)
+}
+
+// flag is the name of the feature in the --features option of the server
+func (feature Feature) flag() string {
+	return strings.ReplaceAll(strings.ToLower(string(feature)), "_", "-")
+}
+
+func newServerCapabilities(serverInfo *ServerInfo, redHatSSO bool) (*ServerCapabilities, error) {
+	serverVersion := serverInfo.SystemInfo.ServerVersion
+
+	capabilities := &ServerCapabilities{
+		ServerVersion:    serverVersion,
+		Distribution:     DistributionKeycloak,
+		ServerInfo:       serverInfo,
+		disabledFeatures: map[Feature]bool{},
+	}
+
+	if strings.Contains(serverVersion, ".GA") {
+		serverVersion = strings.ReplaceAll(serverVersion, ".GA", "")
+		capabilities.Distribution = DistributionRedHatSSO
+	} else if redHatBuildOfKeycloakVersionPattern.MatchString(serverVersion) {
+		// the Red Hat build of Keycloak shares its major and minor version with the upstream release it is based on
+		serverVersion = redHatBuildOfKeycloakVersionPattern.ReplaceAllString(serverVersion, "")
+		capabilities.Distribution = DistributionRedHatBuildOfKeycloak
+	}
+
+	v, err := version.NewVersion(serverVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	if redHatSSO || (capabilities.Distribution == DistributionRedHatSSO && v.Segments()[0] == 7) {
+		keycloakVersion, ok := redHatSSO7VersionMap[v.Segments()[1]]
+		if !ok {
+			return nil, fmt.Errorf("unknown Red Hat SSO version %s", serverInfo.SystemInfo.ServerVersion)
+		}
+
+		v, err = version.NewVersion(keycloakVersion)
+		if err != nil {
+			return nil, err
+		}
+
+		capabilities.Distribution = DistributionRedHatSSO
+	}
+
+	capabilities.Version = v
+
+	if serverInfo.Features != nil {
+		capabilities.features = map[Feature]bool{}
+		for _, feature := range serverInfo.Features {
+			capabilities.features[Feature(feature.Name)] = feature.Enabled
+		}
+	}
+
+	for _, feature := range serverInfo.ProfileInfo.DisabledFeatures {
+		capabilities.disabledFeatures[Feature(feature)] = true
+	}
+
+	return capabilities, nil
+}
+
+func (capabilities *ServerCapabilities) VersionIsGreaterThanOrEqualTo(versionString Version) bool {
+	v, err := version.NewVersion(string(versionString))
+	if err != nil {
+		return false
+	}
+
+	return capabilities.Version.GreaterThanOrEqual(v)
+}
+
+// RequireFeature returns an UnsupportedFeatureError if a feature isn't available on the server
+func (capabilities *ServerCapabilities) RequireFeature(feature Feature) error {
+	unsupported := &UnsupportedFeatureError{
+		Feature:       feature,
+		ServerVersion: capabilities.ServerVersion,
+	}
+
+	definition, known := featureDefinitions[feature]
+	if known && !capabilities.VersionIsGreaterThanOrEqualTo(definition.minVersion) {
+		unsupported.MinVersion = definition.minVersion
+		return unsupported
+	}
+
+	if known && !definition.profile {
+		return nil
+	}
+
+	if enabled, reported := capabilities.features[feature]; reported {
+		if !enabled {
+			unsupported.Disabled = true
+			return unsupported
+		}
+		return nil
+	}
+
+	if capabilities.disabledFeatures[feature] {
+		unsupported.Disabled = true
+		return unsupported
+	}
+
+	// features that aren't reported by the server anymore have either been removed, or made a permanent part of it.
+	// for the features the provider knows about, the version check above tells which one it is.
+	if !known {
+		return unsupported
+	}
+
+	return nil
+}
+
+// FeatureIsEnabled reports whether a feature is available on the server
+func (capabilities *ServerCapabilities) FeatureIsEnabled(feature Feature) bool {
+	return capabilities.RequireFeature(feature) == nil
+}
+
+// ProviderIsInstalled reports whether the server has a provider with the given id, e.g. an authenticator or a
+// password policy
+func (capabilities *ServerCapabilities) ProviderIsInstalled(providerType, providerId string) bool {
+	return capabilities.ServerInfo.providerInstalled(providerType, providerId)
+}
+
+// GetServerCapabilities returns the capabilities of the server, which are loaded from serverinfo the first time
+func (keycloakClient *KeycloakClient) GetServerCapabilities(ctx context.Context) (*ServerCapabilities, error) {
+	keycloakClient.capabilitiesMutex.Lock()
+	defer keycloakClient.capabilitiesMutex.Unlock()
+
+	if keycloakClient.capabilities == nil {
+		err := keycloakClient.loadServerCapabilities(ctx)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return keycloakClient.capabilities, nil
+}
+
+// loadServerCapabilities must be called while holding capabilitiesMutex
+func (keycloakClient *KeycloakClient) loadServerCapabilities(ctx context.Context) error {
+	serverInfo, err := keycloakClient.GetServerInfo(ctx)
+	if err != nil {
+		return err
+	}
+
+	capabilities, err := newServerCapabilities(serverInfo, keycloakClient.redHatSSO)
+	if err != nil {
+		return err
+	}
+
+	keycloakClient.capabilities = capabilities
+
+	return nil
+}
+
+// RequireFeature returns an UnsupportedFeatureError if a feature isn't available on the server
+func (keycloakClient *KeycloakClient) RequireFeature(ctx context.Context, feature Feature) error {
+	capabilities, err := keycloakClient.GetServerCapabilities(ctx)
+	if err != nil {
+		return err
+	}
+
+	return capabilities.RequireFeature(feature)
+}
+
+// FeatureIsEnabled reports whether a feature is available on the server
+func (keycloakClient *KeycloakClient) FeatureIsEnabled(ctx context.Context, feature Feature) (bool, error) {
+	capabilities, err := keycloakClient.GetServerCapabilities(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	return capabilities.FeatureIsEnabled(feature), nil
+}
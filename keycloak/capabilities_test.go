@@ -0,0 +1,106 @@
+package keycloak
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestServerCapabilitiesVersions(t *testing.T) {
+	tests := []struct {
+		serverVersion string
+		redHatSSO     bool
+		version       string
+		distribution  string
+	}{
+		{serverVersion: "26.0.5", version: "26.0.5", distribution: DistributionKeycloak},
+		{serverVersion: "24.0.8.redhat-00001", version: "24.0.8", distribution: DistributionRedHatBuildOfKeycloak},
+		{serverVersion: "7.6.10.GA", version: "18.0.0", distribution: DistributionRedHatSSO},
+		{serverVersion: "7.3.9.GA", version: "4.8.3", distribution: DistributionRedHatSSO},
+		{serverVersion: "7.5.3", redHatSSO: true, version: "15.0.6", distribution: DistributionRedHatSSO},
+	}
+
+	for _, test := range tests {
+		capabilities, err := newServerCapabilities(&ServerInfo{SystemInfo: SystemInfo{ServerVersion: test.serverVersion}}, test.redHatSSO)
+		if err != nil {
+			t.Fatalf("%s: %v", test.serverVersion, err)
+		}
+
+		if capabilities.Version.String() != test.version || capabilities.Distribution != test.distribution {
+			t.Errorf("%s: expected %s %s, got %s %s", test.serverVersion, test.distribution, test.version, capabilities.Distribution, capabilities.Version)
+		}
+	}
+
+	if _, err := newServerCapabilities(&ServerInfo{SystemInfo: SystemInfo{ServerVersion: "7.9.0.GA"}}, false); err == nil {
+		t.Error("expected an unknown Red Hat SSO version to be rejected")
+	}
+}
+
+func TestServerCapabilitiesRequireFeature(t *testing.T) {
+	capabilities, err := newServerCapabilities(&ServerInfo{
+		SystemInfo: SystemInfo{ServerVersion: "24.0.5"},
+		Features: []FeatureInfo{
+			{Name: "TOKEN_EXCHANGE", Enabled: false},
+			{Name: "CIBA", Enabled: true},
+		},
+		ProfileInfo: ProfileInfo{DisabledFeatures: []string{"TOKEN_EXCHANGE"}},
+	}, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := capabilities.RequireFeature(FeatureCiba); err != nil {
+		t.Errorf("expected CIBA to be enabled, got %v", err)
+	}
+
+	// no longer reported by keycloak 24, where it can't be turned off anymore
+	if err := capabilities.RequireFeature(FeatureDeclarativeUserProfile); err != nil {
+		t.Errorf("expected the declarative user profile to be enabled, got %v", err)
+	}
+
+	var unsupported *UnsupportedFeatureError
+
+	err = capabilities.RequireFeature(FeatureTokenExchange)
+	if !errors.As(err, &unsupported) || !unsupported.Disabled || !strings.Contains(err.Error(), "--features=token-exchange") {
+		t.Errorf("expected token exchange to be disabled, got %v", err)
+	}
+
+	err = capabilities.RequireFeature(FeatureOrganization)
+	if !errors.As(err, &unsupported) || unsupported.Disabled || unsupported.MinVersion != Version_25 {
+		t.Errorf("expected organizations to require a newer version, got %v", err)
+	}
+
+	if !capabilities.FeatureIsEnabled(FeatureLdapGroupsPath) {
+		t.Error("expected version only features to be enabled")
+	}
+
+	if capabilities.FeatureIsEnabled(Feature("UNKNOWN")) {
+		t.Error("expected features the server doesn't report to be unsupported")
+	}
+}
+
+func TestKeycloakClientLoadsCapabilitiesOnce(t *testing.T) {
+	ctx := context.Background()
+	keycloakClient, server := newFakeKeycloakClient(t)
+
+	server.SetFeature("TOKEN_EXCHANGE", true)
+
+	// the capabilities were loaded during the initial login, so changes to the server aren't seen
+	if ok, err := keycloakClient.FeatureIsEnabled(ctx, FeatureTokenExchange); err != nil || ok {
+		t.Errorf("expected the capabilities from the initial login to be used, got %t %v", ok, err)
+	}
+	if err := keycloakClient.RequireFeature(ctx, FeatureOrganization); err != nil {
+		t.Errorf("expected organizations to be supported, got %v", err)
+	}
+
+	serverInfoRequests := 0
+	for _, request := range server.Requests() {
+		if strings.HasSuffix(request, "/serverinfo") {
+			serverInfoRequests++
+		}
+	}
+	if serverInfoRequests != 1 {
+		t.Errorf("expected serverinfo to be requested once, got %d requests", serverInfoRequests)
+	}
+}
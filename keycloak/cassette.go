@@ -0,0 +1,350 @@
+package keycloak
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+const (
+	// CassetteModeRecord saves every admin api request and its response to a cassette
+	CassetteModeRecord = "record"
+	// CassetteModeReplay serves responses from a cassette instead of sending requests to keycloak
+	CassetteModeReplay = "replay"
+)
+
+var CassetteModes = []string{CassetteModeRecord, CassetteModeReplay}
+
+// substitutions are only learned for tokens at least this long, so that a request with a changed setting (an enum, a
+// port, a priority) doesn't match a recorded request by accident. the names generated by acctest are much longer.
+const cassetteMinSubstitutionLength = 5
+
+var cassetteTokenPattern = regexp.MustCompile(`[A-Za-z0-9]+`)
+
+// errUnmatchedCassetteRequest is returned for requests that weren't recorded. Retrying them won't help.
+var errUnmatchedCassetteRequest = errors.New("no recorded interaction matches the request")
+
+// CassetteRecorder records the admin api requests sent by a KeycloakClient to cassettes, one file per name, and replays
+// them later without a keycloak server. Only one cassette can be used at a time, so tests that share a client have to
+// run one after another.
+//
+// Acceptance tests generate random names, so the requests sent during a replay won't be identical to the recorded ones.
+// When a request only differs from a recorded one in its long alphanumeric tokens, the recorded token is substituted
+// with the new one for the rest of the replay, in both requests and responses.
+type CassetteRecorder struct {
+	dir  string
+	mode string
+
+	mutex         sync.Mutex
+	cassette      *cassette
+	substitutions map[string]string
+}
+
+type cassette struct {
+	name         string
+	Interactions []*cassetteInteraction `json:"interactions"`
+	replayed     []bool
+}
+
+type cassetteInteraction struct {
+	Request  cassetteRequest  `json:"request"`
+	Response cassetteResponse `json:"response"`
+}
+
+type cassetteRequest struct {
+	Method string `json:"method"`
+	Path   string `json:"path"`
+	Query  string `json:"query,omitempty"`
+	Body   string `json:"body,omitempty"`
+}
+
+type cassetteResponse struct {
+	StatusCode  int    `json:"statusCode"`
+	ContentType string `json:"contentType,omitempty"`
+	Location    string `json:"location,omitempty"`
+	Body        string `json:"body,omitempty"`
+}
+
+func NewCassetteRecorder(dir, mode string) (*CassetteRecorder, error) {
+	if mode != CassetteModeRecord && mode != CassetteModeReplay {
+		return nil, fmt.Errorf("invalid cassette mode %s, expected one of %s", mode, strings.Join(CassetteModes, ", "))
+	}
+
+	return &CassetteRecorder{
+		dir:           dir,
+		mode:          mode,
+		substitutions: map[string]string{},
+	}, nil
+}
+
+func (recorder *CassetteRecorder) Mode() string {
+	return recorder.mode
+}
+
+// Start records to, or replays from, the cassette with the given name until Stop is called
+func (recorder *CassetteRecorder) Start(name string) error {
+	recorder.mutex.Lock()
+	defer recorder.mutex.Unlock()
+
+	if recorder.cassette != nil {
+		return fmt.Errorf("cannot start cassette %s while cassette %s is in use, tests using cassettes must not run in parallel", name, recorder.cassette.name)
+	}
+
+	cassette := &cassette{name: name}
+
+	if recorder.mode == CassetteModeReplay {
+		data, err := os.ReadFile(recorder.path(name))
+		if err != nil {
+			return fmt.Errorf("failed to read cassette %s: %v", name, err)
+		}
+
+		if err := json.Unmarshal(data, cassette); err != nil {
+			return fmt.Errorf("failed to parse cassette %s: %v", name, err)
+		}
+
+		cassette.replayed = make([]bool, len(cassette.Interactions))
+	}
+
+	recorder.cassette = cassette
+
+	return nil
+}
+
+// Stop saves the current cassette when recording
+func (recorder *CassetteRecorder) Stop() error {
+	recorder.mutex.Lock()
+	defer recorder.mutex.Unlock()
+
+	cassette := recorder.cassette
+	if cassette == nil {
+		return nil
+	}
+
+	recorder.cassette = nil
+
+	if recorder.mode != CassetteModeRecord {
+		return nil
+	}
+
+	data, err := json.MarshalIndent(cassette, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(recorder.dir, 0755); err != nil {
+		return err
+	}
+
+	return os.WriteFile(recorder.path(cassette.name), append(data, '\n'), 0644)
+}
+
+func (recorder *CassetteRecorder) path(name string) string {
+	return filepath.Join(recorder.dir, strings.ReplaceAll(name, "/", "_")+".json")
+}
+
+// record saves a request sent by sendRequest and the response it received, with secrets scrubbed
+func (recorder *CassetteRecorder) record(request *http.Request, requestBody []byte, response *http.Response, responseBody []byte) {
+	recorder.mutex.Lock()
+	defer recorder.mutex.Unlock()
+
+	if recorder.mode != CassetteModeRecord || recorder.cassette == nil {
+		return
+	}
+
+	recorder.cassette.Interactions = append(recorder.cassette.Interactions, &cassetteInteraction{
+		Request: cassetteRequest{
+			Method: request.Method,
+			Path:   request.URL.Path,
+			Query:  request.URL.RawQuery,
+			Body:   defaultRedactor.body(requestBody),
+		},
+		Response: cassetteResponse{
+			StatusCode:  response.StatusCode,
+			ContentType: response.Header.Get("Content-Type"),
+			Location:    response.Header.Get("Location"),
+			Body:        defaultRedactor.body(responseBody),
+		},
+	})
+}
+
+// replay finds the first interaction in the current cassette that hasn't been replayed yet and matches the request
+func (recorder *CassetteRecorder) replay(request *http.Request) (*http.Response, error) {
+	var requestBody []byte
+	if request.Body != nil {
+		var err error
+		requestBody, err = io.ReadAll(request.Body)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if strings.HasSuffix(request.URL.Path, "/protocol/openid-connect/token") {
+		// tokens aren't recorded, and any token is good enough for the replayed responses
+		return newCassetteResponse(request, http.StatusOK, "application/json", "", `{"access_token":"replayed","expires_in":3600,"token_type":"Bearer"}`), nil
+	}
+
+	recorder.mutex.Lock()
+	defer recorder.mutex.Unlock()
+
+	if recorder.cassette == nil {
+		return nil, fmt.Errorf("no cassette has been started: %w: %s %s", errUnmatchedCassetteRequest, request.Method, request.URL.String())
+	}
+
+	live := cassetteRequest{
+		Method: request.Method,
+		Path:   request.URL.Path,
+		Query:  request.URL.RawQuery,
+		Body:   defaultRedactor.body(requestBody),
+	}
+
+	// an identical request is preferred over one that only matches after learning new substitutions
+	for _, learn := range []bool{false, true} {
+		for i, interaction := range recorder.cassette.Interactions {
+			if recorder.cassette.replayed[i] {
+				continue
+			}
+
+			substitutions, ok := recorder.match(interaction.Request, live, learn)
+			if !ok {
+				continue
+			}
+
+			recorder.cassette.replayed[i] = true
+			recorder.substitutions = substitutions
+
+			replacer := recorder.replacer()
+			recorded := interaction.Response
+
+			return newCassetteResponse(request, recorded.StatusCode, recorded.ContentType, replacer.Replace(recorded.Location), replacer.Replace(recorded.Body)), nil
+		}
+	}
+
+	return nil, fmt.Errorf("cassette %s: %w: %s %s", recorder.cassette.name, errUnmatchedCassetteRequest, request.Method, request.URL.String())
+}
+
+// match compares a recorded request with a live one, and returns the substitutions that make them equal
+func (recorder *CassetteRecorder) match(recorded, live cassetteRequest, learn bool) (map[string]string, bool) {
+	if recorded.Method != live.Method {
+		return nil, false
+	}
+
+	substitutions := make(map[string]string, len(recorder.substitutions))
+	for token, replacement := range recorder.substitutions {
+		substitutions[token] = replacement
+	}
+
+	for _, values := range [][2]string{
+		{recorded.Path, live.Path},
+		{recorded.Query, live.Query},
+		{recorded.Body, live.Body},
+	} {
+		if !matchCassetteTokens(values[0], values[1], substitutions, learn) {
+			return nil, false
+		}
+	}
+
+	return substitutions, true
+}
+
+func (recorder *CassetteRecorder) replacer() *strings.Replacer {
+	var replacements []string
+	for token, replacement := range recorder.substitutions {
+		replacements = append(replacements, token, replacement)
+	}
+
+	return strings.NewReplacer(replacements...)
+}
+
+// matchCassetteTokens checks whether a recorded string equals a live one once its alphanumeric tokens are substituted.
+// When learn is set, new substitutions are added for long tokens that differ.
+func matchCassetteTokens(recorded, live string, substitutions map[string]string, learn bool) bool {
+	if recorded == live && len(substitutions) == 0 {
+		return true
+	}
+
+	recordedTokens := cassetteTokenPattern.FindAllStringIndex(recorded, -1)
+	liveTokens := cassetteTokenPattern.FindAllStringIndex(live, -1)
+	if len(recordedTokens) != len(liveTokens) {
+		return false
+	}
+
+	recordedEnd, liveEnd := 0, 0
+	for i := range recordedTokens {
+		recordedToken := recorded[recordedTokens[i][0]:recordedTokens[i][1]]
+		liveToken := live[liveTokens[i][0]:liveTokens[i][1]]
+
+		// everything between the tokens has to be identical
+		if recorded[recordedEnd:recordedTokens[i][0]] != live[liveEnd:liveTokens[i][0]] {
+			return false
+		}
+		recordedEnd, liveEnd = recordedTokens[i][1], liveTokens[i][1]
+
+		if replacement, ok := substitutions[recordedToken]; ok {
+			if replacement != liveToken {
+				return false
+			}
+			continue
+		}
+
+		if recordedToken == liveToken {
+			continue
+		}
+
+		if !learn || len(recordedToken) < cassetteMinSubstitutionLength || len(liveToken) < cassetteMinSubstitutionLength {
+			return false
+		}
+
+		substitutions[recordedToken] = liveToken
+	}
+
+	return recorded[recordedEnd:] == live[liveEnd:]
+}
+
+func newCassetteResponse(request *http.Request, statusCode int, contentType, location, body string) *http.Response {
+	header := http.Header{}
+	if contentType != "" {
+		header.Set("Content-Type", contentType)
+	}
+	if location != "" {
+		header.Set("Location", location)
+	}
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", statusCode, http.StatusText(statusCode)),
+		StatusCode:    statusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(strings.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       request,
+	}
+}
+
+// cassetteTransport serves requests from the current cassette when replaying, and passes them on otherwise
+type cassetteTransport struct {
+	recorder  func() *CassetteRecorder
+	transport http.RoundTripper
+}
+
+func (cassetteTransport *cassetteTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	if recorder := cassetteTransport.recorder(); recorder != nil && recorder.mode == CassetteModeReplay {
+		return recorder.replay(request)
+	}
+
+	return cassetteTransport.transport.RoundTrip(request)
+}
+
+// UseCassetteRecorder records the requests sent by the client to the recorder's current cassette, or replays them from
+// it, depending on the recorder's mode. Passing nil stops using cassettes.
+func (keycloakClient *KeycloakClient) UseCassetteRecorder(recorder *CassetteRecorder) {
+	keycloakClient.cassettes.Store(recorder)
+}
@@ -0,0 +1,180 @@
+package keycloak
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newCassetteKeycloakClient(t *testing.T, url string, recorder *CassetteRecorder) *KeycloakClient {
+	t.Helper()
+
+	keycloakClient, err := NewKeycloakClient(context.Background(), KeycloakClientConfig{
+		Url:                  url,
+		ClientId:             "terraform",
+		ClientSecret:         "secret",
+		Realm:                "master",
+		ClientTimeout:        5,
+		MaxRetries:           DefaultMaxRetries,
+		MinBackoff:           DefaultMinBackoff,
+		MaxBackoff:           DefaultMaxBackoff,
+		RetryableStatusCodes: DefaultRetryableStatusCodes,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	keycloakClient.UseCassetteRecorder(recorder)
+
+	return keycloakClient
+}
+
+// recordCassette creates a realm with an openid client through the fake admin api and records it to a cassette
+func recordCassette(t *testing.T, dir, realmName string) string {
+	t.Helper()
+
+	ctx := context.Background()
+	_, server := newFakeKeycloakClient(t)
+
+	recorder, err := NewCassetteRecorder(dir, CassetteModeRecord)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keycloakClient := newCassetteKeycloakClient(t, server.URL, recorder)
+
+	if err := recorder.Start("TestCassette"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := keycloakClient.NewRealm(ctx, &Realm{Realm: realmName, Enabled: true}); err != nil {
+		t.Fatal(err)
+	}
+
+	client := &OpenidClient{RealmId: realmName, ClientId: "backend", Enabled: true, ClientSecret: "super-secret-value"}
+	if err := keycloakClient.NewOpenidClient(ctx, client); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := keycloakClient.GetOpenidClient(ctx, realmName, client.Id); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := keycloakClient.GetRealm(ctx, "missing"); !ErrorIs404(err) {
+		t.Fatalf("expected a 404 for a missing realm, got %v", err)
+	}
+
+	if err := recorder.Stop(); err != nil {
+		t.Fatal(err)
+	}
+
+	return client.Id
+}
+
+func TestCassetteRecordScrubsSecrets(t *testing.T) {
+	dir := t.TempDir()
+	recordCassette(t, dir, "tf-acc-1111111111")
+
+	data, err := os.ReadFile(filepath.Join(dir, "TestCassette.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cassette := string(data)
+	if strings.Contains(cassette, "super-secret-value") || strings.Contains(cassette, "Bearer") {
+		t.Errorf("expected secrets to be scrubbed from the cassette, got %s", cassette)
+	}
+	if !strings.Contains(cassette, redactedValue) || !strings.Contains(cassette, "tf-acc-1111111111") {
+		t.Errorf("expected the requests to be recorded, got %s", cassette)
+	}
+	if strings.Contains(cassette, "openid-connect/token") {
+		t.Errorf("expected token requests not to be recorded, got %s", cassette)
+	}
+}
+
+func TestCassetteReplaySubstitutesGeneratedNames(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	clientId := recordCassette(t, dir, "tf-acc-1111111111")
+
+	recorder, err := NewCassetteRecorder(dir, CassetteModeReplay)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// nothing listens on this address, every response has to come from the cassette
+	keycloakClient := newCassetteKeycloakClient(t, "http://127.0.0.1:1", recorder)
+
+	if err := recorder.Start("TestCassette"); err != nil {
+		t.Fatal(err)
+	}
+	defer recorder.Stop()
+
+	if err := keycloakClient.NewRealm(ctx, &Realm{Realm: "tf-acc-2222222222", Enabled: true}); err != nil {
+		t.Fatal(err)
+	}
+
+	client := &OpenidClient{RealmId: "tf-acc-2222222222", ClientId: "backend", Enabled: true, ClientSecret: "another-secret-value"}
+	if err := keycloakClient.NewOpenidClient(ctx, client); err != nil {
+		t.Fatal(err)
+	}
+	if client.Id != clientId {
+		t.Errorf("expected the recorded client id %s, got %s", clientId, client.Id)
+	}
+
+	replayed, err := keycloakClient.GetOpenidClient(ctx, "tf-acc-2222222222", client.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if replayed.RealmId != "tf-acc-2222222222" || replayed.ClientId != "backend" {
+		t.Errorf("unexpected replayed client %+v", replayed)
+	}
+
+	if _, err := keycloakClient.GetRealm(ctx, "missing"); !ErrorIs404(err) {
+		t.Errorf("expected the recorded 404 to be replayed, got %v", err)
+	}
+}
+
+func TestCassetteReplayFailsOnUnmatchedRequests(t *testing.T) {
+	dir := t.TempDir()
+	recordCassette(t, dir, "tf-acc-1111111111")
+
+	recorder, err := NewCassetteRecorder(dir, CassetteModeReplay)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keycloakClient := newCassetteKeycloakClient(t, "http://127.0.0.1:1", recorder)
+
+	if err := recorder.Start("TestCassette"); err != nil {
+		t.Fatal(err)
+	}
+	defer recorder.Stop()
+
+	start := time.Now()
+
+	// the recorded realm was enabled, so this request doesn't match even though the name could be substituted
+	err = keycloakClient.NewRealm(context.Background(), &Realm{Realm: "tf-acc-2222222222", Enabled: false})
+	if !errors.Is(err, errUnmatchedCassetteRequest) {
+		t.Fatalf("expected an unmatched request error, got %v", err)
+	}
+
+	if elapsed := time.Since(start); elapsed >= DefaultMinBackoff {
+		t.Errorf("expected unmatched requests not to be retried, took %s", elapsed)
+	}
+}
+
+func TestCassetteRecorderRejectsConcurrentCassettes(t *testing.T) {
+	recorder, err := NewCassetteRecorder(t.TempDir(), CassetteModeRecord)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := recorder.Start("TestOne"); err != nil {
+		t.Fatal(err)
+	}
+	if err := recorder.Start("TestTwo"); err == nil {
+		t.Error("expected a second cassette not to start while the first one is in use")
+	}
+}
@@ -0,0 +1,245 @@
+package keycloak
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	_ "crypto/sha256"
+	_ "crypto/sha512"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"time"
+)
+
+// these are the ids of the client authenticators within keycloak
+const (
+	ClientAuthenticatorClientSecret    = "client-secret"
+	ClientAuthenticatorClientJwt       = "client-jwt"
+	ClientAuthenticatorClientSecretJwt = "client-secret-jwt"
+	ClientAuthenticatorClientX509      = "client-x509"
+)
+
+const (
+	clientAssertionType     = "urn:ietf:params:oauth:client-assertion-type:jwt-bearer"
+	clientAssertionLifespan = time.Minute
+)
+
+var ClientAuthenticators = []string{
+	ClientAuthenticatorClientSecret,
+	ClientAuthenticatorClientJwt,
+	ClientAuthenticatorClientSecretJwt,
+	ClientAuthenticatorClientX509,
+}
+
+// clientAssertionSigner creates the signed JWTs that are used in place of a client secret by the client-jwt and
+// client-secret-jwt authenticators
+type clientAssertionSigner struct {
+	algorithm string
+	keyId     string
+	key       interface{}
+}
+
+type clientAssertionHeader struct {
+	Algorithm string `json:"alg"`
+	Type      string `json:"typ"`
+	KeyId     string `json:"kid,omitempty"`
+}
+
+type clientAssertionClaims struct {
+	Issuer    string `json:"iss"`
+	Subject   string `json:"sub"`
+	Audience  string `json:"aud"`
+	JwtId     string `json:"jti"`
+	IssuedAt  int64  `json:"iat"`
+	ExpiresAt int64  `json:"exp"`
+}
+
+func newClientAssertionSigner(clientAuthenticator, algorithm, signingKey, keyId, clientSecret string) (*clientAssertionSigner, error) {
+	switch clientAuthenticator {
+	case ClientAuthenticatorClientSecretJwt:
+		if clientSecret == "" {
+			return nil, fmt.Errorf("a client secret is required by the %s client authenticator", clientAuthenticator)
+		}
+		if algorithm == "" {
+			algorithm = "HS256"
+		}
+		if hashForAlgorithm(algorithm) == 0 || algorithm[:2] != "HS" {
+			return nil, fmt.Errorf("algorithm %s is not supported by the %s client authenticator", algorithm, clientAuthenticator)
+		}
+
+		return &clientAssertionSigner{
+			algorithm: algorithm,
+			keyId:     keyId,
+			key:       []byte(clientSecret),
+		}, nil
+	case ClientAuthenticatorClientJwt:
+		if signingKey == "" {
+			return nil, fmt.Errorf("a signing key is required by the %s client authenticator", clientAuthenticator)
+		}
+
+		key, err := parsePrivateKey(signingKey)
+		if err != nil {
+			return nil, err
+		}
+
+		if algorithm == "" {
+			algorithm = "RS256"
+			if _, ok := key.(*ecdsa.PrivateKey); ok {
+				algorithm = "ES256"
+			}
+		}
+		if hashForAlgorithm(algorithm) == 0 {
+			return nil, fmt.Errorf("algorithm %s is not supported by the %s client authenticator", algorithm, clientAuthenticator)
+		}
+
+		switch key.(type) {
+		case *rsa.PrivateKey:
+			if algorithm[:2] != "RS" && algorithm[:2] != "PS" {
+				return nil, fmt.Errorf("algorithm %s cannot be used with an RSA signing key", algorithm)
+			}
+		case *ecdsa.PrivateKey:
+			if algorithm[:2] != "ES" {
+				return nil, fmt.Errorf("algorithm %s cannot be used with an EC signing key", algorithm)
+			}
+		default:
+			return nil, fmt.Errorf("signing key must be an RSA or EC private key")
+		}
+
+		return &clientAssertionSigner{
+			algorithm: algorithm,
+			keyId:     keyId,
+			key:       key,
+		}, nil
+	}
+
+	return nil, nil
+}
+
+func parsePrivateKey(signingKey string) (interface{}, error) {
+	block, _ := pem.Decode([]byte(signingKey))
+	if block == nil {
+		return nil, fmt.Errorf("signing key is not PEM encoded")
+	}
+
+	if key, err := x509.ParsePKCS8PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+
+	if key, err := x509.ParseECPrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+
+	return nil, fmt.Errorf("unable to parse signing key, it must be a PKCS#1, PKCS#8 or SEC 1 private key")
+}
+
+func hashForAlgorithm(algorithm string) crypto.Hash {
+	if len(algorithm) != 5 {
+		return 0
+	}
+
+	switch algorithm[:2] {
+	case "RS", "PS", "ES", "HS":
+	default:
+		return 0
+	}
+
+	switch algorithm[2:] {
+	case "256":
+		return crypto.SHA256
+	case "384":
+		return crypto.SHA384
+	case "512":
+		return crypto.SHA512
+	}
+
+	return 0
+}
+
+// sign creates a new client assertion for the given client, which is only valid for the given token endpoint
+func (signer *clientAssertionSigner) sign(clientId, audience string) (string, error) {
+	jwtId := make([]byte, 16)
+	if _, err := rand.Read(jwtId); err != nil {
+		return "", err
+	}
+
+	now := time.Now()
+
+	header, err := json.Marshal(&clientAssertionHeader{
+		Algorithm: signer.algorithm,
+		Type:      "JWT",
+		KeyId:     signer.keyId,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	claims, err := json.Marshal(&clientAssertionClaims{
+		Issuer:    clientId,
+		Subject:   clientId,
+		Audience:  audience,
+		JwtId:     hex.EncodeToString(jwtId),
+		IssuedAt:  now.Unix(),
+		ExpiresAt: now.Add(clientAssertionLifespan).Unix(),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	signingInput := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(claims)
+
+	signature, err := signer.signature([]byte(signingInput))
+	if err != nil {
+		return "", err
+	}
+
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
+
+func (signer *clientAssertionSigner) signature(signingInput []byte) ([]byte, error) {
+	hashFunc := hashForAlgorithm(signer.algorithm)
+
+	switch key := signer.key.(type) {
+	case []byte:
+		mac := hmac.New(hashFunc.New, key)
+		mac.Write(signingInput)
+
+		return mac.Sum(nil), nil
+	case *rsa.PrivateKey:
+		digest := hashFunc.New()
+		digest.Write(signingInput)
+
+		if signer.algorithm[:2] == "PS" {
+			return rsa.SignPSS(rand.Reader, key, hashFunc, digest.Sum(nil), &rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthEqualsHash})
+		}
+
+		return rsa.SignPKCS1v15(rand.Reader, key, hashFunc, digest.Sum(nil))
+	case *ecdsa.PrivateKey:
+		digest := hashFunc.New()
+		digest.Write(signingInput)
+
+		r, s, err := ecdsa.Sign(rand.Reader, key, digest.Sum(nil))
+		if err != nil {
+			return nil, err
+		}
+
+		// JWS uses the fixed size concatenation of r and s, rather than the ASN.1 encoding used by crypto/ecdsa
+		keySize := (key.Curve.Params().BitSize + 7) / 8
+		signature := make([]byte, 2*keySize)
+		r.FillBytes(signature[:keySize])
+		s.FillBytes(signature[keySize:])
+
+		return signature, nil
+	}
+
+	return nil, fmt.Errorf("unsupported signing key")
+}
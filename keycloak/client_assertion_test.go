@@ -0,0 +1,388 @@
+package keycloak
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func pemEncodePrivateKey(t *testing.T, key interface{}) string {
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}))
+}
+
+// decodeClientAssertion splits a client assertion into its decoded parts, along with the input that was signed
+func decodeClientAssertion(t *testing.T, clientAssertion string) (*clientAssertionHeader, *clientAssertionClaims, []byte, []byte) {
+	parts := strings.Split(clientAssertion, ".")
+	if len(parts) != 3 {
+		t.Fatalf("expected client assertion to have three parts, got %d", len(parts))
+	}
+
+	var header clientAssertionHeader
+	var claims clientAssertionClaims
+	for i, v := range []interface{}{&header, &claims} {
+		decoded, err := base64.RawURLEncoding.DecodeString(parts[i])
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := json.Unmarshal(decoded, v); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return &header, &claims, []byte(parts[0] + "." + parts[1]), signature
+}
+
+func sha256Digest(signingInput []byte) []byte {
+	digest := crypto.SHA256.New()
+	digest.Write(signingInput)
+
+	return digest.Sum(nil)
+}
+
+func TestClientAssertionSignerRS256(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	signer, err := newClientAssertionSigner(ClientAuthenticatorClientJwt, "", pemEncodePrivateKey(t, key), "my-kid", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	clientAssertion, err := signer.sign("terraform", "https://keycloak.example.com/realms/master/protocol/openid-connect/token")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	header, claims, signingInput, signature := decodeClientAssertion(t, clientAssertion)
+
+	if header.Algorithm != "RS256" || header.KeyId != "my-kid" || header.Type != "JWT" {
+		t.Errorf("unexpected header %+v", header)
+	}
+	if claims.Issuer != "terraform" || claims.Subject != "terraform" || claims.Audience != "https://keycloak.example.com/realms/master/protocol/openid-connect/token" {
+		t.Errorf("unexpected claims %+v", claims)
+	}
+	if claims.JwtId == "" || claims.ExpiresAt-claims.IssuedAt != int64(clientAssertionLifespan/time.Second) {
+		t.Errorf("unexpected claims %+v", claims)
+	}
+
+	if err := rsa.VerifyPKCS1v15(&key.PublicKey, crypto.SHA256, sha256Digest(signingInput), signature); err != nil {
+		t.Errorf("client assertion signature is invalid: %v", err)
+	}
+}
+
+func TestClientAssertionSignerES256(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	signer, err := newClientAssertionSigner(ClientAuthenticatorClientJwt, "", pemEncodePrivateKey(t, key), "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	clientAssertion, err := signer.sign("terraform", "audience")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	header, _, signingInput, signature := decodeClientAssertion(t, clientAssertion)
+
+	if header.Algorithm != "ES256" {
+		t.Errorf("expected ES256 to be chosen for an EC key, got %s", header.Algorithm)
+	}
+	if len(signature) != 64 {
+		t.Fatalf("expected a 64 byte signature, got %d", len(signature))
+	}
+
+	r := new(big.Int).SetBytes(signature[:32])
+	s := new(big.Int).SetBytes(signature[32:])
+	if !ecdsa.Verify(&key.PublicKey, sha256Digest(signingInput), r, s) {
+		t.Errorf("client assertion signature is invalid")
+	}
+}
+
+func TestClientAssertionSignerHS256(t *testing.T) {
+	signer, err := newClientAssertionSigner(ClientAuthenticatorClientSecretJwt, "", "", "", "my-secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	clientAssertion, err := signer.sign("terraform", "audience")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	header, _, signingInput, signature := decodeClientAssertion(t, clientAssertion)
+
+	if header.Algorithm != "HS256" {
+		t.Errorf("expected HS256, got %s", header.Algorithm)
+	}
+
+	mac := hmac.New(crypto.SHA256.New, []byte("my-secret"))
+	mac.Write(signingInput)
+	if !hmac.Equal(mac.Sum(nil), signature) {
+		t.Errorf("client assertion signature is invalid")
+	}
+}
+
+func TestClientAssertionSignerErrors(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for name, args := range map[string][]string{
+		"missing signing key":     {ClientAuthenticatorClientJwt, "", "", ""},
+		"invalid signing key":     {ClientAuthenticatorClientJwt, "", "not a key", ""},
+		"mismatched algorithm":    {ClientAuthenticatorClientJwt, "ES256", pemEncodePrivateKey(t, rsaKey), ""},
+		"unsupported algorithm":   {ClientAuthenticatorClientJwt, "RS1024", pemEncodePrivateKey(t, rsaKey), ""},
+		"missing client secret":   {ClientAuthenticatorClientSecretJwt, "", "", ""},
+		"asymmetric with secrets": {ClientAuthenticatorClientSecretJwt, "RS256", "", "my-secret"},
+	} {
+		if _, err := newClientAssertionSigner(args[0], args[1], args[2], "", args[3]); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+
+	signer, err := newClientAssertionSigner(ClientAuthenticatorClientSecret, "", "", "", "my-secret")
+	if signer != nil || err != nil {
+		t.Errorf("expected no signer for the %s client authenticator", ClientAuthenticatorClientSecret)
+	}
+}
+
+// newTestKeycloakServer serves just enough of keycloak for the client to log in, and records the token requests it
+// receives and the authorization headers sent to the admin API
+func newTestKeycloakServer(t *testing.T, tokenRequests *[]*http.Request, authorizationHeaders *[]string) *httptest.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/realms/master/protocol/openid-connect/token", func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			t.Error(err)
+		}
+		*tokenRequests = append(*tokenRequests, r)
+
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"access_token":"token-%d","refresh_token":"refresh","token_type":"Bearer"}`, len(*tokenRequests))
+	})
+	mux.HandleFunc("/admin/serverinfo", func(w http.ResponseWriter, r *http.Request) {
+		*authorizationHeaders = append(*authorizationHeaders, r.Header.Get("Authorization"))
+
+		if r.Header.Get("Authorization") == "Bearer expired" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"systemInfo":{"version":"26.0.0"}}`)
+	})
+
+	return httptest.NewUnstartedServer(mux)
+}
+
+func TestKeycloakClientClientJwtLogin(t *testing.T) {
+	var tokenRequests []*http.Request
+	var authorizationHeaders []string
+
+	server := newTestKeycloakServer(t, &tokenRequests, &authorizationHeaders)
+	server.Start()
+	defer server.Close()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	keycloakClient, err := NewKeycloakClient(ctx, KeycloakClientConfig{
+		Url:                 server.URL,
+		ClientId:            "terraform",
+		ClientAuthenticator: ClientAuthenticatorClientJwt,
+		JwtSigningAlg:       "PS256",
+		JwtSigningKey:       pemEncodePrivateKey(t, key),
+		JwtSigningKeyId:     "my-kid",
+		Realm:               "master",
+		InitialLogin:        true,
+		ClientTimeout:       5,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := keycloakClient.refresh(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(tokenRequests) != 2 {
+		t.Fatalf("expected a login and a refresh token request, got %d", len(tokenRequests))
+	}
+
+	for _, tokenRequest := range tokenRequests {
+		if tokenRequest.PostForm.Get("grant_type") != "client_credentials" || tokenRequest.PostForm.Get("client_assertion_type") != clientAssertionType {
+			t.Errorf("unexpected token request %v", tokenRequest.PostForm)
+		}
+		if tokenRequest.PostForm.Has("client_secret") {
+			t.Errorf("expected no client secret to be sent, got %v", tokenRequest.PostForm)
+		}
+
+		header, claims, signingInput, signature := decodeClientAssertion(t, tokenRequest.PostForm.Get("client_assertion"))
+		if header.Algorithm != "PS256" || claims.Audience != server.URL+"/realms/master/protocol/openid-connect/token" {
+			t.Errorf("unexpected client assertion %+v %+v", header, claims)
+		}
+		if err := rsa.VerifyPSS(&key.PublicKey, crypto.SHA256, sha256Digest(signingInput), signature, nil); err != nil {
+			t.Errorf("client assertion signature is invalid: %v", err)
+		}
+	}
+
+	// every token request needs a new assertion, keycloak rejects a jti that has already been used
+	if tokenRequests[0].PostForm.Get("client_assertion") == tokenRequests[1].PostForm.Get("client_assertion") {
+		t.Errorf("expected a new client assertion for the refresh")
+	}
+
+	if keycloakClient.clientCredentials.AccessToken != "token-2" {
+		t.Errorf("expected the refreshed access token to be used, got %s", keycloakClient.clientCredentials.AccessToken)
+	}
+}
+
+func TestKeycloakClientStaticAccessToken(t *testing.T) {
+	var tokenRequests []*http.Request
+	var authorizationHeaders []string
+
+	server := newTestKeycloakServer(t, &tokenRequests, &authorizationHeaders)
+	server.Start()
+	defer server.Close()
+
+	ctx := context.Background()
+	keycloakClient, err := NewKeycloakClient(ctx, KeycloakClientConfig{
+		Url:           server.URL,
+		Realm:         "master",
+		AccessToken:   "my-token",
+		InitialLogin:  true,
+		ClientTimeout: 5,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(tokenRequests) != 0 {
+		t.Errorf("expected no token requests, got %d", len(tokenRequests))
+	}
+	if len(authorizationHeaders) != 1 || authorizationHeaders[0] != "Bearer my-token" {
+		t.Errorf("expected the static access token to be used, got %v", authorizationHeaders)
+	}
+	if ok, _ := keycloakClient.VersionIsGreaterThanOrEqualTo(ctx, Version_26); !ok {
+		t.Errorf("expected the server version to be read with the static access token")
+	}
+
+	// a rejected static token is reported as is, rather than attempting a refresh
+	keycloakClient.clientCredentials.AccessToken = "expired"
+	if _, err := keycloakClient.GetServerInfo(ctx); err == nil {
+		t.Errorf("expected an error for a rejected access token")
+	}
+	if len(tokenRequests) != 0 || len(authorizationHeaders) != 2 {
+		t.Errorf("expected the rejected access token not to be refreshed, got %d token requests and %d admin requests", len(tokenRequests), len(authorizationHeaders))
+	}
+	if err := keycloakClient.refresh(ctx); err == nil {
+		t.Errorf("expected refreshing a static access token to fail")
+	}
+}
+
+func TestKeycloakClientClientX509Login(t *testing.T) {
+	var tokenRequests []*http.Request
+	var authorizationHeaders []string
+
+	server := newTestKeycloakServer(t, &tokenRequests, &authorizationHeaders)
+	server.TLS = &tls.Config{ClientAuth: tls.RequireAnyClientCert}
+	server.StartTLS()
+	defer server.Close()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "terraform"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	certificate, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	clientCertificate := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certificate}))
+	caCertificate := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}))
+
+	ctx := context.Background()
+
+	_, err = NewKeycloakClient(ctx, KeycloakClientConfig{
+		Url:                 server.URL,
+		ClientId:            "terraform",
+		ClientAuthenticator: ClientAuthenticatorClientX509,
+		Realm:               "master",
+		InitialLogin:        true,
+		ClientTimeout:       5,
+		CaCert:              caCertificate,
+	})
+	if err == nil {
+		t.Fatalf("expected an error when the client certificate is missing")
+	}
+
+	_, err = NewKeycloakClient(ctx, KeycloakClientConfig{
+		Url:                 server.URL,
+		ClientId:            "terraform",
+		ClientAuthenticator: ClientAuthenticatorClientX509,
+		Realm:               "master",
+		InitialLogin:        true,
+		ClientTimeout:       5,
+		CaCert:              caCertificate,
+		TlsClientCert:       clientCertificate,
+		TlsClientPrivateKey: pemEncodePrivateKey(t, key),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(tokenRequests) != 1 {
+		t.Fatalf("expected one token request, got %d", len(tokenRequests))
+	}
+
+	tokenRequest := tokenRequests[0]
+	if len(tokenRequest.TLS.PeerCertificates) != 1 || tokenRequest.TLS.PeerCertificates[0].Subject.CommonName != "terraform" {
+		t.Errorf("expected the client certificate to be presented")
+	}
+	if tokenRequest.PostForm.Get("client_id") != "terraform" || tokenRequest.PostForm.Has("client_secret") || tokenRequest.PostForm.Has("client_assertion") {
+		t.Errorf("unexpected token request %v", tokenRequest.PostForm)
+	}
+}
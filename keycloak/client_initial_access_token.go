@@ -0,0 +1,63 @@
+package keycloak
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// ClientInitialAccessToken allows a number of clients to be registered through dynamic client registration, until it
+// expires. Keycloak only returns the Token itself when the token is created.
+type ClientInitialAccessToken struct {
+	Id      string `json:"id,omitempty"`
+	RealmId string `json:"-"`
+	// Expiration is the number of seconds the token is valid for, 0 when it doesn't expire
+	Expiration     int    `json:"expiration"`
+	Count          int    `json:"count"`
+	RemainingCount int    `json:"remainingCount,omitempty"`
+	Timestamp      int64  `json:"timestamp,omitempty"`
+	Token          string `json:"token,omitempty"`
+}
+
+func (keycloakClient *KeycloakClient) NewClientInitialAccessToken(ctx context.Context, token *ClientInitialAccessToken) error {
+	body, _, err := keycloakClient.post(ctx, fmt.Sprintf("/realms/%s/clients-initial-access", token.RealmId), token)
+	if err != nil {
+		return err
+	}
+
+	err = json.Unmarshal(body, token)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// GetClientInitialAccessToken returns the token with the given id without its Token, or nil when it has been used up or
+// has expired. Keycloak removes used up tokens right away but expired ones only periodically, so those are skipped.
+func (keycloakClient *KeycloakClient) GetClientInitialAccessToken(ctx context.Context, realmId, id string) (*ClientInitialAccessToken, error) {
+	var tokens []*ClientInitialAccessToken
+
+	err := keycloakClient.get(ctx, fmt.Sprintf("/realms/%s/clients-initial-access", realmId), &tokens, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, token := range tokens {
+		if token.Id == id && !token.expired(time.Now()) {
+			token.RealmId = realmId
+			return token, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (token *ClientInitialAccessToken) expired(now time.Time) bool {
+	return token.Expiration > 0 && now.Unix() >= token.Timestamp+int64(token.Expiration)
+}
+
+func (keycloakClient *KeycloakClient) DeleteClientInitialAccessToken(ctx context.Context, realmId, id string) error {
+	return keycloakClient.delete(ctx, fmt.Sprintf("/realms/%s/clients-initial-access/%s", realmId, id), nil)
+}
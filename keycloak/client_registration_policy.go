@@ -0,0 +1,102 @@
+package keycloak
+
+import (
+	"context"
+	"fmt"
+)
+
+const clientRegistrationPolicyProviderType = "org.keycloak.services.clientregistration.policy.ClientRegistrationPolicy"
+
+// ClientRegistrationPolicy is a policy that the dynamic client registration requests of a realm have to satisfy. The
+// policies of anonymous requests and of requests with an initial access token or a bearer token are separate, which is
+// the SubType of the component: anonymous or authenticated. The settings in Config depend on the ProviderId.
+type ClientRegistrationPolicy struct {
+	Id         string
+	Name       string
+	RealmId    string
+	SubType    string
+	ProviderId string
+
+	Config map[string][]string
+}
+
+func convertFromClientRegistrationPolicyToComponent(policy *ClientRegistrationPolicy) *component {
+	// empty lists are sent as empty arrays rather than null, which keycloak would store as a null value
+	config := map[string][]string{}
+	for key, values := range policy.Config {
+		if values == nil {
+			values = []string{}
+		}
+		config[key] = values
+	}
+
+	return &component{
+		Id:           policy.Id,
+		Name:         policy.Name,
+		ParentId:     policy.RealmId,
+		ProviderId:   policy.ProviderId,
+		ProviderType: clientRegistrationPolicyProviderType,
+		SubType:      policy.SubType,
+		Config:       config,
+	}
+}
+
+func convertFromComponentToClientRegistrationPolicy(component *component, realmId string) *ClientRegistrationPolicy {
+	return &ClientRegistrationPolicy{
+		Id:         component.Id,
+		Name:       component.Name,
+		RealmId:    realmId,
+		SubType:    component.SubType,
+		ProviderId: component.ProviderId,
+		Config:     component.Config,
+	}
+}
+
+func (keycloakClient *KeycloakClient) NewClientRegistrationPolicy(ctx context.Context, policy *ClientRegistrationPolicy) error {
+	_, location, err := keycloakClient.post(ctx, fmt.Sprintf("/realms/%s/components", policy.RealmId), convertFromClientRegistrationPolicyToComponent(policy))
+	if err != nil {
+		return err
+	}
+
+	policy.Id = getIdFromLocationHeader(location)
+
+	return nil
+}
+
+func (keycloakClient *KeycloakClient) GetClientRegistrationPolicy(ctx context.Context, realmId, id string) (*ClientRegistrationPolicy, error) {
+	var component *component
+
+	err := keycloakClient.get(ctx, fmt.Sprintf("/realms/%s/components/%s", realmId, id), &component, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return convertFromComponentToClientRegistrationPolicy(component, realmId), nil
+}
+
+// GetClientRegistrationPolicies returns the policies of the realm, keycloak creates a few of each sub type with a new realm
+func (keycloakClient *KeycloakClient) GetClientRegistrationPolicies(ctx context.Context, realmId string) ([]*ClientRegistrationPolicy, error) {
+	var components []*component
+
+	err := keycloakClient.get(ctx, fmt.Sprintf("/realms/%s/components", realmId), &components, map[string]string{
+		"type": clientRegistrationPolicyProviderType,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var policies []*ClientRegistrationPolicy
+	for _, component := range components {
+		policies = append(policies, convertFromComponentToClientRegistrationPolicy(component, realmId))
+	}
+
+	return policies, nil
+}
+
+func (keycloakClient *KeycloakClient) UpdateClientRegistrationPolicy(ctx context.Context, policy *ClientRegistrationPolicy) error {
+	return keycloakClient.put(ctx, fmt.Sprintf("/realms/%s/components/%s", policy.RealmId, policy.Id), convertFromClientRegistrationPolicyToComponent(policy))
+}
+
+func (keycloakClient *KeycloakClient) DeleteClientRegistrationPolicy(ctx context.Context, realmId, id string) error {
+	return keycloakClient.DeleteComponent(ctx, realmId, id)
+}
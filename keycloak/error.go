@@ -1,27 +1,215 @@
 package keycloak
 
 import (
-	"github.com/hashicorp/errwrap"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
+	"regexp"
+
+	"github.com/hashicorp/errwrap"
 )
 
 type ApiError struct {
 	Code    int
 	Message string
+
+	// The attributes below are parsed from the json keycloak responds with, when it sends one. Depending on the endpoint
+	// this is an oauth error ({"error": "...", "error_description": "..."}), a message ({"errorMessage": "..."}) that
+	// may name the field it is about, or a list of field errors ({"errors": [...]}) when several attributes are invalid.
+	ErrorCode        string
+	ErrorDescription string
+	ErrorMessage     string
+	Field            string
+	Params           []string
+	Errors           []ApiFieldError
+}
+
+// ApiFieldError is a validation error for a single field of a representation, such as "redirectUris" of a client
+type ApiFieldError struct {
+	Field        string
+	ErrorMessage string
+	Params       []string
+}
+
+type apiErrorRepresentation struct {
+	Error            string        `json:"error"`
+	ErrorDescription string        `json:"error_description"`
+	ErrorMessage     string        `json:"errorMessage"`
+	Field            string        `json:"field"`
+	Params           []interface{} `json:"params"`
+	Errors           []struct {
+		Field        string        `json:"field"`
+		ErrorMessage string        `json:"errorMessage"`
+		Params       []interface{} `json:"params"`
+	} `json:"errors"`
+}
+
+// apiErrorMessageFields are the fields of the validation messages keycloak sends without naming the field
+var apiErrorMessageFields = []struct {
+	pattern *regexp.Regexp
+	field   string
+}{
+	{regexp.MustCompile(`(?i)redirect uri`), "redirectUris"},
+	{regexp.MustCompile(`(?i)root url`), "rootUrl"},
+	{regexp.MustCompile(`(?i)base url`), "baseUrl"},
+	{regexp.MustCompile(`(?i)web origin`), "webOrigins"},
+}
+
+var unknownProviderPattern = regexp.MustCompile(`(?i)(unknown|invalid|could not find|couldn't find|no such|not found).*provider|provider.*(not found|does not exist|unknown)`)
+
+func newApiError(request *http.Request, response *http.Response, body []byte) *ApiError {
+	message := fmt.Sprintf("error sending %s request to %s: %s.", request.Method, request.URL.Path, response.Status)
+
+	if len(body) != 0 {
+		message = fmt.Sprintf("%s Response body: %s", message, body)
+	}
+
+	apiError := &ApiError{
+		Code:    response.StatusCode,
+		Message: message,
+	}
+
+	var representation apiErrorRepresentation
+	if err := json.Unmarshal(body, &representation); err != nil {
+		return apiError
+	}
+
+	apiError.ErrorCode = representation.Error
+	apiError.ErrorDescription = representation.ErrorDescription
+	apiError.ErrorMessage = representation.ErrorMessage
+	apiError.Field = representation.Field
+	apiError.Params = apiErrorParams(representation.Params)
+
+	for _, fieldError := range representation.Errors {
+		apiError.Errors = append(apiError.Errors, ApiFieldError{
+			Field:        fieldError.Field,
+			ErrorMessage: fieldError.ErrorMessage,
+			Params:       apiErrorParams(fieldError.Params),
+		})
+	}
+
+	return apiError
+}
+
+func apiErrorParams(params []interface{}) []string {
+	var result []string
+	for _, param := range params {
+		result = append(result, fmt.Sprint(param))
+	}
+
+	return result
 }
 
 func (e *ApiError) Error() string {
 	return e.Message
 }
 
-func ErrorIs404(err error) bool {
-	keycloakError, ok := errwrap.GetType(err, &ApiError{}).(*ApiError)
+// Description is the message keycloak sent with the error, or the raw message if the response couldn't be parsed
+func (e *ApiError) Description() string {
+	for _, description := range []string{e.ErrorMessage, e.ErrorDescription, e.ErrorCode} {
+		if description != "" {
+			return description
+		}
+	}
 
-	return ok && keycloakError != nil && keycloakError.Code == http.StatusNotFound
+	for _, fieldError := range e.Errors {
+		if fieldError.ErrorMessage != "" {
+			return fieldError.ErrorMessage
+		}
+	}
+
+	return e.Message
+}
+
+// FieldErrors returns the fields named by the error, along with their messages. Some validation messages don't name
+// their field, which is then derived from the message where possible.
+func (e *ApiError) FieldErrors() []ApiFieldError {
+	var fieldErrors []ApiFieldError
+
+	if e.Field != "" {
+		fieldErrors = append(fieldErrors, ApiFieldError{
+			Field:        e.Field,
+			ErrorMessage: e.Description(),
+			Params:       e.Params,
+		})
+	}
+
+	for _, fieldError := range e.Errors {
+		if fieldError.Field != "" {
+			fieldErrors = append(fieldErrors, fieldError)
+		}
+	}
+
+	if len(fieldErrors) == 0 && e.Code == http.StatusBadRequest {
+		description := e.Description()
+		for _, messageField := range apiErrorMessageFields {
+			if messageField.pattern.MatchString(description) {
+				fieldErrors = append(fieldErrors, ApiFieldError{
+					Field:        messageField.field,
+					ErrorMessage: description,
+				})
+				break
+			}
+		}
+	}
+
+	return fieldErrors
+}
+
+// AsApiError returns the ApiError within err, if there is one
+func AsApiError(err error) (*ApiError, bool) {
+	var apiError *ApiError
+	if errors.As(err, &apiError) && apiError != nil {
+		return apiError, true
+	}
+
+	apiError, ok := errwrap.GetType(err, &ApiError{}).(*ApiError)
+
+	return apiError, ok && apiError != nil
+}
+
+func errorHasCode(err error, code int) bool {
+	apiError, ok := AsApiError(err)
+
+	return ok && apiError.Code == code
+}
+
+func ErrorIs404(err error) bool {
+	return errorHasCode(err, http.StatusNotFound)
 }
 
 func ErrorIs409(err error) bool {
-	keycloakError, ok := errwrap.GetType(err, &ApiError{}).(*ApiError)
+	return errorHasCode(err, http.StatusConflict)
+}
+
+// ErrorIsValidation reports whether keycloak rejected a representation, e.g. because an attribute is invalid
+func ErrorIsValidation(err error) bool {
+	apiError, ok := AsApiError(err)
+
+	return ok && (apiError.Code == http.StatusBadRequest || len(apiError.FieldErrors()) != 0)
+}
+
+// ErrorIsDuplicate reports whether the object being created already exists
+func ErrorIsDuplicate(err error) bool {
+	return ErrorIs409(err)
+}
+
+// ErrorIsForbidden reports whether the credentials the provider uses lack the permission for a request
+func ErrorIsForbidden(err error) bool {
+	return errorHasCode(err, http.StatusForbidden)
+}
+
+// ErrorIsUnknownProvider reports whether a request referenced a provider that isn't installed, such as an unknown
+// authenticator, identity provider or component type
+func ErrorIsUnknownProvider(err error) bool {
+	apiError, ok := AsApiError(err)
+	if !ok || apiError.Code < http.StatusBadRequest || apiError.Code == http.StatusNotFound {
+		return false
+	}
+
+	// only the parsed message is checked, since the raw message contains the request path
+	description := apiError.Description()
 
-	return ok && keycloakError != nil && keycloakError.Code == http.StatusConflict
+	return description != apiError.Message && unknownProviderPattern.MatchString(description)
 }
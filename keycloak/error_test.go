@@ -0,0 +1,100 @@
+package keycloak
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func newTestApiError(statusCode int, body string) *ApiError {
+	request := httptest.NewRequest(http.MethodPost, "/admin/realms/test/clients", nil)
+	response := &http.Response{
+		StatusCode: statusCode,
+		Status:     fmt.Sprintf("%d %s", statusCode, http.StatusText(statusCode)),
+	}
+
+	return newApiError(request, response, []byte(body))
+}
+
+func TestApiErrorParsesErrorShapes(t *testing.T) {
+	for _, test := range []struct {
+		name                string
+		statusCode          int
+		body                string
+		expectedDescription string
+		expectedFieldErrors []ApiFieldError
+	}{
+		{
+			name:                "error message",
+			statusCode:          http.StatusConflict,
+			body:                `{"errorMessage":"Client backend already exists"}`,
+			expectedDescription: "Client backend already exists",
+		},
+		{
+			name:                "oauth error",
+			statusCode:          http.StatusBadRequest,
+			body:                `{"error":"invalid_input","error_description":"A redirect URI is not a valid URI"}`,
+			expectedDescription: "A redirect URI is not a valid URI",
+			expectedFieldErrors: []ApiFieldError{{Field: "redirectUris", ErrorMessage: "A redirect URI is not a valid URI"}},
+		},
+		{
+			name:                "field error",
+			statusCode:          http.StatusBadRequest,
+			body:                `{"field":"email","errorMessage":"error-invalid-email","params":["email",1]}`,
+			expectedDescription: "error-invalid-email",
+			expectedFieldErrors: []ApiFieldError{{Field: "email", ErrorMessage: "error-invalid-email", Params: []string{"email", "1"}}},
+		},
+		{
+			name:                "multiple field errors",
+			statusCode:          http.StatusBadRequest,
+			body:                `{"errors":[{"field":"firstName","errorMessage":"error-user-attribute-required"},{"field":"lastName","errorMessage":"error-user-attribute-required"}]}`,
+			expectedDescription: "error-user-attribute-required",
+			expectedFieldErrors: []ApiFieldError{
+				{Field: "firstName", ErrorMessage: "error-user-attribute-required"},
+				{Field: "lastName", ErrorMessage: "error-user-attribute-required"},
+			},
+		},
+		{
+			name:                "not json",
+			statusCode:          http.StatusBadGateway,
+			body:                "<html>Bad Gateway</html>",
+			expectedDescription: "error sending POST request to /admin/realms/test/clients: 502 Bad Gateway. Response body: <html>Bad Gateway</html>",
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			apiError := newTestApiError(test.statusCode, test.body)
+
+			if description := apiError.Description(); description != test.expectedDescription {
+				t.Errorf("expected description %q, got %q", test.expectedDescription, description)
+			}
+			if fieldErrors := apiError.FieldErrors(); !reflect.DeepEqual(fieldErrors, test.expectedFieldErrors) {
+				t.Errorf("expected field errors %+v, got %+v", test.expectedFieldErrors, fieldErrors)
+			}
+		})
+	}
+}
+
+func TestApiErrorPredicates(t *testing.T) {
+	duplicate := fmt.Errorf("failed to create client: %w", newTestApiError(http.StatusConflict, `{"errorMessage":"Client backend already exists"}`))
+	if !ErrorIsDuplicate(duplicate) || !ErrorIs409(duplicate) || ErrorIsValidation(duplicate) {
+		t.Errorf("expected a wrapped 409 to be a duplicate error")
+	}
+
+	validation := newTestApiError(http.StatusBadRequest, `{"field":"email","errorMessage":"error-invalid-email"}`)
+	if !ErrorIsValidation(validation) || ErrorIsForbidden(validation) {
+		t.Errorf("expected a 400 to be a validation error")
+	}
+
+	if !ErrorIsForbidden(newTestApiError(http.StatusForbidden, `{"error":"unknown_error"}`)) {
+		t.Errorf("expected a 403 to be a forbidden error")
+	}
+
+	if !ErrorIsUnknownProvider(newTestApiError(http.StatusBadRequest, `{"errorMessage":"Could not find authenticator provider"}`)) {
+		t.Errorf("expected a missing authenticator to be an unknown provider error")
+	}
+	if ErrorIsUnknownProvider(newTestApiError(http.StatusNotFound, "")) {
+		t.Errorf("expected a 404 without a message not to be an unknown provider error")
+	}
+}
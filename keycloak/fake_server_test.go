@@ -0,0 +1,507 @@
+package keycloak
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/keycloak/terraform-provider-keycloak/keycloak/keycloaktest"
+)
+
+func newFakeKeycloakClient(t *testing.T) (*KeycloakClient, *keycloaktest.Server) {
+	t.Helper()
+
+	server := keycloaktest.NewServer()
+	t.Cleanup(server.Close)
+
+	keycloakClient, err := NewKeycloakClient(context.Background(), KeycloakClientConfig{
+		Url:           server.URL,
+		ClientId:      "terraform",
+		ClientSecret:  "secret",
+		Realm:         "master",
+		InitialLogin:  true,
+		ClientTimeout: 5,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return keycloakClient, server
+}
+
+func newFakeRealm(t *testing.T, keycloakClient *KeycloakClient, name string) {
+	t.Helper()
+
+	if err := keycloakClient.NewRealm(context.Background(), &Realm{Realm: name, Enabled: true}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestKeycloakClientFakeRealm(t *testing.T) {
+	ctx := context.Background()
+	keycloakClient, _ := newFakeKeycloakClient(t)
+
+	newFakeRealm(t, keycloakClient, "test")
+
+	realm, err := keycloakClient.GetRealm(ctx, "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if realm.Realm != "test" || !realm.Enabled {
+		t.Errorf("unexpected realm %+v", realm)
+	}
+
+	realm.DisplayName = "Test"
+	if err := keycloakClient.UpdateRealm(ctx, realm); err != nil {
+		t.Fatal(err)
+	}
+
+	realm, err = keycloakClient.GetRealm(ctx, "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if realm.DisplayName != "Test" {
+		t.Errorf("expected the display name to be updated, got %s", realm.DisplayName)
+	}
+
+	if err := keycloakClient.DeleteRealm(ctx, "test"); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := keycloakClient.GetRealm(ctx, "test"); !ErrorIs404(err) {
+		t.Errorf("expected a 404 for a deleted realm, got %v", err)
+	}
+}
+
+func TestKeycloakClientFakeOpenidClient(t *testing.T) {
+	ctx := context.Background()
+	keycloakClient, _ := newFakeKeycloakClient(t)
+
+	newFakeRealm(t, keycloakClient, "test")
+
+	client := &OpenidClient{
+		RealmId:                "test",
+		ClientId:               "backend",
+		Protocol:               "openid-connect",
+		Enabled:                true,
+		ServiceAccountsEnabled: true,
+		Attributes: OpenidClientAttributes{
+			ExtraConfig: map[string]interface{}{
+				"custom.attribute": "value",
+			},
+		},
+	}
+	if err := keycloakClient.NewOpenidClient(ctx, client); err != nil {
+		t.Fatal(err)
+	}
+
+	found, err := keycloakClient.GetOpenidClientByClientId(ctx, "test", "backend")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if found.Id != client.Id || found.ClientSecret == "" {
+		t.Errorf("expected the client to be found with a generated secret, got %+v", found)
+	}
+	if found.Attributes.ExtraConfig["custom.attribute"] != "value" {
+		t.Errorf("expected the extra config to be round tripped, got %v", found.Attributes.ExtraConfig)
+	}
+
+	serviceAccountUser, err := keycloakClient.GetOpenidClientServiceAccountUserId(ctx, "test", client.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if serviceAccountUser.Username != "service-account-backend" {
+		t.Errorf("unexpected service account user %s", serviceAccountUser.Username)
+	}
+
+	if err := keycloakClient.DeleteOpenidClient(ctx, "test", client.Id); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := keycloakClient.GetOpenidClient(ctx, "test", client.Id); !ErrorIs404(err) {
+		t.Errorf("expected a 404 for a deleted client, got %v", err)
+	}
+}
+
+func TestKeycloakClientFakeGroupsAndUsers(t *testing.T) {
+	ctx := context.Background()
+	keycloakClient, _ := newFakeKeycloakClient(t)
+
+	newFakeRealm(t, keycloakClient, "test")
+
+	parent := &Group{RealmId: "test", Name: "parent"}
+	if err := keycloakClient.NewGroup(ctx, parent); err != nil {
+		t.Fatal(err)
+	}
+
+	child := &Group{RealmId: "test", ParentId: parent.Id, Name: "child"}
+	if err := keycloakClient.NewGroup(ctx, child); err != nil {
+		t.Fatal(err)
+	}
+
+	found, err := keycloakClient.GetGroupByName(ctx, "test", "child")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if found.Id != child.Id || found.Path != "/parent/child" || found.ParentId != parent.Id {
+		t.Errorf("unexpected group %+v", found)
+	}
+
+	user := &User{RealmId: "test", Username: "Alice", Enabled: true}
+	if err := keycloakClient.NewUser(ctx, user); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := keycloakClient.AddUsersToGroup(ctx, "test", child.Id, []interface{}{"alice"}); err != nil {
+		t.Fatal(err)
+	}
+
+	members, err := keycloakClient.GetGroupMembers(ctx, "test", child.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(members) != 1 || members[0].Id != user.Id || members[0].Username != "alice" {
+		t.Errorf("expected alice to be a member of the group, got %v", members)
+	}
+
+	// deleting a group deletes its subgroups
+	if err := keycloakClient.DeleteGroup(ctx, "test", parent.Id); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := keycloakClient.GetGroup(ctx, "test", child.Id); !ErrorIs404(err) {
+		t.Errorf("expected a 404 for a subgroup of a deleted group, got %v", err)
+	}
+}
+
+func TestKeycloakClientFakeRoleComposites(t *testing.T) {
+	ctx := context.Background()
+	keycloakClient, _ := newFakeKeycloakClient(t)
+
+	newFakeRealm(t, keycloakClient, "test")
+
+	composite := &Role{RealmId: "test", Name: "admin", Attributes: map[string][]string{"level": {"high"}}}
+	if err := keycloakClient.CreateRole(ctx, composite); err != nil {
+		t.Fatal(err)
+	}
+
+	child := &Role{RealmId: "test", Name: "viewer"}
+	if err := keycloakClient.CreateRole(ctx, child); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := keycloakClient.AddCompositesToRole(ctx, composite, []*Role{child}); err != nil {
+		t.Fatal(err)
+	}
+
+	found, err := keycloakClient.GetRoleByName(ctx, "test", "", "admin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if found.Id != composite.Id || found.Attributes["level"][0] != "high" {
+		t.Errorf("unexpected role %+v", found)
+	}
+
+	composites, err := keycloakClient.GetRoleComposites(ctx, composite)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(composites) != 1 || composites[0].Id != child.Id {
+		t.Errorf("expected viewer to be a composite of admin, got %v", composites)
+	}
+}
+
+func TestKeycloakClientFakeLdapUserFederation(t *testing.T) {
+	ctx := context.Background()
+	keycloakClient, _ := newFakeKeycloakClient(t)
+
+	newFakeRealm(t, keycloakClient, "test")
+
+	ldap := &LdapUserFederation{
+		Name:                  "openldap",
+		RealmId:               "test",
+		Enabled:               true,
+		Priority:              1,
+		EditMode:              "READ_ONLY",
+		Vendor:                "OTHER",
+		UsernameLDAPAttribute: "cn",
+		RdnLDAPAttribute:      "cn",
+		UuidLDAPAttribute:     "entryDN",
+		UserObjectClasses:     []string{"simpleSecurityObject", "organizationalRole"},
+		ConnectionUrl:         "ldap://openldap",
+		UsersDn:               "dc=example,dc=org",
+		SearchScope:           "1",
+		CachePolicy:           "DEFAULT",
+		BatchSizeForSync:      1000,
+		FullSyncPeriod:        -1,
+		ChangedSyncPeriod:     -1,
+	}
+	if err := keycloakClient.NewLdapUserFederation(ctx, "test", ldap); err != nil {
+		t.Fatal(err)
+	}
+
+	found, err := keycloakClient.GetLdapUserFederation(ctx, "test", ldap.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if found.Name != "openldap" || found.ConnectionUrl != "ldap://openldap" || len(found.UserObjectClasses) != 2 || found.Vendor != "OTHER" {
+		t.Errorf("unexpected user federation %+v", found)
+	}
+}
+
+func TestKeycloakClientFakeRevokedTokens(t *testing.T) {
+	keycloakClient, server := newFakeKeycloakClient(t)
+
+	server.RevokeTokens()
+
+	if _, err := keycloakClient.GetRealm(context.Background(), "master"); err != nil {
+		t.Fatalf("expected the client to log in again after its token was revoked, got %v", err)
+	}
+}
+
+func TestKeycloakClientFakeSmtpConnection(t *testing.T) {
+	ctx := context.Background()
+	keycloakClient, server := newFakeKeycloakClient(t)
+
+	newFakeRealm(t, keycloakClient, "test")
+
+	// keycloak sends the test email to the user that is logged in
+	err := keycloakClient.put(ctx, "/realms/master/users/"+keycloaktest.AdminUserId, map[string]interface{}{"email": "admin@example.com"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	smtpServer := &SmtpServer{Host: "smtp.example.com", From: "keycloak@example.com"}
+	if err := keycloakClient.TestRealmSmtpConnection(ctx, "test", smtpServer); err != nil {
+		t.Fatalf("expected the test email to be sent, got %v", err)
+	}
+
+	if sent := server.SentEmails(); len(sent) != 1 || sent[0] != "admin@example.com" {
+		t.Errorf("expected the test email to be sent to the logged in user, got %v", sent)
+	}
+
+	server.SetSMTPError("Failed to send email")
+
+	err = keycloakClient.TestRealmSmtpConnection(ctx, "test", smtpServer)
+	if err == nil || !strings.Contains(err.Error(), "realm test: Failed to send email") {
+		t.Errorf("expected the error of the server, got %v", err)
+	}
+}
+
+func TestKeycloakClientFakeSmtpConnectionWithoutEmail(t *testing.T) {
+	ctx := context.Background()
+	keycloakClient, _ := newFakeKeycloakClient(t)
+
+	newFakeRealm(t, keycloakClient, "test")
+
+	smtpServer := &SmtpServer{Host: "smtp.example.com", From: "keycloak@example.com"}
+	err := keycloakClient.TestRealmSmtpConnection(ctx, "test", smtpServer)
+	if !errors.Is(err, ErrLoggedInUserHasNoEmail) {
+		t.Errorf("expected the test email to need the email address of the logged in user, got %v", err)
+	}
+}
+
+func TestKeycloakClientFakeSmtpTokenAuthRequiresVersion(t *testing.T) {
+	ctx := context.Background()
+	keycloakClient, _ := newFakeKeycloakClient(t)
+
+	realm := &Realm{
+		Realm:       "test",
+		SslRequired: "external",
+		SmtpServer:  SmtpServer{Host: "smtp.example.com", Auth: true, AuthType: "token"},
+	}
+
+	var unsupported *UnsupportedFeatureError
+	if err := keycloakClient.ValidateRealm(ctx, realm); !errors.As(err, &unsupported) || unsupported.Feature != FeatureSmtpTokenAuth {
+		t.Errorf("expected token authentication to be unsupported by keycloak 26.0, got %v", err)
+	}
+}
+
+func TestKeycloakClientFakeUserProfileAttributes(t *testing.T) {
+	ctx := context.Background()
+	keycloakClient, _ := newFakeKeycloakClient(t)
+
+	newFakeRealm(t, keycloakClient, "test")
+
+	department := &RealmUserProfileAttribute{
+		Name: "department",
+		Validations: map[string]RealmUserProfileValidationConfig{
+			"options": {"options": []interface{}{"sales", "support"}},
+		},
+	}
+	if err := keycloakClient.PutRealmUserProfileAttribute(ctx, "test", department); err != nil {
+		t.Fatal(err)
+	}
+
+	// each update reads and writes the whole profile, without the lock concurrent updates would drop attributes
+	var wg sync.WaitGroup
+	errs := make(chan error, 10)
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			errs <- keycloakClient.PutRealmUserProfileAttribute(ctx, "test", &RealmUserProfileAttribute{Name: fmt.Sprintf("attribute-%d", i), Multivalued: true})
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	department.DefaultValue = "sales"
+	if err := keycloakClient.PutRealmUserProfileAttribute(ctx, "test", department); err != nil {
+		t.Fatal(err)
+	}
+	if err := keycloakClient.DeleteRealmUserProfileAttribute(ctx, "test", "attribute-0"); err != nil {
+		t.Fatal(err)
+	}
+
+	realmUserProfile, err := keycloakClient.getRealmUserProfile(ctx, "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the four default attributes, department and attribute-1 to attribute-9
+	if len(realmUserProfile.Attributes) != 14 {
+		t.Errorf("expected 14 attributes, got %d", len(realmUserProfile.Attributes))
+	}
+	if realmUserProfile.Attributes[4].Name != "department" || realmUserProfile.Attributes[4].DefaultValue != "sales" {
+		t.Errorf("expected department to be updated in place, got %+v", realmUserProfile.Attributes[4])
+	}
+	if _, ok := realmUserProfile.Attributes[4].Validations["options"]["options"].([]interface{}); !ok {
+		t.Errorf("expected the options to be written back as a list, got %#v", realmUserProfile.Attributes[4].Validations)
+	}
+
+	attribute, err := keycloakClient.GetRealmUserProfileAttribute(ctx, "test", "attribute-0")
+	if err != nil || attribute != nil {
+		t.Errorf("expected attribute-0 to be deleted, got %+v, %v", attribute, err)
+	}
+
+	attribute, err = keycloakClient.GetRealmUserProfileAttribute(ctx, "test", "attribute-1")
+	if err != nil || attribute == nil || !attribute.Multivalued {
+		t.Errorf("expected attribute-1 to be multivalued, got %+v, %v", attribute, err)
+	}
+}
+
+func TestKeycloakClientFakeUserProfileGroups(t *testing.T) {
+	ctx := context.Background()
+	keycloakClient, _ := newFakeKeycloakClient(t)
+
+	newFakeRealm(t, keycloakClient, "test")
+
+	if err := keycloakClient.PutRealmUserProfileAttribute(ctx, "test", &RealmUserProfileAttribute{Name: "team", Group: "work"}); !ErrorIsValidation(err) {
+		t.Errorf("expected an attribute of an unknown group to be rejected, got %v", err)
+	}
+
+	if err := keycloakClient.PutRealmUserProfileGroup(ctx, "test", &RealmUserProfileGroup{Name: "work", DisplayHeader: "Work"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := keycloakClient.PutRealmUserProfileAttribute(ctx, "test", &RealmUserProfileAttribute{Name: "team", Group: "work"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := keycloakClient.PutRealmUserProfileGroup(ctx, "test", &RealmUserProfileGroup{Name: "work", DisplayHeader: "Job"}); err != nil {
+		t.Fatal(err)
+	}
+
+	group, err := keycloakClient.GetRealmUserProfileGroup(ctx, "test", "work")
+	if err != nil || group == nil || group.DisplayHeader != "Job" {
+		t.Errorf("expected the group to be updated, got %+v, %v", group, err)
+	}
+
+	if err := keycloakClient.DeleteRealmUserProfileGroup(ctx, "test", "user-metadata"); err != nil {
+		t.Fatal(err)
+	}
+
+	realmUserProfile, err := keycloakClient.GetRealmUserProfile(ctx, "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(realmUserProfile.Groups) != 1 || realmUserProfile.Groups[0].Name != "work" {
+		t.Errorf("expected only the work group to be left, got %+v", realmUserProfile.Groups)
+	}
+}
+
+func TestKeycloakClientFakeClientInitialAccessTokens(t *testing.T) {
+	ctx := context.Background()
+	keycloakClient, _ := newFakeKeycloakClient(t)
+
+	newFakeRealm(t, keycloakClient, "test")
+
+	token := &ClientInitialAccessToken{RealmId: "test", Expiration: 3600, Count: 5}
+	if err := keycloakClient.NewClientInitialAccessToken(ctx, token); err != nil {
+		t.Fatal(err)
+	}
+	if token.Id == "" || token.Token == "" || token.RemainingCount != 5 {
+		t.Errorf("expected the created token to be returned, got %+v", token)
+	}
+
+	listed, err := keycloakClient.GetClientInitialAccessToken(ctx, "test", token.Id)
+	if err != nil || listed == nil {
+		t.Fatalf("expected the token to be listed, got %+v, %v", listed, err)
+	}
+	if listed.Token != "" || listed.Expiration != 3600 {
+		t.Errorf("expected the token to be listed without its value, got %+v", listed)
+	}
+	if !listed.expired(time.Unix(listed.Timestamp+3600, 0)) || listed.expired(time.Unix(listed.Timestamp+3599, 0)) {
+		t.Errorf("expected the token to expire an hour after it was created")
+	}
+
+	if err := keycloakClient.DeleteClientInitialAccessToken(ctx, "test", token.Id); err != nil {
+		t.Fatal(err)
+	}
+
+	listed, err = keycloakClient.GetClientInitialAccessToken(ctx, "test", token.Id)
+	if err != nil || listed != nil {
+		t.Errorf("expected the token to be gone, got %+v, %v", listed, err)
+	}
+	if err := keycloakClient.DeleteClientInitialAccessToken(ctx, "test", token.Id); !ErrorIs404(err) {
+		t.Errorf("expected deleting the token again to return 404, got %v", err)
+	}
+}
+
+func TestKeycloakClientFakeClientRegistrationPolicies(t *testing.T) {
+	ctx := context.Background()
+	keycloakClient, _ := newFakeKeycloakClient(t)
+
+	newFakeRealm(t, keycloakClient, "test")
+
+	policy := &ClientRegistrationPolicy{
+		Name:       "Trusted Hosts",
+		RealmId:    "test",
+		SubType:    "anonymous",
+		ProviderId: "trusted-hosts",
+		Config: map[string][]string{
+			"trusted-hosts":          nil,
+			"client-uris-must-match": {"true"},
+		},
+	}
+	if err := keycloakClient.NewClientRegistrationPolicy(ctx, policy); err != nil {
+		t.Fatal(err)
+	}
+
+	policy.Config["trusted-hosts"] = []string{"example.com"}
+	if err := keycloakClient.UpdateClientRegistrationPolicy(ctx, policy); err != nil {
+		t.Fatal(err)
+	}
+
+	policies, err := keycloakClient.GetClientRegistrationPolicies(ctx, "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(policies) != 1 || policies[0].SubType != "anonymous" || policies[0].Config["trusted-hosts"][0] != "example.com" {
+		t.Errorf("expected the updated policy, got %+v", policies)
+	}
+
+	if err := keycloakClient.DeleteClientRegistrationPolicy(ctx, "test", policy.Id); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := keycloakClient.GetClientRegistrationPolicy(ctx, "test", policy.Id); !ErrorIs404(err) {
+		t.Errorf("expected the policy to be deleted, got %v", err)
+	}
+}
@@ -0,0 +1,191 @@
+package keycloak
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestHttpClientRetriesRetryableStatusCodes(t *testing.T) {
+	var attempts int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if string(body) != `{"realm":"test"}` {
+			t.Errorf("expected the request body to be sent with every attempt, got %s", body)
+		}
+
+		if atomic.AddInt32(&attempts, 1) < 3 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	httpClient, err := newHttpClient(false, 5, "", "", "", 2, time.Millisecond, time.Millisecond*10, DefaultRetryableStatusCodes, 0, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	response, err := httpClient.Post(server.URL, "application/json", strings.NewReader(`{"realm":"test"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusCreated || attempts != 3 {
+		t.Errorf("expected the request to succeed after 3 attempts, got %s after %d attempts", response.Status, attempts)
+	}
+}
+
+func TestHttpClientReturnsLastResponseWhenRetriesAreExhausted(t *testing.T) {
+	var attempts int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&attempts, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+		w.Write([]byte("unavailable"))
+	}))
+	defer server.Close()
+
+	httpClient, err := newHttpClient(false, 5, "", "", "", 1, time.Millisecond, time.Millisecond, DefaultRetryableStatusCodes, 0, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	response, err := httpClient.Get(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer response.Body.Close()
+
+	body, _ := io.ReadAll(response.Body)
+	if response.StatusCode != http.StatusServiceUnavailable || string(body) != "unavailable" || attempts != 2 {
+		t.Errorf("expected the last response after 2 attempts, got %s %q after %d attempts", response.Status, body, attempts)
+	}
+}
+
+func TestHttpClientRetriesInternalServerErrorsByDefault(t *testing.T) {
+	var attempts int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&attempts, 1) == 1 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	httpClient, err := newHttpClient(false, 5, "", "", "", 1, time.Millisecond, time.Millisecond, DefaultRetryableStatusCodes, 0, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	response, err := httpClient.Get(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	response.Body.Close()
+
+	if response.StatusCode != http.StatusOK || attempts != 2 {
+		t.Errorf("expected a 500 to be retried, got %s after %d attempts", response.Status, attempts)
+	}
+}
+
+func TestHttpClientDoesNotRetryOtherStatusCodes(t *testing.T) {
+	var attempts int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&attempts, 1)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	httpClient, err := newHttpClient(false, 5, "", "", "", 3, time.Millisecond, time.Millisecond, []int{http.StatusTooManyRequests}, 0, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	response, err := httpClient.Get(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	response.Body.Close()
+
+	if attempts != 1 {
+		t.Errorf("expected a 500 not to be retried, got %d attempts", attempts)
+	}
+}
+
+func TestHttpClientRespectsRetryAfter(t *testing.T) {
+	var attempts int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&attempts, 1) == 1 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	httpClient, err := newHttpClient(false, 5, "", "", "", 1, time.Millisecond, time.Millisecond, DefaultRetryableStatusCodes, 0, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	start := time.Now()
+	response, err := httpClient.Get(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	response.Body.Close()
+
+	if response.StatusCode != http.StatusOK || attempts != 2 {
+		t.Errorf("expected the request to succeed after 2 attempts, got %s after %d attempts", response.Status, attempts)
+	}
+	if elapsed := time.Since(start); elapsed < time.Second {
+		t.Errorf("expected the retry to wait for the Retry-After header, only waited %s", elapsed)
+	}
+}
+
+func TestHttpClientRateLimitIsSharedByConcurrentRequests(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	httpClient, err := newHttpClient(false, 5, "", "", "", 0, 0, 0, nil, 20, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	start := time.Now()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			response, err := httpClient.Get(server.URL)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			response.Body.Close()
+		}()
+	}
+	wg.Wait()
+
+	// the first request is sent immediately, the remaining four are spaced 50ms apart
+	if elapsed := time.Since(start); elapsed < time.Millisecond*190 {
+		t.Errorf("expected 5 requests at 20 requests per second to take at least 200ms, took %s", elapsed)
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"crypto/tls"
 	"crypto/x509"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"io/ioutil"
@@ -13,89 +14,205 @@ import (
 	"net/http/cookiejar"
 	"net/url"
 	"os"
-	"regexp"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/hashicorp/go-version"
 
 	"golang.org/x/net/publicsuffix"
+	"golang.org/x/time/rate"
 
 	"github.com/hashicorp/go-retryablehttp"
 )
 
 type KeycloakClient struct {
-	baseUrl           string
-	realm             string
-	clientCredentials *ClientCredentials
-	httpClient        *http.Client
-	initialLogin      bool
-	userAgent         string
-	version           *version.Version
-	additionalHeaders map[string]string
-	debug             bool
-	redHatSSO         bool
+	baseUrl               string
+	realm                 string
+	clientCredentials     *ClientCredentials
+	clientAssertionSigner *clientAssertionSigner
+	staticAccessToken     bool
+	accessTokenRefreshAt  time.Time
+	refreshTokenExpiresAt time.Time
+	tokenMutex            sync.Mutex // guards the tokens within clientCredentials, accessTokenRefreshAt and refreshTokenExpiresAt
+	httpClient            *http.Client
+	userAgent             string
+	capabilities          *ServerCapabilities
+	capabilitiesMutex     sync.Mutex
+	additionalHeaders     map[string]string
+	debug                 bool
+	redHatSSO             bool
+	redactor              *redactor
+	cassettes             atomic.Pointer[CassetteRecorder]
+	realmLocks            sync.Map // maps a realm id to the *sync.Mutex returned by lockRealm
 }
 
 type ClientCredentials struct {
-	ClientId     string
-	ClientSecret string
-	Username     string
-	Password     string
-	GrantType    string
-	AccessToken  string `json:"access_token"`
-	RefreshToken string `json:"refresh_token"`
-	TokenType    string `json:"token_type"`
+	ClientId            string
+	ClientSecret        string
+	ClientAuthenticator string
+	Username            string
+	Password            string
+	GrantType           string
+	SubjectToken        string
+	SubjectTokenFile    string
+	SubjectTokenType    string
+	SubjectIssuer       string
+	AccessToken         string `json:"access_token"`
+	RefreshToken        string `json:"refresh_token"`
+	TokenType           string `json:"token_type"`
+	ExpiresIn           int    `json:"expires_in"`
+	RefreshExpiresIn    int    `json:"refresh_expires_in"`
 }
 
 const (
 	apiUrl   = "/admin"
 	tokenUrl = "%s/realms/%s/protocol/openid-connect/token"
+
+	// access tokens are refreshed this long before they expire, or halfway through their lifespan if that is shorter
+	accessTokenRefreshSkew = time.Second * 30
+)
+
+// these are the grants that can be used to log in with a token issued by another identity provider, such as a workload
+// identity token from GitHub Actions or Kubernetes
+const (
+	SubjectTokenGrantTokenExchange = "token-exchange"
+	SubjectTokenGrantJwtBearer     = "jwt-bearer"
+
+	grantTypeTokenExchange  = "urn:ietf:params:oauth:grant-type:token-exchange"
+	grantTypeJwtBearer      = "urn:ietf:params:oauth:grant-type:jwt-bearer"
+	DefaultSubjectTokenType = "urn:ietf:params:oauth:token-type:jwt"
 )
 
-// https://access.redhat.com/articles/2342881
-var redHatSSO7VersionMap = map[int]string{
-	6: "18.0.0",
-	5: "15.0.6",
-	4: "9.0.17",
+var SubjectTokenGrants = []string{
+	SubjectTokenGrantTokenExchange,
+	SubjectTokenGrantJwtBearer,
 }
 
-func NewKeycloakClient(ctx context.Context, url, basePath, clientId, clientSecret, realm, username, password string, initialLogin bool, clientTimeout int, caCert string, tlsInsecureSkipVerify bool, userAgent string, redHatSSO bool, additionalHeaders map[string]string) (*KeycloakClient, error) {
-	clientCredentials := &ClientCredentials{
-		ClientId:     clientId,
-		ClientSecret: clientSecret,
+// KeycloakClientConfig holds the settings NewKeycloakClient creates a client with, mostly as they are configured on the
+// provider
+type KeycloakClientConfig struct {
+	Url      string
+	BasePath string
+	// Realm is the realm the client logs in to
+	Realm string
+
+	ClientId            string
+	ClientSecret        string
+	ClientAuthenticator string
+	JwtSigningAlg       string
+	JwtSigningKey       string
+	JwtSigningKeyId     string
+	Username            string
+	Password            string
+	// AccessToken is a pre-issued token that is used instead of logging in
+	AccessToken       string
+	SubjectTokenGrant string
+	SubjectToken      string
+	SubjectTokenFile  string
+	SubjectTokenType  string
+	SubjectIssuer     string
+	InitialLogin      bool
+
+	// ClientTimeout is in seconds
+	ClientTimeout         int
+	CaCert                string
+	TlsInsecureSkipVerify bool
+	TlsClientCert         string
+	TlsClientPrivateKey   string
+
+	MaxRetries           int
+	MinBackoff           time.Duration
+	MaxBackoff           time.Duration
+	RetryableStatusCodes []int
+	// RequestsPerSecond limits the rate of requests, 0 disables the limit
+	RequestsPerSecond float64
+
+	UserAgent                string
+	RedHatSSO                bool
+	AdditionalHeaders        map[string]string
+	AdditionalRedactedFields []string
+}
+
+func NewKeycloakClient(ctx context.Context, config KeycloakClientConfig) (*KeycloakClient, error) {
+	if config.ClientAuthenticator == "" {
+		config.ClientAuthenticator = ClientAuthenticatorClientSecret
 	}
-	if password != "" && username != "" {
-		clientCredentials.Username = username
-		clientCredentials.Password = password
+
+	clientCredentials := &ClientCredentials{
+		ClientId:            config.ClientId,
+		ClientSecret:        config.ClientSecret,
+		ClientAuthenticator: config.ClientAuthenticator,
+	}
+	if config.AccessToken != "" {
+		// a pre-issued access token is used as is, there is no grant that can be used to obtain a new one
+		clientCredentials.AccessToken = config.AccessToken
+		clientCredentials.TokenType = "Bearer"
+	} else if config.SubjectToken != "" || config.SubjectTokenFile != "" {
+		clientCredentials.SubjectToken = config.SubjectToken
+		clientCredentials.SubjectTokenFile = config.SubjectTokenFile
+		clientCredentials.SubjectIssuer = config.SubjectIssuer
+		clientCredentials.SubjectTokenType = config.SubjectTokenType
+		if config.SubjectTokenType == "" {
+			clientCredentials.SubjectTokenType = DefaultSubjectTokenType
+		}
+
+		switch config.SubjectTokenGrant {
+		case SubjectTokenGrantTokenExchange, "":
+			clientCredentials.GrantType = grantTypeTokenExchange
+		case SubjectTokenGrantJwtBearer:
+			clientCredentials.GrantType = grantTypeJwtBearer
+		default:
+			return nil, fmt.Errorf("unsupported subject token grant %s", config.SubjectTokenGrant)
+		}
+	} else if config.Password != "" && config.Username != "" {
+		clientCredentials.Username = config.Username
+		clientCredentials.Password = config.Password
 		clientCredentials.GrantType = "password"
-	} else if clientSecret != "" {
+	} else if config.ClientSecret != "" || config.ClientAuthenticator == ClientAuthenticatorClientJwt || config.ClientAuthenticator == ClientAuthenticatorClientX509 {
 		clientCredentials.GrantType = "client_credentials"
 	} else {
-		if initialLogin {
-			return nil, fmt.Errorf("must specify client id, username and password for password grant, or client id and secret for client credentials grant")
+		if config.InitialLogin {
+			return nil, fmt.Errorf("must specify client id, username and password for password grant, client id and secret or signing key for client credentials grant, a subject token, or an access token")
 		} else {
 			tflog.Warn(ctx, "missing required keycloak credentials, but proceeding anyways as initial_login is false")
 		}
 	}
 
-	httpClient, err := newHttpClient(tlsInsecureSkipVerify, clientTimeout, caCert)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create http client: %v", err)
+	var signer *clientAssertionSigner
+	if config.AccessToken == "" {
+		var err error
+		signer, err = newClientAssertionSigner(config.ClientAuthenticator, config.JwtSigningAlg, config.JwtSigningKey, config.JwtSigningKeyId, config.ClientSecret)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create client assertion signer: %v", err)
+		}
+
+		if config.ClientAuthenticator == ClientAuthenticatorClientX509 && (config.TlsClientCert == "" || config.TlsClientPrivateKey == "") {
+			return nil, fmt.Errorf("a tls client certificate and private key are required by the %s client authenticator", config.ClientAuthenticator)
+		}
 	}
 
 	keycloakClient := KeycloakClient{
-		baseUrl:           url + basePath,
-		clientCredentials: clientCredentials,
-		httpClient:        httpClient,
-		initialLogin:      initialLogin,
-		realm:             realm,
-		userAgent:         userAgent,
-		redHatSSO:         redHatSSO,
-		additionalHeaders: additionalHeaders,
+		baseUrl:               config.Url + config.BasePath,
+		clientCredentials:     clientCredentials,
+		clientAssertionSigner: signer,
+		staticAccessToken:     config.AccessToken != "",
+		realm:                 config.Realm,
+		userAgent:             config.UserAgent,
+		redHatSSO:             config.RedHatSSO,
+		additionalHeaders:     config.AdditionalHeaders,
+		redactor:              newRedactor(config.AdditionalRedactedFields),
+	}
+
+	httpClient, err := newHttpClient(config.TlsInsecureSkipVerify, config.ClientTimeout, config.CaCert, config.TlsClientCert, config.TlsClientPrivateKey, config.MaxRetries, config.MinBackoff, config.MaxBackoff, config.RetryableStatusCodes, config.RequestsPerSecond, keycloakClient.cassettes.Load)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create http client: %v", err)
 	}
 
-	if keycloakClient.initialLogin {
+	keycloakClient.httpClient = httpClient
+
+	if config.InitialLogin {
 		err = keycloakClient.login(ctx)
 		if err != nil {
 			return nil, fmt.Errorf("failed to perform initial login to Keycloak: %v", err)
@@ -112,11 +229,44 @@ func NewKeycloakClient(ctx context.Context, url, basePath, clientId, clientSecre
 }
 
 func (keycloakClient *KeycloakClient) login(ctx context.Context) error {
+	// a static access token skips the token request, but the server version still needs to be looked up
+	if !keycloakClient.staticAccessToken {
+		keycloakClient.tokenMutex.Lock()
+		err := keycloakClient.requestAccessToken(ctx)
+		keycloakClient.tokenMutex.Unlock()
+
+		if err != nil {
+			return err
+		}
+	}
+
+	keycloakClient.capabilitiesMutex.Lock()
+	defer keycloakClient.capabilitiesMutex.Unlock()
+
+	return keycloakClient.loadServerCapabilities(ctx)
+}
+
+// getVersion returns the version of the server, which is looked up the first time it is needed when initial_login is
+// false
+func (keycloakClient *KeycloakClient) getVersion(ctx context.Context) (*version.Version, error) {
+	capabilities, err := keycloakClient.GetServerCapabilities(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return capabilities.Version, nil
+}
+
+// requestAccessToken must be called while holding tokenMutex
+func (keycloakClient *KeycloakClient) requestAccessToken(ctx context.Context) error {
 	accessTokenUrl := fmt.Sprintf(tokenUrl, keycloakClient.baseUrl, keycloakClient.realm)
-	accessTokenData := keycloakClient.getAuthenticationFormData()
+	accessTokenData, err := keycloakClient.getAuthenticationFormData(accessTokenUrl)
+	if err != nil {
+		return err
+	}
 
 	tflog.Debug(ctx, "Login request", map[string]interface{}{
-		"request": accessTokenData.Encode(),
+		"request": keycloakClient.redactor.form(accessTokenData),
 	})
 
 	accessTokenRequest, err := http.NewRequestWithContext(ctx, http.MethodPost, accessTokenUrl, strings.NewReader(accessTokenData.Encode()))
@@ -138,76 +288,48 @@ func (keycloakClient *KeycloakClient) login(ctx context.Context) error {
 	if err != nil {
 		return err
 	}
+
+	defer accessTokenResponse.Body.Close()
+
 	if accessTokenResponse.StatusCode != http.StatusOK {
 		return fmt.Errorf("error sending POST request to %s: %s", accessTokenUrl, accessTokenResponse.Status)
 	}
 
-	defer accessTokenResponse.Body.Close()
-
 	body, _ := ioutil.ReadAll(accessTokenResponse.Body)
 
 	tflog.Debug(ctx, "Login response", map[string]interface{}{
-		"response": string(body),
+		"response": keycloakClient.redactor.body(body),
 	})
 
-	var clientCredentials ClientCredentials
-	err = json.Unmarshal(body, &clientCredentials)
-	if err != nil {
-		return err
-	}
-
-	keycloakClient.clientCredentials.AccessToken = clientCredentials.AccessToken
-	keycloakClient.clientCredentials.RefreshToken = clientCredentials.RefreshToken
-	keycloakClient.clientCredentials.TokenType = clientCredentials.TokenType
+	return keycloakClient.setAccessToken(body)
+}
 
-	info, err := keycloakClient.GetServerInfo(ctx)
-	if err != nil {
-		return err
+// refresh must be called while holding tokenMutex
+func (keycloakClient *KeycloakClient) refresh(ctx context.Context) error {
+	if keycloakClient.staticAccessToken {
+		return fmt.Errorf("the configured access token was rejected, and it cannot be refreshed")
 	}
 
-	serverVersion := info.SystemInfo.ServerVersion
-	if strings.Contains(serverVersion, ".GA") {
-		serverVersion = strings.ReplaceAll(info.SystemInfo.ServerVersion, ".GA", "")
-	} else {
-		regex, err := regexp.Compile(`\.redhat-\w+`)
-
-		if err != nil {
-			fmt.Println("Error compiling regex:", err)
-			return err
-		}
+	// without a usable refresh token, e.g. once the session has expired, the client logs in again
+	if !keycloakClient.hasUsableRefreshToken() {
+		tflog.Debug(ctx, "No usable refresh token, logging in again")
 
-		// Check if the pattern is found in serverVersion
-		if regex.MatchString(serverVersion) {
-			// Replace the matched pattern with an empty string
-			serverVersion = regex.ReplaceAllString(serverVersion, "")
-		}
+		return keycloakClient.requestAccessToken(ctx)
 	}
 
-	v, err := version.NewVersion(serverVersion)
+	refreshTokenUrl := fmt.Sprintf(tokenUrl, keycloakClient.baseUrl, keycloakClient.realm)
+	refreshTokenData := url.Values{}
+	refreshTokenData.Set("client_id", keycloakClient.clientCredentials.ClientId)
+	refreshTokenData.Set("grant_type", "refresh_token")
+	refreshTokenData.Set("refresh_token", keycloakClient.clientCredentials.RefreshToken)
+
+	err := keycloakClient.setClientAuthenticationFormData(refreshTokenData, refreshTokenUrl)
 	if err != nil {
 		return err
 	}
 
-	if keycloakClient.redHatSSO {
-		keycloakVersion, err := version.NewVersion(redHatSSO7VersionMap[v.Segments()[1]])
-		if err != nil {
-			return err
-		}
-
-		keycloakClient.version = keycloakVersion
-	} else {
-		keycloakClient.version = v
-	}
-
-	return nil
-}
-
-func (keycloakClient *KeycloakClient) refresh(ctx context.Context) error {
-	refreshTokenUrl := fmt.Sprintf(tokenUrl, keycloakClient.baseUrl, keycloakClient.realm)
-	refreshTokenData := keycloakClient.getAuthenticationFormData()
-
 	tflog.Debug(ctx, "Refresh request", map[string]interface{}{
-		"request": refreshTokenData.Encode(),
+		"request": keycloakClient.redactor.form(refreshTokenData),
 	})
 
 	refreshTokenRequest, err := http.NewRequestWithContext(ctx, http.MethodPost, refreshTokenUrl, strings.NewReader(refreshTokenData.Encode()))
@@ -235,18 +357,29 @@ func (keycloakClient *KeycloakClient) refresh(ctx context.Context) error {
 	body, _ := ioutil.ReadAll(refreshTokenResponse.Body)
 
 	tflog.Debug(ctx, "Refresh response", map[string]interface{}{
-		"response": string(body),
+		"response": keycloakClient.redactor.body(body),
 	})
 
-	// Handle 401 "User or client no longer has role permissions for client key" until I better understand why that happens in the first place
+	// keycloak rejects the refresh token with a 400 when the session it belongs to has ended, e.g. after a logout, and
+	// with "User or client no longer has role permissions for client key" when the roles of the user have changed
 	if refreshTokenResponse.StatusCode == http.StatusBadRequest {
-		tflog.Debug(ctx, "Unexpected 400, attempting to log in again")
+		tflog.Debug(ctx, "Refresh token was rejected, attempting to log in again")
 
-		return keycloakClient.login(ctx)
+		return keycloakClient.requestAccessToken(ctx)
+	}
+	if refreshTokenResponse.StatusCode != http.StatusOK {
+		return fmt.Errorf("error sending POST request to %s: %s", refreshTokenUrl, refreshTokenResponse.Status)
 	}
 
+	return keycloakClient.setAccessToken(body)
+}
+
+// setAccessToken stores the tokens from a token response, and schedules the next refresh based on expires_in. The
+// refresh token is used until refresh_expires_in, 0 meaning it doesn't expire, as with offline tokens. It must be called
+// while holding tokenMutex.
+func (keycloakClient *KeycloakClient) setAccessToken(body []byte) error {
 	var clientCredentials ClientCredentials
-	err = json.Unmarshal(body, &clientCredentials)
+	err := json.Unmarshal(body, &clientCredentials)
 	if err != nil {
 		return err
 	}
@@ -255,10 +388,77 @@ func (keycloakClient *KeycloakClient) refresh(ctx context.Context) error {
 	keycloakClient.clientCredentials.RefreshToken = clientCredentials.RefreshToken
 	keycloakClient.clientCredentials.TokenType = clientCredentials.TokenType
 
+	keycloakClient.accessTokenRefreshAt = time.Time{}
+	if clientCredentials.ExpiresIn > 0 {
+		expiresIn := time.Second * time.Duration(clientCredentials.ExpiresIn)
+		keycloakClient.accessTokenRefreshAt = time.Now().Add(expiresIn - min(accessTokenRefreshSkew, expiresIn/2))
+	}
+
+	keycloakClient.refreshTokenExpiresAt = time.Time{}
+	if clientCredentials.RefreshExpiresIn > 0 {
+		keycloakClient.refreshTokenExpiresAt = time.Now().Add(time.Second * time.Duration(clientCredentials.RefreshExpiresIn))
+	}
+
 	return nil
 }
 
-func (keycloakClient *KeycloakClient) getAuthenticationFormData() url.Values {
+// hasUsableRefreshToken must be called while holding tokenMutex. Only the sessions of users are refreshed, the other
+// grants log in again instead: a client can always obtain a new token, and a subject token read from a file may have
+// been rotated and has to be exchanged again.
+func (keycloakClient *KeycloakClient) hasUsableRefreshToken() bool {
+	if keycloakClient.clientCredentials.GrantType != "password" || keycloakClient.clientCredentials.RefreshToken == "" {
+		return false
+	}
+
+	return keycloakClient.refreshTokenExpiresAt.IsZero() || time.Now().Before(keycloakClient.refreshTokenExpiresAt)
+}
+
+// getAuthorization returns the Authorization header for the next request. The first request logs in, and the access
+// token is refreshed shortly before it expires.
+func (keycloakClient *KeycloakClient) getAuthorization(ctx context.Context) (string, error) {
+	keycloakClient.tokenMutex.Lock()
+	defer keycloakClient.tokenMutex.Unlock()
+
+	if keycloakClient.clientCredentials.AccessToken == "" {
+		err := keycloakClient.requestAccessToken(ctx)
+		if err != nil {
+			return "", fmt.Errorf("error logging in: %s", err)
+		}
+	} else if !keycloakClient.accessTokenRefreshAt.IsZero() && time.Now().After(keycloakClient.accessTokenRefreshAt) {
+		tflog.Debug(ctx, "Access token is about to expire, attempting refresh")
+
+		err := keycloakClient.refresh(ctx)
+		if err != nil {
+			return "", fmt.Errorf("error refreshing credentials: %s", err)
+		}
+	}
+
+	return keycloakClient.authorization(), nil
+}
+
+// refreshRejectedAuthorization refreshes the access token after it was rejected by keycloak. When several concurrent
+// requests were rejected with the same token, only the first one refreshes it, and the others use the new token.
+func (keycloakClient *KeycloakClient) refreshRejectedAuthorization(ctx context.Context, rejectedAuthorization string) (string, error) {
+	keycloakClient.tokenMutex.Lock()
+	defer keycloakClient.tokenMutex.Unlock()
+
+	if keycloakClient.authorization() == rejectedAuthorization {
+		err := keycloakClient.refresh(ctx)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	return keycloakClient.authorization(), nil
+}
+
+func (keycloakClient *KeycloakClient) authorization() string {
+	return fmt.Sprintf("%s %s", keycloakClient.clientCredentials.TokenType, keycloakClient.clientCredentials.AccessToken)
+}
+
+// getAuthenticationFormData builds the token request for the configured grant. The client authenticates with its secret,
+// a signed JWT that is only valid for the given token endpoint, or with its TLS client certificate alone.
+func (keycloakClient *KeycloakClient) getAuthenticationFormData(accessTokenUrl string) (url.Values, error) {
 	authenticationFormData := url.Values{}
 	authenticationFormData.Set("client_id", keycloakClient.clientCredentials.ClientId)
 	authenticationFormData.Set("grant_type", keycloakClient.clientCredentials.GrantType)
@@ -266,27 +466,75 @@ func (keycloakClient *KeycloakClient) getAuthenticationFormData() url.Values {
 	if keycloakClient.clientCredentials.GrantType == "password" {
 		authenticationFormData.Set("username", keycloakClient.clientCredentials.Username)
 		authenticationFormData.Set("password", keycloakClient.clientCredentials.Password)
+	} else if keycloakClient.clientCredentials.GrantType == grantTypeTokenExchange || keycloakClient.clientCredentials.GrantType == grantTypeJwtBearer {
+		subjectToken, err := keycloakClient.clientCredentials.getSubjectToken()
+		if err != nil {
+			return nil, err
+		}
 
-		if keycloakClient.clientCredentials.ClientSecret != "" {
-			authenticationFormData.Set("client_secret", keycloakClient.clientCredentials.ClientSecret)
+		if keycloakClient.clientCredentials.GrantType == grantTypeJwtBearer {
+			authenticationFormData.Set("assertion", subjectToken)
+		} else {
+			authenticationFormData.Set("subject_token", subjectToken)
+			authenticationFormData.Set("subject_token_type", keycloakClient.clientCredentials.SubjectTokenType)
+
+			if keycloakClient.clientCredentials.SubjectIssuer != "" {
+				authenticationFormData.Set("subject_issuer", keycloakClient.clientCredentials.SubjectIssuer)
+			}
 		}
+	}
 
-	} else if keycloakClient.clientCredentials.GrantType == "client_credentials" {
-		authenticationFormData.Set("client_secret", keycloakClient.clientCredentials.ClientSecret)
+	err := keycloakClient.setClientAuthenticationFormData(authenticationFormData, accessTokenUrl)
+	if err != nil {
+		return nil, err
 	}
 
-	return authenticationFormData
+	return authenticationFormData, nil
 }
 
-func (keycloakClient *KeycloakClient) addRequestHeaders(request *http.Request) {
-	tokenType := keycloakClient.clientCredentials.TokenType
-	accessToken := keycloakClient.clientCredentials.AccessToken
+// setClientAuthenticationFormData adds the fields that authenticate the client to the form data of a token request
+func (keycloakClient *KeycloakClient) setClientAuthenticationFormData(authenticationFormData url.Values, accessTokenUrl string) error {
+	switch keycloakClient.clientCredentials.ClientAuthenticator {
+	case ClientAuthenticatorClientJwt, ClientAuthenticatorClientSecretJwt:
+		clientAssertion, err := keycloakClient.clientAssertionSigner.sign(keycloakClient.clientCredentials.ClientId, accessTokenUrl)
+		if err != nil {
+			return fmt.Errorf("error signing client assertion: %v", err)
+		}
+
+		authenticationFormData.Set("client_assertion_type", clientAssertionType)
+		authenticationFormData.Set("client_assertion", clientAssertion)
+	case ClientAuthenticatorClientX509:
+		// the client is authenticated by the certificate presented during the TLS handshake
+	default:
+		if keycloakClient.clientCredentials.GrantType == "client_credentials" || keycloakClient.clientCredentials.ClientSecret != "" {
+			authenticationFormData.Set("client_secret", keycloakClient.clientCredentials.ClientSecret)
+		}
+	}
 
+	return nil
+}
+
+// getSubjectToken returns the token that is exchanged during login. Tokens that are read from a file are read again for
+// every login and refresh, since workload identity tokens are short-lived and rotated on disk.
+func (clientCredentials *ClientCredentials) getSubjectToken() (string, error) {
+	if clientCredentials.SubjectTokenFile == "" {
+		return clientCredentials.SubjectToken, nil
+	}
+
+	subjectToken, err := os.ReadFile(clientCredentials.SubjectTokenFile)
+	if err != nil {
+		return "", fmt.Errorf("error reading subject token file: %v", err)
+	}
+
+	return strings.TrimSpace(string(subjectToken)), nil
+}
+
+func (keycloakClient *KeycloakClient) addRequestHeaders(request *http.Request, authorization string) {
 	for header, value := range keycloakClient.additionalHeaders {
 		request.Header.Set(header, value)
 	}
 
-	request.Header.Set("Authorization", fmt.Sprintf("%s %s", tokenType, accessToken))
+	request.Header.Set("Authorization", authorization)
 	request.Header.Set("Accept", "application/json")
 
 	if keycloakClient.userAgent != "" {
@@ -303,12 +551,9 @@ func (keycloakClient *KeycloakClient) addRequestHeaders(request *http.Request) {
 Sends an HTTP request and refreshes credentials on 403 or 401 errors
 */
 func (keycloakClient *KeycloakClient) sendRequest(ctx context.Context, request *http.Request, body []byte) ([]byte, string, error) {
-	if !keycloakClient.initialLogin {
-		keycloakClient.initialLogin = true
-		err := keycloakClient.login(ctx)
-		if err != nil {
-			return nil, "", fmt.Errorf("error logging in: %s", err)
-		}
+	authorization, err := keycloakClient.getAuthorization(ctx)
+	if err != nil {
+		return nil, "", err
 	}
 
 	requestMethod := request.Method
@@ -321,38 +566,40 @@ func (keycloakClient *KeycloakClient) sendRequest(ctx context.Context, request *
 
 	if body != nil {
 		request.Body = ioutil.NopCloser(bytes.NewReader(body))
-		requestLogArgs["body"] = string(body)
+		requestLogArgs["body"] = keycloakClient.redactor.body(body)
 	}
 
-	tflog.Debug(ctx, "Sending request", requestLogArgs)
+	keycloakClient.addRequestHeaders(request, authorization)
 
-	keycloakClient.addRequestHeaders(request)
+	requestLogArgs["headers"] = keycloakClient.redactor.headers(request.Header)
+
+	tflog.Debug(ctx, "Sending request", requestLogArgs)
 
 	response, err := keycloakClient.httpClient.Do(request)
 	if err != nil {
-		return nil, "", fmt.Errorf("error sending request: %v", err)
+		return nil, "", fmt.Errorf("error sending request: %w", err)
 	}
 
 	// Unauthorized: Token could have expired
 	// Forbidden: After creating a realm, following GETs for the realm return 403 until you refresh
-	if response.StatusCode == http.StatusUnauthorized || response.StatusCode == http.StatusForbidden {
+	if (response.StatusCode == http.StatusUnauthorized || response.StatusCode == http.StatusForbidden) && !keycloakClient.staticAccessToken {
 		tflog.Debug(ctx, "Got unexpected response, attempting refresh", map[string]interface{}{
 			"status": response.Status,
 		})
 
-		err := keycloakClient.refresh(ctx)
+		authorization, err = keycloakClient.refreshRejectedAuthorization(ctx, authorization)
 		if err != nil {
 			return nil, "", fmt.Errorf("error refreshing credentials: %s", err)
 		}
 
-		keycloakClient.addRequestHeaders(request)
+		keycloakClient.addRequestHeaders(request, authorization)
 
 		if body != nil {
 			request.Body = ioutil.NopCloser(bytes.NewReader(body))
 		}
 		response, err = keycloakClient.httpClient.Do(request)
 		if err != nil {
-			return nil, "", fmt.Errorf("error sending request after refresh: %v", err)
+			return nil, "", fmt.Errorf("error sending request after refresh: %w", err)
 		}
 	}
 
@@ -363,27 +610,22 @@ func (keycloakClient *KeycloakClient) sendRequest(ctx context.Context, request *
 		return nil, "", err
 	}
 
+	if cassetteRecorder := keycloakClient.cassettes.Load(); cassetteRecorder != nil {
+		cassetteRecorder.record(request, body, response, responseBody)
+	}
+
 	responseLogArgs := map[string]interface{}{
 		"status": response.Status,
 	}
 
 	if len(responseBody) != 0 && request.URL.Path != "/auth/admin/serverinfo" {
-		responseLogArgs["body"] = string(responseBody)
+		responseLogArgs["body"] = keycloakClient.redactor.body(responseBody)
 	}
 
 	tflog.Debug(ctx, "Received response", responseLogArgs)
 
 	if response.StatusCode >= 400 {
-		errorMessage := fmt.Sprintf("error sending %s request to %s: %s.", request.Method, request.URL.Path, response.Status)
-
-		if len(responseBody) != 0 {
-			errorMessage = fmt.Sprintf("%s Response body: %s", errorMessage, responseBody)
-		}
-
-		return nil, "", &ApiError{
-			Code:    response.StatusCode,
-			Message: errorMessage,
-		}
+		return nil, "", newApiError(request, response, responseBody)
 	}
 
 	return responseBody, response.Header.Get("Location"), nil
@@ -499,7 +741,33 @@ func (keycloakClient *KeycloakClient) marshal(body interface{}) ([]byte, error)
 	return json.Marshal(body)
 }
 
-func newHttpClient(tlsInsecureSkipVerify bool, clientTimeout int, caCert string) (*http.Client, error) {
+// lockRealm serializes the read-modify-write updates of a document that the whole realm shares, such as its user
+// profile, so that resources managing different parts of it don't overwrite each other. The returned function releases
+// the lock.
+func (keycloakClient *KeycloakClient) lockRealm(realmId string) func() {
+	value, _ := keycloakClient.realmLocks.LoadOrStore(realmId, &sync.Mutex{})
+	mutex := value.(*sync.Mutex)
+	mutex.Lock()
+
+	return mutex.Unlock
+}
+
+// these are used when the provider does not configure retries
+const (
+	DefaultMaxRetries = 1
+	DefaultMinBackoff = time.Second * 1
+	DefaultMaxBackoff = time.Second * 3
+)
+
+var DefaultRetryableStatusCodes = []int{
+	http.StatusTooManyRequests,
+	http.StatusInternalServerError,
+	http.StatusBadGateway,
+	http.StatusServiceUnavailable,
+	http.StatusGatewayTimeout,
+}
+
+func newHttpClient(tlsInsecureSkipVerify bool, clientTimeout int, caCert, tlsClientCert, tlsClientPrivateKey string, maxRetries int, minBackoff, maxBackoff time.Duration, retryableStatusCodes []int, requestsPerSecond float64, cassetteRecorder func() *CassetteRecorder) (*http.Client, error) {
 	cookieJar, err := cookiejar.New(&cookiejar.Options{
 		PublicSuffixList: publicsuffix.List,
 	})
@@ -518,15 +786,93 @@ func newHttpClient(tlsInsecureSkipVerify bool, clientTimeout int, caCert string)
 		transport.TLSClientConfig.RootCAs = caCertPool
 	}
 
+	if tlsClientCert != "" || tlsClientPrivateKey != "" {
+		clientCertificate, err := tls.X509KeyPair([]byte(tlsClientCert), []byte(tlsClientPrivateKey))
+		if err != nil {
+			return nil, fmt.Errorf("failed to load tls client certificate: %v", err)
+		}
+		transport.TLSClientConfig.Certificates = []tls.Certificate{clientCertificate}
+	}
+
 	retryClient := retryablehttp.NewClient()
-	retryClient.RetryMax = 1
-	retryClient.RetryWaitMin = time.Second * 1
-	retryClient.RetryWaitMax = time.Second * 3
+	retryClient.RetryMax = maxRetries
+	retryClient.RetryWaitMin = minBackoff
+	retryClient.RetryWaitMax = maxBackoff
+	retryClient.CheckRetry = newRetryPolicy(retryableStatusCodes)
+	// the last response is returned once retries are exhausted, so the status and body still end up in the ApiError
+	retryClient.ErrorHandler = retryablehttp.PassthroughErrorHandler
+	retryClient.Logger = nil
+	retryClient.RequestLogHook = func(_ retryablehttp.Logger, request *http.Request, attempt int) {
+		if attempt > 0 {
+			tflog.Debug(request.Context(), "Retrying request", map[string]interface{}{
+				"method":  request.Method,
+				"path":    request.URL.Path,
+				"attempt": attempt,
+			})
+		}
+	}
+
+	var roundTripper http.RoundTripper = transport
+	if cassetteRecorder != nil {
+		roundTripper = &cassetteTransport{
+			recorder:  cassetteRecorder,
+			transport: roundTripper,
+		}
+	}
+
+	// each attempt is throttled, so retries count against the rate limit as well
+	retryClient.HTTPClient.Transport = roundTripper
+	if requestsPerSecond > 0 {
+		retryClient.HTTPClient.Transport = &rateLimitedTransport{
+			limiter:   rate.NewLimiter(rate.Limit(requestsPerSecond), 1),
+			transport: roundTripper,
+		}
+	}
+	retryClient.HTTPClient.Timeout = time.Second * time.Duration(clientTimeout)
 
 	httpClient := retryClient.StandardClient()
-	httpClient.Timeout = time.Second * time.Duration(clientTimeout)
-	httpClient.Transport = transport
 	httpClient.Jar = cookieJar
 
 	return httpClient, nil
 }
+
+// newRetryPolicy retries connection errors the same way retryablehttp does by default, but only retries the given
+// status codes, rather than every 5xx response
+func newRetryPolicy(retryableStatusCodes []int) retryablehttp.CheckRetry {
+	return func(ctx context.Context, response *http.Response, err error) (bool, error) {
+		if ctx.Err() != nil {
+			return false, ctx.Err()
+		}
+
+		if errors.Is(err, errUnmatchedCassetteRequest) {
+			return false, err
+		}
+
+		if err != nil {
+			return retryablehttp.DefaultRetryPolicy(ctx, response, err)
+		}
+
+		for _, statusCode := range retryableStatusCodes {
+			if response.StatusCode == statusCode {
+				return true, nil
+			}
+		}
+
+		return false, nil
+	}
+}
+
+// rateLimitedTransport limits the rate of requests sent to keycloak. The same client is used by every resource, so the
+// limit is shared by all of the operations terraform runs concurrently.
+type rateLimitedTransport struct {
+	limiter   *rate.Limiter
+	transport http.RoundTripper
+}
+
+func (rateLimitedTransport *rateLimitedTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	if err := rateLimitedTransport.limiter.Wait(request.Context()); err != nil {
+		return nil, err
+	}
+
+	return rateLimitedTransport.transport.RoundTrip(request)
+}
@@ -48,8 +48,18 @@ func TestAccKeycloakApiClientRefresh(t *testing.T) {
 		t.Fatal("KEYCLOAK_CLIENT_TIMEOUT must be an integer")
 	}
 
-	keycloakClient, err := NewKeycloakClient(ctx, os.Getenv("KEYCLOAK_URL"), "", os.Getenv("KEYCLOAK_CLIENT_ID"), os.Getenv("KEYCLOAK_CLIENT_SECRET"), os.Getenv("KEYCLOAK_REALM"), os.Getenv("KEYCLOAK_USER"), os.Getenv("KEYCLOAK_PASSWORD"), true, clientTimeout, "", false, "", false, map[string]string{
-		"foo": "bar",
+	keycloakClient, err := NewKeycloakClient(ctx, KeycloakClientConfig{
+		Url:           os.Getenv("KEYCLOAK_URL"),
+		ClientId:      os.Getenv("KEYCLOAK_CLIENT_ID"),
+		ClientSecret:  os.Getenv("KEYCLOAK_CLIENT_SECRET"),
+		Realm:         os.Getenv("KEYCLOAK_REALM"),
+		Username:      os.Getenv("KEYCLOAK_USER"),
+		Password:      os.Getenv("KEYCLOAK_PASSWORD"),
+		InitialLogin:  true,
+		ClientTimeout: clientTimeout,
+		AdditionalHeaders: map[string]string{
+			"foo": "bar",
+		},
 	})
 	if err != nil {
 		t.Fatalf("%s", err)
@@ -0,0 +1,1279 @@
+// Package keycloaktest provides an in-memory fake of the Keycloak admin REST API, so the keycloak package and the
+// provider's CRUD functions can be tested without starting Keycloak.
+package keycloaktest
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultVersion is the server version reported by a new Server
+const DefaultVersion = "26.0.0"
+
+// AdminUserId is the id of the user in the master realm that every access token is issued to. Like the service account
+// of a client, it has no email address until a test gives it one.
+const AdminUserId = "9b1d1a6c-3f5e-4c1e-8a4f-2d7c0e6b5a10"
+
+type object = map[string]interface{}
+
+// Server fakes the token endpoint, serverinfo, realms, clients, roles, groups, users, the user profile, components,
+// partial imports of users, client initial access tokens, authentication flows, login and admin events and the SMTP connection test. State is kept in memory and is only as strict as the
+// provider needs: unknown attributes are stored and returned as is. Requests to any other endpoint fail with 501 Not
+// Implemented, so tests notice when they depend on something the fake doesn't cover.
+type Server struct {
+	*httptest.Server
+
+	// Version is the server version reported by /admin/serverinfo
+	Version string
+
+	mutex      sync.Mutex
+	serverInfo object
+	// features maps the profile features reported by serverinfo to whether they are enabled
+	features map[string]bool
+	// smtpError is the message testSMTPConnection fails with, it succeeds when empty
+	smtpError string
+	// sentEmails holds the recipients of the test emails sent by testSMTPConnection
+	sentEmails []string
+	tokens     map[string]bool
+	realms     []*realm
+	requests   []string
+}
+
+type realm struct {
+	representation object
+	clients        *collection
+	roles          *collection
+	groups         *collection
+	users          *collection
+	components     *collection
+	flows          *collection
+	// composites maps the id of a composite role to the ids of its child roles
+	composites map[string][]string
+	// memberships maps the id of a user to the ids of their groups
+	memberships map[string][]string
+	// events and adminEvents are stored newest first, as keycloak returns them
+	events              []object
+	adminEvents         []object
+	userProfile         object
+	initialAccessTokens *collection
+}
+
+// NewServer starts a fake with an empty master realm
+func NewServer() *Server {
+	server := &Server{
+		Version: DefaultVersion,
+		tokens:  map[string]bool{},
+		// the default features of Keycloak 26, without the ones that aren't used by the provider
+		features: map[string]bool{
+			"ADMIN_FINE_GRAINED_AUTHZ": false,
+			"CIBA":                     true,
+			"CLIENT_POLICIES":          true,
+			"DEVICE_FLOW":              true,
+			"DYNAMIC_SCOPES":           false,
+			"ORGANIZATION":             true,
+			"PAR":                      true,
+			"RECOVERY_CODES":           false,
+			"TOKEN_EXCHANGE":           false,
+			"UPDATE_EMAIL":             false,
+		},
+		serverInfo: object{
+			"themes": object{
+				"login":   []interface{}{object{"name": "base"}, object{"name": "keycloak"}},
+				"account": []interface{}{object{"name": "base"}, object{"name": "keycloak.v3"}},
+				"admin":   []interface{}{object{"name": "base"}, object{"name": "keycloak.v2"}},
+				"email":   []interface{}{object{"name": "base"}, object{"name": "keycloak"}},
+			},
+			"componentTypes": object{},
+			"protocolMapperTypes": object{
+				"openid-connect": []interface{}{
+					object{
+						"id":       "oidc-hardcoded-claim-mapper",
+						"name":     "Hardcoded claim",
+						"category": "Token mapper",
+						"properties": []interface{}{
+							object{"name": "claim.name", "label": "Token Claim Name", "type": "String"},
+							object{"name": "access.token.claim", "label": "Add to access token", "type": "boolean", "defaultValue": true},
+						},
+					},
+				},
+			},
+			"passwordPolicies": []interface{}{
+				object{"id": "length", "displayName": "Minimum length", "configType": "int", "defaultValue": "8"},
+				object{"id": "passwordHistory", "displayName": "Not recently used", "configType": "int", "defaultValue": "3"},
+			},
+			"providers": object{
+				"password-policy": providers("length", "maxLength", "digits", "lowerCase", "upperCase", "specialChars",
+					"notUsername", "notEmail", "notContainsUsername", "passwordHistory", "passwordAge",
+					"forceExpiredPasswordChange", "hashAlgorithm", "hashIterations", "regexPattern", "passwordBlacklist",
+					"maxAuthAge"),
+			},
+		},
+	}
+	master := newRealm(object{"id": "master", "realm": "master", "enabled": true})
+	master.users.add(object{"id": AdminUserId, "username": "service-account-terraform", "enabled": true})
+	server.realms = append(server.realms, master)
+
+	server.Server = httptest.NewServer(server)
+
+	return server
+}
+
+func providers(names ...string) object {
+	result := object{}
+	for _, name := range names {
+		result[name] = object{}
+	}
+
+	return object{"internal": true, "providers": result}
+}
+
+func newRealm(representation object) *realm {
+	return &realm{
+		representation: representation,
+		clients:        &collection{},
+		roles:          &collection{},
+		groups:         &collection{},
+		users:          &collection{},
+		components:     &collection{},
+		flows:          &collection{},
+		composites:     map[string][]string{},
+		memberships:    map[string][]string{},
+		userProfile:    defaultUserProfile(),
+
+		initialAccessTokens: &collection{},
+	}
+}
+
+// defaultUserProfile is the user profile of a new realm, without the validations and permissions keycloak adds
+func defaultUserProfile() object {
+	attributes := []interface{}{}
+	for _, name := range []string{"username", "email", "firstName", "lastName"} {
+		attributes = append(attributes, object{"name": name, "displayName": "${" + name + "}"})
+	}
+
+	return object{
+		"attributes": attributes,
+		"groups": []interface{}{
+			object{"name": "user-metadata", "displayHeader": "User metadata"},
+		},
+	}
+}
+
+// InstallProvider adds a provider to the serverinfo response, e.g. to satisfy validation of a password policy
+func (server *Server) InstallProvider(providerType, providerId string) {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+
+	installed := server.serverInfo["providers"].(object)
+	if _, ok := installed[providerType]; !ok {
+		installed[providerType] = providers()
+	}
+	installed[providerType].(object)["providers"].(object)[providerId] = object{}
+}
+
+// SetFeature enables or disables a profile feature, as with the --features and --features-disabled options of the server
+func (server *Server) SetFeature(name string, enabled bool) {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+
+	server.features[name] = enabled
+}
+
+// SetSMTPError makes testSMTPConnection fail with the given message, as when the SMTP server can't be reached. An empty
+// message makes it succeed again.
+func (server *Server) SetSMTPError(message string) {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+
+	server.smtpError = message
+}
+
+// SentEmails returns the recipients of the test emails sent by testSMTPConnection, in order
+func (server *Server) SentEmails() []string {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+
+	return append([]string(nil), server.sentEmails...)
+}
+
+// AddEvent stores a login event in a realm, e.g. object{"type": "LOGIN", "clientId": "account", "userId": "..."}
+func (server *Server) AddEvent(realmName string, event map[string]interface{}) {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+
+	realm := server.findRealm(realmName)
+	realm.events = append([]object{event}, realm.events...)
+}
+
+// AddAdminEvent stores an admin event in a realm, e.g. object{"operationType": "CREATE", "resourcePath": "users/..."}
+func (server *Server) AddAdminEvent(realmName string, event map[string]interface{}) {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+
+	realm := server.findRealm(realmName)
+	realm.adminEvents = append([]object{event}, realm.adminEvents...)
+}
+
+// RevokeTokens invalidates every access token that has been issued, as if they had expired
+func (server *Server) RevokeTokens() {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+
+	server.tokens = map[string]bool{}
+}
+
+// Requests returns the method and path of every request the server received, in order
+func (server *Server) Requests() []string {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+
+	return append([]string(nil), server.requests...)
+}
+
+func (server *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+
+	server.requests = append(server.requests, r.Method+" "+r.URL.Path)
+
+	if strings.HasPrefix(r.URL.Path, "/realms/") && strings.HasSuffix(r.URL.Path, "/protocol/openid-connect/token") {
+		server.token(w, r)
+		return
+	}
+
+	if !strings.HasPrefix(r.URL.Path, "/admin/") {
+		writeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+
+	if !server.tokens[strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")] {
+		writeError(w, http.StatusUnauthorized, "HTTP 401 Unauthorized")
+		return
+	}
+
+	var body object
+	if r.Body != nil {
+		content, _ := io.ReadAll(r.Body)
+		if len(content) != 0 {
+			// array bodies, such as composite roles, are wrapped so every handler receives an object
+			if err := json.Unmarshal(content, &body); err != nil {
+				var list []interface{}
+				if err := json.Unmarshal(content, &list); err != nil {
+					writeError(w, http.StatusBadRequest, "unable to parse request body")
+					return
+				}
+				body = object{"items": list}
+			}
+		}
+	}
+
+	request := &request{
+		Request: r,
+		server:  server,
+		w:       w,
+		body:    body,
+	}
+
+	path := strings.Trim(strings.TrimPrefix(r.URL.Path, "/admin"), "/")
+	if path == "serverinfo" && r.Method == http.MethodGet {
+		serverInfo := object{"systemInfo": object{"version": server.Version}}
+
+		var features, disabledFeatures []interface{}
+		for name, enabled := range server.features {
+			features = append(features, object{"name": name, "enabled": enabled})
+			if !enabled {
+				disabledFeatures = append(disabledFeatures, name)
+			}
+		}
+		serverInfo["features"] = features
+		serverInfo["profileInfo"] = object{"name": "community", "disabledFeatures": disabledFeatures}
+
+		for key, value := range server.serverInfo {
+			serverInfo[key] = value
+		}
+		request.write(http.StatusOK, serverInfo)
+		return
+	}
+
+	segments := strings.Split(path, "/")
+	if segments[0] != "realms" {
+		request.notImplemented()
+		return
+	}
+
+	if len(segments) == 1 {
+		request.realms()
+		return
+	}
+
+	request.realm(segments[1], segments[2:])
+}
+
+func (server *Server) token(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil || r.PostForm.Get("client_id") == "" && r.PostForm.Get("grant_type") != "password" {
+		writeJson(w, http.StatusUnauthorized, object{"error": "invalid_client"})
+		return
+	}
+
+	token := newId()
+	server.tokens[token] = true
+
+	writeJson(w, http.StatusOK, object{
+		"access_token":  token,
+		"refresh_token": newId(),
+		"token_type":    "Bearer",
+		"expires_in":    300,
+	})
+}
+
+func (server *Server) findRealm(name string) *realm {
+	for _, realm := range server.realms {
+		if realm.representation["realm"] == name {
+			return realm
+		}
+	}
+
+	return nil
+}
+
+// request holds everything the handlers need, since they are all called while holding the server's mutex
+type request struct {
+	*http.Request
+
+	server *Server
+	w      http.ResponseWriter
+	body   object
+}
+
+func (r *request) write(status int, body interface{}) {
+	writeJson(r.w, status, body)
+}
+
+func (r *request) created(id string) {
+	r.w.Header().Set("Location", fmt.Sprintf("%s%s/%s", r.server.URL, strings.TrimSuffix(r.URL.Path, "/"), id))
+	r.w.WriteHeader(http.StatusCreated)
+}
+
+func (r *request) noContent() {
+	r.w.WriteHeader(http.StatusNoContent)
+}
+
+func (r *request) notFound(what string) {
+	writeError(r.w, http.StatusNotFound, fmt.Sprintf("Could not find %s", what))
+}
+
+func (r *request) conflict(message string) {
+	writeJson(r.w, http.StatusConflict, object{"errorMessage": message})
+}
+
+func (r *request) notImplemented() {
+	writeError(r.w, http.StatusNotImplemented, fmt.Sprintf("%s %s is not implemented by keycloaktest", r.Method, r.URL.Path))
+}
+
+func writeJson(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJson(w, status, object{"error": message})
+}
+
+func (r *request) realms() {
+	switch r.Method {
+	case http.MethodGet:
+		var realms []interface{}
+		for _, realm := range r.server.realms {
+			realms = append(realms, realm.representation)
+		}
+		r.write(http.StatusOK, realms)
+	case http.MethodPost:
+		name, _ := r.body["realm"].(string)
+		if name == "" {
+			writeError(r.w, http.StatusBadRequest, "realm name is required")
+			return
+		}
+		if r.server.findRealm(name) != nil {
+			r.conflict("Conflict detected. See logs for details")
+			return
+		}
+		if id, _ := r.body["id"].(string); id == "" {
+			r.body["id"] = name
+		}
+
+		realm := newRealm(r.body)
+		defaultRole := realm.roles.add(object{"name": "default-roles-" + name, "composite": true, "clientRole": false, "containerId": r.body["id"]})
+		realm.representation["defaultRole"] = defaultRole
+		r.server.realms = append(r.server.realms, realm)
+
+		r.created(name)
+	default:
+		r.notImplemented()
+	}
+}
+
+func (r *request) realm(name string, segments []string) {
+	realm := r.server.findRealm(name)
+	if realm == nil {
+		r.notFound("realm")
+		return
+	}
+
+	if len(segments) == 0 {
+		switch r.Method {
+		case http.MethodGet:
+			r.write(http.StatusOK, realm.representation)
+		case http.MethodPut:
+			// keycloak sets the attributes it is sent one by one, so the ones that aren't sent are kept
+			if attributes, ok := r.body["attributes"].(map[string]interface{}); ok {
+				existing, _ := realm.representation["attributes"].(map[string]interface{})
+				if existing == nil {
+					existing = object{}
+				}
+				merge(existing, attributes)
+				r.body["attributes"] = existing
+			}
+			merge(realm.representation, r.body, "id", "realm")
+			r.noContent()
+		case http.MethodDelete:
+			for i, existing := range r.server.realms {
+				if existing == realm {
+					r.server.realms = append(r.server.realms[:i], r.server.realms[i+1:]...)
+					break
+				}
+			}
+			r.noContent()
+		default:
+			r.notImplemented()
+		}
+		return
+	}
+
+	switch segments[0] {
+	case "clients":
+		r.clients(realm, segments[1:])
+	case "roles":
+		r.roles(realm, realm.representation["id"].(string), false, segments[1:])
+	case "roles-by-id":
+		r.rolesById(realm, segments[1:])
+	case "groups":
+		r.groups(realm, segments[1:])
+	case "users":
+		r.users(realm, segments[1:])
+	case "components":
+		dropEmptyConfig(r.body)
+		r.collection(realm.components, "component", segments[1:], func(o object) bool {
+			return matches(o, "parentId", r.URL.Query().Get("parent")) &&
+				matches(o, "providerType", r.URL.Query().Get("type")) &&
+				matches(o, "name", r.URL.Query().Get("name"))
+		})
+	case "events":
+		if len(segments) > 1 {
+			r.notImplemented()
+			return
+		}
+		query := r.URL.Query()
+		r.events(realm.events, func(o object) bool {
+			return matchesAny(o, "type", query["type"]) &&
+				matches(o, "clientId", query.Get("client")) &&
+				matches(o, "userId", query.Get("user")) &&
+				matches(o, "ipAddress", query.Get("ipAddress"))
+		})
+	case "admin-events":
+		query := r.URL.Query()
+		r.events(realm.adminEvents, func(o object) bool {
+			authDetails, _ := o["authDetails"].(map[string]interface{})
+			return matchesAny(o, "operationType", query["operationTypes"]) &&
+				matchesAny(o, "resourceType", query["resourceTypes"]) &&
+				matches(o, "resourcePath", query.Get("resourcePath")) &&
+				matches(authDetails, "clientId", query.Get("authClient")) &&
+				matches(authDetails, "userId", query.Get("authUser"))
+		})
+	case "partialImport":
+		if r.Method != http.MethodPost {
+			r.notImplemented()
+			return
+		}
+		r.partialImport(realm)
+	case "clients-initial-access":
+		r.clientsInitialAccess(realm, segments[1:])
+	case "testSMTPConnection":
+		if r.Method != http.MethodPost {
+			r.notImplemented()
+			return
+		}
+		if host, _ := r.body["host"].(string); host == "" {
+			r.write(http.StatusInternalServerError, object{"errorMessage": "Failed to send email"})
+			return
+		}
+		if r.server.smtpError != "" {
+			r.write(http.StatusInternalServerError, object{"errorMessage": r.server.smtpError})
+			return
+		}
+		// keycloak sends the test email to the user that is logged in
+		email, _ := r.server.findRealm("master").users.get(AdminUserId)["email"].(string)
+		if email == "" {
+			r.write(http.StatusInternalServerError, object{"errorMessage": "Logged in user does not have an e-mail."})
+			return
+		}
+		r.server.sentEmails = append(r.server.sentEmails, email)
+		r.noContent()
+	case "authentication":
+		if len(segments) > 1 && segments[1] == "flows" {
+			r.collection(realm.flows, "flow", segments[2:], func(o object) bool { return true })
+			return
+		}
+		r.notImplemented()
+	default:
+		r.notImplemented()
+	}
+}
+
+// collection implements the list, create, read, update and delete endpoints that most resources share
+func (r *request) collection(c *collection, what string, segments []string, filter func(object) bool) {
+	if len(segments) == 0 {
+		switch r.Method {
+		case http.MethodGet:
+			r.write(http.StatusOK, c.find(filter))
+		case http.MethodPost:
+			r.created(c.add(r.body)["id"].(string))
+		default:
+			r.notImplemented()
+		}
+		return
+	}
+
+	if len(segments) > 1 {
+		r.notImplemented()
+		return
+	}
+
+	o := c.get(segments[0])
+	if o == nil {
+		r.notFound(what)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		r.write(http.StatusOK, o)
+	case http.MethodPut:
+		merge(o, r.body, "id")
+		r.noContent()
+	case http.MethodDelete:
+		c.remove(o["id"].(string))
+		r.noContent()
+	default:
+		r.notImplemented()
+	}
+}
+
+func (r *request) clients(realm *realm, segments []string) {
+	if len(segments) == 0 && r.Method == http.MethodPost {
+		if len(realm.clients.find(func(o object) bool { return o["clientId"] == r.body["clientId"] })) != 0 {
+			r.conflict(fmt.Sprintf("Client %s already exists", r.body["clientId"]))
+			return
+		}
+		if _, ok := r.body["secret"]; !ok && r.body["publicClient"] != true {
+			r.body["secret"] = newId()
+		}
+	}
+
+	if len(segments) < 2 {
+		r.collection(realm.clients, "client", segments, func(o object) bool {
+			return matches(o, "clientId", r.URL.Query().Get("clientId"))
+		})
+
+		switch {
+		case r.Method == http.MethodPost || r.Method == http.MethodPut:
+			realm.ensureServiceAccountUsers()
+		case r.Method == http.MethodDelete && len(segments) == 1:
+			realm.deleteClientDependents(segments[0])
+		}
+		return
+	}
+
+	client := realm.clients.get(segments[0])
+	if client == nil {
+		r.notFound("client")
+		return
+	}
+
+	switch segments[1] {
+	case "client-secret":
+		r.write(http.StatusOK, object{"type": "secret", "value": client["secret"]})
+	case "service-account-user":
+		users := realm.users.find(func(o object) bool { return o["serviceAccountClientId"] == client["id"] })
+		if len(users) == 0 {
+			writeError(r.w, http.StatusBadRequest, "Service account not enabled for the client")
+			return
+		}
+		r.write(http.StatusOK, users[0])
+	case "roles":
+		r.roles(realm, client["id"].(string), true, segments[2:])
+	default:
+		r.notImplemented()
+	}
+}
+
+// ensureServiceAccountUsers creates the users keycloak creates for every client with service accounts enabled
+func (realm *realm) ensureServiceAccountUsers() {
+	for _, client := range realm.clients.objects {
+		if client["serviceAccountsEnabled"] != true {
+			continue
+		}
+
+		if len(realm.users.find(func(o object) bool { return o["serviceAccountClientId"] == client["id"] })) == 0 {
+			realm.users.add(object{
+				"username":               "service-account-" + strings.ToLower(client["clientId"].(string)),
+				"enabled":                true,
+				"serviceAccountClientId": client["id"],
+			})
+		}
+	}
+}
+
+// deleteClientDependents removes the roles and service account user that are deleted along with a client
+func (realm *realm) deleteClientDependents(clientId string) {
+	for _, role := range realm.roles.find(func(o object) bool { return o["containerId"] == clientId }) {
+		realm.roles.remove(role["id"].(string))
+		delete(realm.composites, role["id"].(string))
+	}
+
+	for _, user := range realm.users.find(func(o object) bool { return o["serviceAccountClientId"] == clientId }) {
+		realm.users.remove(user["id"].(string))
+		delete(realm.memberships, user["id"].(string))
+	}
+}
+
+// roles implements the by-name role endpoints of a realm or client
+func (r *request) roles(realm *realm, containerId string, clientRole bool, segments []string) {
+	inContainer := func(o object) bool {
+		return o["containerId"] == containerId
+	}
+
+	if len(segments) == 0 {
+		switch r.Method {
+		case http.MethodGet:
+			search := strings.ToLower(r.URL.Query().Get("search"))
+			r.write(http.StatusOK, realm.roles.find(func(o object) bool {
+				return inContainer(o) && strings.Contains(strings.ToLower(o["name"].(string)), search)
+			}))
+		case http.MethodPost:
+			if realm.findRole(containerId, r.body["name"]) != nil {
+				r.conflict(fmt.Sprintf("Role with name %s already exists", r.body["name"]))
+				return
+			}
+			r.body["containerId"] = containerId
+			r.body["clientRole"] = clientRole
+			r.body["composite"] = false
+			role := realm.roles.add(r.body)
+			r.created(role["name"].(string))
+		default:
+			r.notImplemented()
+		}
+		return
+	}
+
+	role := realm.findRole(containerId, segments[0])
+	if role == nil {
+		r.notFound("role")
+		return
+	}
+
+	if len(segments) > 1 {
+		r.notImplemented()
+		return
+	}
+
+	r.role(realm, role)
+}
+
+func (r *request) rolesById(realm *realm, segments []string) {
+	if len(segments) == 0 {
+		r.notImplemented()
+		return
+	}
+
+	role := realm.roles.get(segments[0])
+	if role == nil {
+		r.notFound("role")
+		return
+	}
+
+	if len(segments) == 1 {
+		r.role(realm, role)
+		return
+	}
+
+	if segments[1] != "composites" || len(segments) > 2 {
+		r.notImplemented()
+		return
+	}
+
+	id := role["id"].(string)
+	switch r.Method {
+	case http.MethodGet:
+		var composites []object
+		for _, compositeId := range realm.composites[id] {
+			if composite := realm.roles.get(compositeId); composite != nil {
+				composites = append(composites, composite)
+			}
+		}
+		r.write(http.StatusOK, composites)
+	case http.MethodPost:
+		for _, item := range itemIds(r.body) {
+			if !contains(realm.composites[id], item) {
+				realm.composites[id] = append(realm.composites[id], item)
+			}
+		}
+		role["composite"] = len(realm.composites[id]) != 0
+		r.noContent()
+	case http.MethodDelete:
+		var remaining []string
+		for _, compositeId := range realm.composites[id] {
+			if !contains(itemIds(r.body), compositeId) {
+				remaining = append(remaining, compositeId)
+			}
+		}
+		realm.composites[id] = remaining
+		role["composite"] = len(remaining) != 0
+		r.noContent()
+	default:
+		r.notImplemented()
+	}
+}
+
+func (r *request) role(realm *realm, role object) {
+	switch r.Method {
+	case http.MethodGet:
+		r.write(http.StatusOK, role)
+	case http.MethodPut:
+		merge(role, r.body, "id", "containerId", "clientRole", "composite")
+		r.noContent()
+	case http.MethodDelete:
+		realm.roles.remove(role["id"].(string))
+		delete(realm.composites, role["id"].(string))
+		r.noContent()
+	default:
+		r.notImplemented()
+	}
+}
+
+func (realm *realm) findRole(containerId string, name interface{}) object {
+	roles := realm.roles.find(func(o object) bool {
+		return o["containerId"] == containerId && o["name"] == name
+	})
+	if len(roles) == 0 {
+		return nil
+	}
+
+	return roles[0]
+}
+
+func (r *request) groups(realm *realm, segments []string) {
+	if len(segments) == 0 {
+		switch r.Method {
+		case http.MethodGet:
+			r.write(http.StatusOK, realm.groupTree("", strings.ToLower(r.URL.Query().Get("search"))))
+		case http.MethodPost:
+			r.createGroup(realm, "")
+		default:
+			r.notImplemented()
+		}
+		return
+	}
+
+	group := realm.groups.get(segments[0])
+	if group == nil {
+		r.notFound("group")
+		return
+	}
+
+	id := group["id"].(string)
+
+	if len(segments) == 1 {
+		switch r.Method {
+		case http.MethodGet:
+			r.write(http.StatusOK, realm.groupRepresentation(group, ""))
+		case http.MethodPut:
+			merge(group, r.body, "id", "parentId", "subGroups", "path")
+			r.noContent()
+		case http.MethodDelete:
+			realm.deleteGroup(id)
+			r.noContent()
+		default:
+			r.notImplemented()
+		}
+		return
+	}
+
+	switch {
+	case segments[1] == "children" && r.Method == http.MethodPost:
+		r.createGroup(realm, id)
+	case segments[1] == "children" && r.Method == http.MethodGet:
+		var children []object
+		for _, child := range realm.groups.find(func(o object) bool { return o["parentId"] == id }) {
+			children = append(children, realm.groupRepresentation(child, ""))
+		}
+		r.write(http.StatusOK, children)
+	case segments[1] == "members" && r.Method == http.MethodGet:
+		r.write(http.StatusOK, page(realm.users.find(func(o object) bool {
+			return contains(realm.memberships[o["id"].(string)], id)
+		}), r.URL.Query()))
+	default:
+		r.notImplemented()
+	}
+}
+
+func (r *request) createGroup(realm *realm, parentId string) {
+	name, _ := r.body["name"].(string)
+	siblings := realm.groups.find(func(o object) bool {
+		return o["name"] == name && (o["parentId"] == parentId || parentId == "" && o["parentId"] == nil)
+	})
+	if len(siblings) != 0 {
+		r.conflict(fmt.Sprintf("Top level group named '%s' already exists.", name))
+		return
+	}
+
+	delete(r.body, "subGroups")
+	if parentId != "" {
+		r.body["parentId"] = parentId
+	}
+
+	r.created(realm.groups.add(r.body)["id"].(string))
+}
+
+func (realm *realm) deleteGroup(id string) {
+	for _, child := range realm.groups.find(func(o object) bool { return o["parentId"] == id }) {
+		realm.deleteGroup(child["id"].(string))
+	}
+
+	realm.groups.remove(id)
+	for userId, groupIds := range realm.memberships {
+		realm.memberships[userId] = remove(groupIds, id)
+	}
+}
+
+func (realm *realm) groupPath(group object) string {
+	if parent := realm.groups.get(fmt.Sprint(group["parentId"])); parent != nil {
+		return realm.groupPath(parent) + "/" + group["name"].(string)
+	}
+
+	return "/" + group["name"].(string)
+}
+
+// groupRepresentation returns a group along with its subgroups, pruned to the subgroups matching search
+func (realm *realm) groupRepresentation(group object, search string) object {
+	representation := object{}
+	for key, value := range group {
+		representation[key] = value
+	}
+
+	representation["path"] = realm.groupPath(group)
+	subGroups := realm.groupTree(group["id"].(string), search)
+	representation["subGroups"] = subGroups
+	representation["subGroupCount"] = len(subGroups)
+
+	return representation
+}
+
+// groupTree returns the children of the given parent, and when searching, only the groups that match the search or have
+// a descendant that does
+func (realm *realm) groupTree(parentId, search string) []object {
+	groups := []object{}
+	for _, group := range realm.groups.find(func(o object) bool {
+		if parentId == "" {
+			return o["parentId"] == nil
+		}
+		return o["parentId"] == parentId
+	}) {
+		matched := strings.Contains(strings.ToLower(group["name"].(string)), search)
+
+		representation := realm.groupRepresentation(group, search)
+		if matched {
+			representation = realm.groupRepresentation(group, "")
+		}
+
+		if matched || len(representation["subGroups"].([]object)) != 0 {
+			groups = append(groups, representation)
+		}
+	}
+
+	return groups
+}
+
+func (r *request) users(realm *realm, segments []string) {
+	if len(segments) == 1 && segments[0] == "profile" {
+		r.userProfile(realm)
+		return
+	}
+
+	if len(segments) == 0 && r.Method == http.MethodPost {
+		username := strings.ToLower(fmt.Sprint(r.body["username"]))
+		if len(realm.users.find(func(o object) bool { return o["username"] == username })) != 0 {
+			r.conflict("User exists with same username")
+			return
+		}
+		r.body["username"] = username
+		delete(r.body, "credentials")
+	}
+
+	if len(segments) < 2 {
+		if len(segments) == 0 && r.Method == http.MethodGet {
+			r.write(http.StatusOK, page(realm.users.find(r.userFilter()), r.URL.Query()))
+			return
+		}
+
+		r.collection(realm.users, "user", segments, nil)
+		if r.Method == http.MethodDelete && len(segments) == 1 {
+			delete(realm.memberships, segments[0])
+		}
+		return
+	}
+
+	user := realm.users.get(segments[0])
+	if user == nil {
+		r.notFound("user")
+		return
+	}
+
+	id := user["id"].(string)
+
+	switch {
+	case segments[1] == "reset-password" && r.Method == http.MethodPut:
+		r.noContent()
+	case segments[1] == "groups" && len(segments) == 2 && r.Method == http.MethodGet:
+		var groups []object
+		for _, groupId := range realm.memberships[id] {
+			if group := realm.groups.get(groupId); group != nil {
+				groups = append(groups, object{"id": groupId, "name": group["name"], "path": realm.groupPath(group)})
+			}
+		}
+		r.write(http.StatusOK, groups)
+	case segments[1] == "groups" && len(segments) == 3 && r.Method == http.MethodPut:
+		if realm.groups.get(segments[2]) == nil {
+			r.notFound("group")
+			return
+		}
+		if !contains(realm.memberships[id], segments[2]) {
+			realm.memberships[id] = append(realm.memberships[id], segments[2])
+		}
+		r.noContent()
+	case segments[1] == "groups" && len(segments) == 3 && r.Method == http.MethodDelete:
+		realm.memberships[id] = remove(realm.memberships[id], segments[2])
+		r.noContent()
+	case segments[1] == "federated-identity" && r.Method == http.MethodGet:
+		r.write(http.StatusOK, []object{})
+	default:
+		r.notImplemented()
+	}
+}
+
+// clientsInitialAccess only returns the token itself when it is created, like keycloak does
+func (r *request) clientsInitialAccess(realm *realm, segments []string) {
+	switch {
+	case len(segments) == 0 && r.Method == http.MethodGet:
+		tokens := []object{}
+		for _, token := range realm.initialAccessTokens.find(nil) {
+			listed := object{}
+			merge(listed, token)
+			delete(listed, "token")
+			tokens = append(tokens, listed)
+		}
+		r.write(http.StatusOK, tokens)
+	case len(segments) == 0 && r.Method == http.MethodPost:
+		r.write(http.StatusOK, realm.initialAccessTokens.add(object{
+			"expiration":     r.body["expiration"],
+			"count":          r.body["count"],
+			"remainingCount": r.body["count"],
+			"timestamp":      time.Now().Unix(),
+			"token":          newId(),
+		}))
+	case len(segments) == 1 && r.Method == http.MethodDelete:
+		if realm.initialAccessTokens.get(segments[0]) == nil {
+			r.notFound("initial access token")
+			return
+		}
+		realm.initialAccessTokens.remove(segments[0])
+		r.noContent()
+	default:
+		r.notImplemented()
+	}
+}
+
+// userProfile replaces the whole profile on PUT, like keycloak does, and rejects attributes of unknown groups
+func (r *request) userProfile(realm *realm) {
+	switch r.Method {
+	case http.MethodGet:
+		r.write(http.StatusOK, realm.userProfile)
+	case http.MethodPut:
+		groups := map[string]bool{}
+		for _, group := range objects(r.body["groups"]) {
+			groups[fmt.Sprint(group["name"])] = true
+		}
+		for _, attribute := range objects(r.body["attributes"]) {
+			if group, _ := attribute["group"].(string); group != "" && !groups[group] {
+				r.write(http.StatusBadRequest, object{"errorMessage": fmt.Sprintf("Attribute '%s' references unknown group '%s'", attribute["name"], group)})
+				return
+			}
+		}
+		realm.userProfile = r.body
+		r.write(http.StatusOK, realm.userProfile)
+	default:
+		r.notImplemented()
+	}
+}
+
+func (r *request) userFilter() func(object) bool {
+	query := r.URL.Query()
+	exact := query.Get("exact") == "true"
+
+	return func(o object) bool {
+		for _, key := range []string{"username", "email", "firstName", "lastName"} {
+			value := strings.ToLower(query.Get(key))
+			if value == "" {
+				continue
+			}
+
+			actual := strings.ToLower(fmt.Sprint(o[key]))
+			if exact && actual != value || !exact && !strings.Contains(actual, value) {
+				return false
+			}
+		}
+
+		if search := strings.ToLower(query.Get("search")); search != "" {
+			for _, key := range []string{"username", "email", "firstName", "lastName"} {
+				if strings.Contains(strings.ToLower(fmt.Sprint(o[key])), search) {
+					return true
+				}
+			}
+			return false
+		}
+
+		return true
+	}
+}
+
+// page applies the first and max query parameters used for pagination
+func page(objects []object, query map[string][]string) []object {
+	first, _ := strconv.Atoi(firstValue(query["first"]))
+	if first > len(objects) {
+		first = len(objects)
+	}
+	objects = objects[first:]
+
+	if max, err := strconv.Atoi(firstValue(query["max"])); err == nil && max >= 0 && max < len(objects) {
+		objects = objects[:max]
+	}
+
+	return objects
+}
+
+func firstValue(values []string) string {
+	if len(values) == 0 {
+		return ""
+	}
+
+	return values[0]
+}
+
+// collection stores objects in the order they were created, which is the order keycloak usually lists them in
+type collection struct {
+	objects []object
+}
+
+// partialImport only imports users, which are added unless a user with the same username exists
+func (r *request) partialImport(realm *realm) {
+	results := []interface{}{}
+	added, skipped := 0, 0
+	for _, user := range objects(r.body["users"]) {
+		username, _ := user["username"].(string)
+		if existing := realm.users.find(func(o object) bool { return o["username"] == username }); len(existing) != 0 {
+			skipped++
+			results = append(results, object{"action": "SKIPPED", "resourceType": "USER", "resourceName": username, "id": existing[0]["id"]})
+			continue
+		}
+
+		added++
+		realm.users.add(user)
+		results = append(results, object{"action": "ADDED", "resourceType": "USER", "resourceName": username, "id": user["id"]})
+	}
+
+	r.write(http.StatusOK, object{"added": added, "skipped": skipped, "overwritten": 0, "results": results})
+}
+
+func (c *collection) add(o object) object {
+	if o == nil {
+		o = object{}
+	}
+	if id, _ := o["id"].(string); id == "" {
+		o["id"] = newId()
+	}
+
+	c.objects = append(c.objects, o)
+
+	return o
+}
+
+func (c *collection) get(id string) object {
+	for _, o := range c.objects {
+		if o["id"] == id {
+			return o
+		}
+	}
+
+	return nil
+}
+
+func (c *collection) find(filter func(object) bool) []object {
+	result := []object{}
+	for _, o := range c.objects {
+		if filter == nil || filter(o) {
+			result = append(result, o)
+		}
+	}
+
+	return result
+}
+
+func (c *collection) remove(id string) {
+	for i, o := range c.objects {
+		if o["id"] == id {
+			c.objects = append(c.objects[:i], c.objects[i+1:]...)
+			return
+		}
+	}
+}
+
+// merge copies every attribute of update into o, except for the given read only attributes
+func merge(o, update object, readOnly ...string) {
+	for key, value := range update {
+		if !contains(readOnly, key) {
+			o[key] = value
+		}
+	}
+}
+
+// dropEmptyConfig removes config entries without values from a component, like Keycloak does when it stores them
+func dropEmptyConfig(component object) {
+	config, _ := component["config"].(object)
+	for key, values := range config {
+		if values, ok := values.([]interface{}); ok && len(values) == 0 {
+			delete(config, key)
+		}
+	}
+}
+
+func matches(o object, key, value string) bool {
+	return value == "" || fmt.Sprint(o[key]) == value
+}
+
+func matchesAny(o object, key string, values []string) bool {
+	for _, value := range values {
+		if matches(o, key, value) {
+			return true
+		}
+	}
+
+	return len(values) == 0
+}
+
+// events lists login or admin events, which are filtered before they are paged with first and max. Dates aren't
+// supported.
+func (r *request) events(events []object, filter func(object) bool) {
+	if r.Method != http.MethodGet || r.URL.Query().Get("dateFrom") != "" || r.URL.Query().Get("dateTo") != "" {
+		r.notImplemented()
+		return
+	}
+
+	first, _ := strconv.Atoi(r.URL.Query().Get("first"))
+	max, err := strconv.Atoi(r.URL.Query().Get("max"))
+	if err != nil {
+		max = 100
+	}
+
+	result := []object{}
+	for _, event := range events {
+		if filter(event) {
+			result = append(result, event)
+		}
+	}
+
+	if first > len(result) {
+		first = len(result)
+	}
+	result = result[first:]
+	if max < len(result) {
+		result = result[:max]
+	}
+
+	r.write(http.StatusOK, result)
+}
+
+func itemIds(body object) []string {
+	var ids []string
+	items, _ := body["items"].([]interface{})
+	for _, item := range items {
+		if o, ok := item.(map[string]interface{}); ok {
+			ids = append(ids, fmt.Sprint(o["id"]))
+		}
+	}
+
+	return ids
+}
+
+// objects returns the objects of a json array, skipping anything else
+func objects(value interface{}) []object {
+	var result []object
+	items, _ := value.([]interface{})
+	for _, item := range items {
+		if o, ok := item.(map[string]interface{}); ok {
+			result = append(result, o)
+		}
+	}
+
+	return result
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
+
+func remove(values []string, value string) []string {
+	var result []string
+	for _, v := range values {
+		if v != value {
+			result = append(result, v)
+		}
+	}
+
+	return result
+}
+
+func newId() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+
+	id := hex.EncodeToString(b)
+
+	return fmt.Sprintf("%s-%s-%s-%s-%s", id[0:8], id[8:12], id[12:16], id[16:20], id[20:])
+}
@@ -465,6 +465,31 @@ func (keycloakClient *KeycloakClient) GetLdapUserFederation(ctx context.Context,
 	return convertFromComponentToLdapUserFederation(component)
 }
 
+func (keycloakClient *KeycloakClient) GetLdapUserFederations(ctx context.Context, realmName, realmId string) ([]*LdapUserFederation, error) {
+	var components []*component
+	var ldapUserFederations []*LdapUserFederation
+
+	err := keycloakClient.get(ctx, fmt.Sprintf("/realms/%s/components?parent=%s&type=%s", realmName, realmId, userStorageProviderType), &components, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, component := range components {
+		if component.ProviderId != "ldap" {
+			continue
+		}
+
+		ldapUserFederation, err := convertFromComponentToLdapUserFederation(component)
+		if err != nil {
+			return nil, err
+		}
+
+		ldapUserFederations = append(ldapUserFederations, ldapUserFederation)
+	}
+
+	return ldapUserFederations, nil
+}
+
 func (keycloakClient *KeycloakClient) GetLdapUserFederationMappers(ctx context.Context, realmId, id string) (*[]interface{}, error) {
 	var components []*component
 	var ldapUserFederationMappers []interface{}
@@ -0,0 +1,227 @@
+package keycloak
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+)
+
+type OrganizationDomain struct {
+	Name     string `json:"name"`
+	Verified bool   `json:"verified"`
+}
+
+type Organization struct {
+	Id      string `json:"id,omitempty"`
+	RealmId string `json:"-"`
+
+	Name        string                `json:"name"`
+	Alias       string                `json:"alias,omitempty"`
+	Enabled     bool                  `json:"enabled"`
+	Description string                `json:"description"`
+	RedirectUrl string                `json:"redirectUrl"`
+	Domains     []*OrganizationDomain `json:"domains"`
+	Attributes  map[string][]string   `json:"attributes"`
+}
+
+const (
+	organizationDomainConfigKey         = "kc.org.domain"
+	organizationRedirectEmailMatchesKey = "kc.org.broker.redirect.mode.email-matches"
+	organizationMembersPageSize         = 100
+)
+
+// OrganizationIdentityProvider describes an identity provider linked to an organization.
+// Users whose email matches Domain can be redirected to the identity provider automatically.
+type OrganizationIdentityProvider struct {
+	RealmId        string
+	OrganizationId string
+	Alias          string
+
+	Domain               string
+	RedirectEmailMatches bool
+}
+
+// CheckOrganizationsSupported returns an error if the server does not support organizations
+func (keycloakClient *KeycloakClient) CheckOrganizationsSupported(ctx context.Context) error {
+	return keycloakClient.RequireFeature(ctx, FeatureOrganization)
+}
+
+func (keycloakClient *KeycloakClient) NewOrganization(ctx context.Context, organization *Organization) error {
+	_, location, err := keycloakClient.post(ctx, fmt.Sprintf("/realms/%s/organizations", organization.RealmId), organization)
+	if err != nil {
+		return err
+	}
+
+	organization.Id = getIdFromLocationHeader(location)
+
+	return nil
+}
+
+func (keycloakClient *KeycloakClient) GetOrganization(ctx context.Context, realmId, id string) (*Organization, error) {
+	var organization Organization
+
+	err := keycloakClient.get(ctx, fmt.Sprintf("/realms/%s/organizations/%s", realmId, id), &organization, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	organization.RealmId = realmId
+
+	return &organization, nil
+}
+
+func (keycloakClient *KeycloakClient) UpdateOrganization(ctx context.Context, organization *Organization) error {
+	return keycloakClient.put(ctx, fmt.Sprintf("/realms/%s/organizations/%s", organization.RealmId, organization.Id), organization)
+}
+
+func (keycloakClient *KeycloakClient) DeleteOrganization(ctx context.Context, realmId, id string) error {
+	return keycloakClient.delete(ctx, fmt.Sprintf("/realms/%s/organizations/%s", realmId, id), nil)
+}
+
+func (keycloakClient *KeycloakClient) GetOrganizationMembers(ctx context.Context, realmId, organizationId string) ([]*User, error) {
+	var members []*User
+
+	// the members endpoint is paginated and only returns a handful of members by default
+	for first := 0; ; first += organizationMembersPageSize {
+		var page []*User
+
+		err := keycloakClient.get(ctx, fmt.Sprintf("/realms/%s/organizations/%s/members", realmId, organizationId), &page, map[string]string{
+			"first": strconv.Itoa(first),
+			"max":   strconv.Itoa(organizationMembersPageSize),
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, member := range page {
+			member.RealmId = realmId
+		}
+
+		members = append(members, page...)
+
+		if len(page) < organizationMembersPageSize {
+			break
+		}
+	}
+
+	return members, nil
+}
+
+func (keycloakClient *KeycloakClient) AddUserToOrganization(ctx context.Context, realmId, organizationId, userId string) error {
+	// this endpoint expects the user id as the raw request body rather than a JSON document
+	_, err := keycloakClient.sendRaw(ctx, fmt.Sprintf("/realms/%s/organizations/%s/members", realmId, organizationId), []byte(userId))
+
+	return err
+}
+
+func (keycloakClient *KeycloakClient) RemoveUserFromOrganization(ctx context.Context, realmId, organizationId, userId string) error {
+	return keycloakClient.delete(ctx, fmt.Sprintf("/realms/%s/organizations/%s/members/%s", realmId, organizationId, userId), nil)
+}
+
+func (keycloakClient *KeycloakClient) AddUsersToOrganization(ctx context.Context, realmId, organizationId string, usernames []interface{}) error {
+	for _, username := range usernames {
+		user, err := keycloakClient.GetUserByUsername(ctx, realmId, username.(string)) // we need the user's id in order to add them to an organization
+		if err != nil {
+			return err
+		}
+		if user == nil {
+			return fmt.Errorf("user with username %s does not exist", username.(string))
+		}
+
+		err = keycloakClient.AddUserToOrganization(ctx, realmId, organizationId, user.Id)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (keycloakClient *KeycloakClient) RemoveUsersFromOrganization(ctx context.Context, realmId, organizationId string, usernames []interface{}) error {
+	for _, username := range usernames {
+		user, err := keycloakClient.GetUserByUsername(ctx, realmId, username.(string)) // we need the user's id in order to remove them from an organization
+		if err != nil {
+			return err
+		}
+		if user == nil {
+			return fmt.Errorf("user with username %s does not exist", username.(string))
+		}
+
+		err = keycloakClient.RemoveUserFromOrganization(ctx, realmId, organizationId, user.Id)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (keycloakClient *KeycloakClient) NewOrganizationIdentityProvider(ctx context.Context, organizationIdentityProvider *OrganizationIdentityProvider) error {
+	// this endpoint expects the identity provider alias as the raw request body rather than a JSON document
+	_, err := keycloakClient.sendRaw(ctx, fmt.Sprintf("/realms/%s/organizations/%s/identity-providers", organizationIdentityProvider.RealmId, organizationIdentityProvider.OrganizationId), []byte(organizationIdentityProvider.Alias))
+	if err != nil {
+		return err
+	}
+
+	return keycloakClient.UpdateOrganizationIdentityProvider(ctx, organizationIdentityProvider)
+}
+
+func (keycloakClient *KeycloakClient) GetOrganizationIdentityProvider(ctx context.Context, realmId, organizationId, alias string) (*OrganizationIdentityProvider, error) {
+	var identityProvider IdentityProvider
+
+	err := keycloakClient.get(ctx, fmt.Sprintf("/realms/%s/organizations/%s/identity-providers/%s", realmId, organizationId, alias), &identityProvider, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	organizationIdentityProvider := &OrganizationIdentityProvider{
+		RealmId:        realmId,
+		OrganizationId: organizationId,
+		Alias:          identityProvider.Alias,
+	}
+
+	if identityProvider.Config != nil {
+		if domain, ok := identityProvider.Config.ExtraConfig[organizationDomainConfigKey].(string); ok {
+			organizationIdentityProvider.Domain = domain
+		}
+
+		if redirectEmailMatches, ok := identityProvider.Config.ExtraConfig[organizationRedirectEmailMatchesKey].(string); ok {
+			organizationIdentityProvider.RedirectEmailMatches, err = parseBoolAndTreatEmptyStringAsFalse(redirectEmailMatches)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return organizationIdentityProvider, nil
+}
+
+// UpdateOrganizationIdentityProvider stores the organization specific settings within the config of the linked identity provider
+func (keycloakClient *KeycloakClient) UpdateOrganizationIdentityProvider(ctx context.Context, organizationIdentityProvider *OrganizationIdentityProvider) error {
+	identityProvider, err := keycloakClient.GetIdentityProvider(ctx, organizationIdentityProvider.RealmId, organizationIdentityProvider.Alias)
+	if err != nil {
+		return err
+	}
+
+	if identityProvider.Config == nil {
+		identityProvider.Config = &IdentityProviderConfig{}
+	}
+
+	if identityProvider.Config.ExtraConfig == nil {
+		identityProvider.Config.ExtraConfig = map[string]interface{}{}
+	}
+
+	identityProvider.Config.ExtraConfig[organizationDomainConfigKey] = organizationIdentityProvider.Domain
+	identityProvider.Config.ExtraConfig[organizationRedirectEmailMatchesKey] = strconv.FormatBool(organizationIdentityProvider.RedirectEmailMatches)
+
+	return keycloakClient.UpdateIdentityProvider(ctx, identityProvider)
+}
+
+func (keycloakClient *KeycloakClient) DeleteOrganizationIdentityProvider(ctx context.Context, realmId, organizationId, alias string) error {
+	return keycloakClient.delete(ctx, fmt.Sprintf("/realms/%s/organizations/%s/identity-providers/%s", realmId, organizationId, alias), nil)
+}
+
+// IsOrganizationConfigKey returns true for identity provider config keys which are managed through an organization
+func IsOrganizationConfigKey(key string) bool {
+	return key == organizationDomainConfigKey || key == organizationRedirectEmailMatchesKey
+}
@@ -2,8 +2,11 @@ package keycloak
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"github.com/keycloak/terraform-provider-keycloak/keycloak/types"
+	"sort"
+	"strconv"
 	"strings"
 )
 
@@ -30,6 +33,9 @@ type Realm struct {
 	DisplayNameHtml   string `json:"displayNameHtml"`
 	UserManagedAccess bool   `json:"userManagedAccessAllowed"`
 
+	// Organizations, Keycloak 25+
+	OrganizationsEnabled *bool `json:"organizationsEnabled,omitempty"`
+
 	// Login Config
 	RegistrationAllowed         bool   `json:"registrationAllowed"`
 	RegistrationEmailAsUsername bool   `json:"registrationEmailAsUsername"`
@@ -166,6 +172,157 @@ type SmtpServer struct {
 	Ssl                types.KeycloakBoolQuoted `json:"ssl,omitempty"`
 	User               string                   `json:"user,omitempty"`
 	Password           string                   `json:"password,omitempty"`
+
+	// XOAUTH2 authentication, where the password is replaced by a token obtained with the client credentials grant
+	AuthType              string `json:"authType,omitempty"`
+	AuthTokenUrl          string `json:"authTokenUrl,omitempty"`
+	AuthTokenScope        string `json:"authTokenScope,omitempty"`
+	AuthTokenClientId     string `json:"authTokenClientId,omitempty"`
+	AuthTokenClientSecret string `json:"authTokenClientSecret,omitempty"`
+}
+
+// Keycloak has no fields for the CIBA and PAR policies in the realm representation, it keeps them in the attributes of
+// the realm instead
+const (
+	realmAttributeCibaBackchannelTokenDeliveryMode = "cibaBackchannelTokenDeliveryMode"
+	realmAttributeCibaExpiresIn                    = "cibaExpiresIn"
+	realmAttributeCibaInterval                     = "cibaInterval"
+	realmAttributeCibaAuthRequestedUserHint        = "cibaAuthRequestedUserHint"
+	realmAttributeParRequestUriLifespan            = "parRequestUriLifespan"
+)
+
+// RealmCibaPolicyAttributes and RealmParPolicyAttributes are the attributes of the realm that the policies are kept in
+var (
+	RealmCibaPolicyAttributes = []string{
+		realmAttributeCibaBackchannelTokenDeliveryMode,
+		realmAttributeCibaExpiresIn,
+		realmAttributeCibaInterval,
+		realmAttributeCibaAuthRequestedUserHint,
+	}
+	RealmParPolicyAttributes = []string{realmAttributeParRequestUriLifespan}
+)
+
+// RealmCibaPolicy is the policy of the client initiated backchannel authentication grant, the defaults are the ones
+// keycloak uses when the realm doesn't have the attributes
+type RealmCibaPolicy struct {
+	BackchannelTokenDeliveryMode string
+	ExpiresIn                    int
+	Interval                     int
+	AuthRequestedUserHint        string
+}
+
+// RealmParPolicy is the policy of pushed authorization requests
+type RealmParPolicy struct {
+	RequestUriLifespan int
+}
+
+func (realm *Realm) getIntAttribute(key string, defaultValue int) int {
+	switch value := realm.Attributes[key].(type) {
+	case string:
+		if i, err := strconv.Atoi(value); err == nil {
+			return i
+		}
+	case float64:
+		return int(value)
+	}
+
+	return defaultValue
+}
+
+func (realm *Realm) getStringAttribute(key string, defaultValue string) string {
+	if value, ok := realm.Attributes[key].(string); ok && value != "" {
+		return value
+	}
+
+	return defaultValue
+}
+
+func (realm *Realm) setAttribute(key string, value string) {
+	if realm.Attributes == nil {
+		realm.Attributes = map[string]interface{}{}
+	}
+
+	realm.Attributes[key] = value
+}
+
+func (realm *Realm) GetCibaPolicy() *RealmCibaPolicy {
+	return &RealmCibaPolicy{
+		BackchannelTokenDeliveryMode: realm.getStringAttribute(realmAttributeCibaBackchannelTokenDeliveryMode, "poll"),
+		ExpiresIn:                    realm.getIntAttribute(realmAttributeCibaExpiresIn, 120),
+		Interval:                     realm.getIntAttribute(realmAttributeCibaInterval, 5),
+		AuthRequestedUserHint:        realm.getStringAttribute(realmAttributeCibaAuthRequestedUserHint, "login_hint"),
+	}
+}
+
+// SetCibaPolicy sets the attributes of the policy, leaving the other attributes of the realm as they are
+func (realm *Realm) SetCibaPolicy(cibaPolicy *RealmCibaPolicy) {
+	realm.setAttribute(realmAttributeCibaBackchannelTokenDeliveryMode, cibaPolicy.BackchannelTokenDeliveryMode)
+	realm.setAttribute(realmAttributeCibaExpiresIn, strconv.Itoa(cibaPolicy.ExpiresIn))
+	realm.setAttribute(realmAttributeCibaInterval, strconv.Itoa(cibaPolicy.Interval))
+	realm.setAttribute(realmAttributeCibaAuthRequestedUserHint, cibaPolicy.AuthRequestedUserHint)
+}
+
+func (realm *Realm) GetParPolicy() *RealmParPolicy {
+	return &RealmParPolicy{
+		RequestUriLifespan: realm.getIntAttribute(realmAttributeParRequestUriLifespan, 60),
+	}
+}
+
+// SetParPolicy sets the attributes of the policy, leaving the other attributes of the realm as they are
+func (realm *Realm) SetParPolicy(parPolicy *RealmParPolicy) {
+	realm.setAttribute(realmAttributeParRequestUriLifespan, strconv.Itoa(parPolicy.RequestUriLifespan))
+}
+
+// RealmPasswordPolicy is one of the policies the password policy of a realm is made of, like length(12). The Value of
+// a policy without config, like notUsername, is empty.
+type RealmPasswordPolicy struct {
+	Id    string
+	Value string
+}
+
+// ParseRealmPasswordPolicy splits a password policy like "length(12) and notUsername" into its policies, the same way
+// keycloak does
+func ParseRealmPasswordPolicy(passwordPolicy string) []RealmPasswordPolicy {
+	var policies []RealmPasswordPolicy
+
+	for _, policy := range strings.Split(passwordPolicy, " and ") {
+		policy = strings.TrimSpace(policy)
+		if policy == "" {
+			continue
+		}
+
+		id, value, found := strings.Cut(policy, "(")
+		if found {
+			value = strings.TrimSuffix(value, ")")
+		}
+
+		policies = append(policies, RealmPasswordPolicy{
+			Id:    strings.TrimSpace(id),
+			Value: value,
+		})
+	}
+
+	return policies
+}
+
+// FormatRealmPasswordPolicy joins policies into a password policy. They're sorted by id, so the same policies always
+// give the same password policy whatever order they're in.
+func FormatRealmPasswordPolicy(policies []RealmPasswordPolicy) string {
+	policies = append([]RealmPasswordPolicy{}, policies...)
+	sort.SliceStable(policies, func(i, j int) bool {
+		return policies[i].Id < policies[j].Id
+	})
+
+	var formatted []string
+	for _, policy := range policies {
+		if policy.Value == "" {
+			formatted = append(formatted, policy.Id)
+		} else {
+			formatted = append(formatted, fmt.Sprintf("%s(%s)", policy.Id, policy.Value))
+		}
+	}
+
+	return strings.Join(formatted, " and ")
 }
 
 func (keycloakClient *KeycloakClient) NewRealm(ctx context.Context, realm *Realm) error {
@@ -220,6 +377,40 @@ func (keycloakClient *KeycloakClient) DeleteRealm(ctx context.Context, name stri
 	return nil
 }
 
+// ErrLoggedInUserHasNoEmail is returned when a test email can't be sent because the user the provider is logged in as
+// has no email address. Keycloak always sends the test email to that user, and service accounts usually don't have one.
+var ErrLoggedInUserHasNoEmail = errors.New("the user the provider is logged in as has no email address to send the test email to")
+
+// TestRealmSmtpConnection sends a test email with the given smtp settings through an existing realm.
+func (keycloakClient *KeycloakClient) TestRealmSmtpConnection(ctx context.Context, realmId string, smtpServer *SmtpServer) error {
+	err := keycloakClient.testSmtpConnection(ctx, realmId, smtpServer)
+	if apiError, ok := AsApiError(err); ok {
+		return fmt.Errorf("validation error: unable to send a test email with the smtp settings of realm %s: %s", realmId, apiError.Description())
+	}
+
+	return err
+}
+
+// TestSmtpConnection sends a test email with the given smtp settings through the realm the provider logs in to, so the
+// settings of a realm can be tested before the realm is created.
+func (keycloakClient *KeycloakClient) TestSmtpConnection(ctx context.Context, smtpServer *SmtpServer) error {
+	err := keycloakClient.testSmtpConnection(ctx, keycloakClient.realm, smtpServer)
+	if apiError, ok := AsApiError(err); ok {
+		return fmt.Errorf("validation error: unable to send a test email with the smtp settings: %s", apiError.Description())
+	}
+
+	return err
+}
+
+func (keycloakClient *KeycloakClient) testSmtpConnection(ctx context.Context, realmId string, smtpServer *SmtpServer) error {
+	_, _, err := keycloakClient.post(ctx, fmt.Sprintf("/realms/%s/testSMTPConnection", realmId), smtpServer)
+	if apiError, ok := AsApiError(err); ok && apiError.Description() == "Logged in user does not have an e-mail." {
+		return ErrLoggedInUserHasNoEmail
+	}
+
+	return err
+}
+
 func (keycloakClient *KeycloakClient) ValidateRealm(ctx context.Context, realm *Realm) error {
 	if realm.DuplicateEmailsAllowed == true && realm.RegistrationEmailAsUsername == true {
 		return fmt.Errorf("validation error: DuplicateEmailsAllowed cannot be true if RegistrationEmailAsUsername is true")
@@ -255,17 +446,19 @@ func (keycloakClient *KeycloakClient) ValidateRealm(ctx context.Context, realm *
 		return fmt.Errorf("validation error: theme \"%s\" does not exist on the server", realm.EmailTheme)
 	}
 
+	if realm.SmtpServer.AuthType == "token" {
+		if err := keycloakClient.RequireFeature(ctx, FeatureSmtpTokenAuth); err != nil {
+			return err
+		}
+	}
+
 	if realm.InternationalizationEnabled == true && !contains(realm.SupportLocales, realm.DefaultLocale) {
 		return fmt.Errorf("validation error: DefaultLocale should be in the SupportLocales")
 	}
 
-	if realm.PasswordPolicy != "" {
-		policies := strings.Split(realm.PasswordPolicy, " and ")
-		for _, policyTypeRepresentation := range policies {
-			policy := strings.Split(policyTypeRepresentation, "(")
-			if !serverInfo.providerInstalled("password-policy", policy[0]) {
-				return fmt.Errorf("validation error: password-policy \"%s\" does not exist on the server, installed providers: %s", policy[0], serverInfo.getInstalledProvidersNames("password-policy"))
-			}
+	for _, policy := range ParseRealmPasswordPolicy(realm.PasswordPolicy) {
+		if !serverInfo.providerInstalled("password-policy", policy.Id) {
+			return fmt.Errorf("validation error: password-policy \"%s\" does not exist on the server, installed providers: %s", policy.Id, serverInfo.getInstalledProvidersNames("password-policy"))
 		}
 	}
 
@@ -0,0 +1,76 @@
+package keycloak
+
+import (
+	"context"
+	"fmt"
+)
+
+// https://www.keycloak.org/docs/latest/server_admin/#_client_policies
+
+type RealmClientProfileExecutor struct {
+	Executor      string                 `json:"executor"`
+	Configuration map[string]interface{} `json:"configuration"`
+}
+
+type RealmClientProfile struct {
+	Name        string                        `json:"name"`
+	Description string                        `json:"description,omitempty"`
+	Executors   []*RealmClientProfileExecutor `json:"executors"`
+}
+
+type RealmClientProfiles struct {
+	Profiles       []*RealmClientProfile `json:"profiles"`
+	GlobalProfiles []*RealmClientProfile `json:"globalProfiles,omitempty"`
+}
+
+type RealmClientPolicyCondition struct {
+	Condition     string                 `json:"condition"`
+	Configuration map[string]interface{} `json:"configuration"`
+}
+
+type RealmClientPolicy struct {
+	Name        string                        `json:"name"`
+	Description string                        `json:"description,omitempty"`
+	Enabled     bool                          `json:"enabled"`
+	Conditions  []*RealmClientPolicyCondition `json:"conditions"`
+	Profiles    []string                      `json:"profiles"`
+}
+
+type RealmClientPolicies struct {
+	Policies       []*RealmClientPolicy `json:"policies"`
+	GlobalPolicies []*RealmClientPolicy `json:"globalPolicies,omitempty"`
+}
+
+func (keycloakClient *KeycloakClient) GetRealmClientProfiles(ctx context.Context, realmId string) (*RealmClientProfiles, error) {
+	var realmClientProfiles RealmClientProfiles
+
+	err := keycloakClient.get(ctx, fmt.Sprintf("/realms/%s/client-policies/profiles", realmId), &realmClientProfiles, map[string]string{
+		"include-global-profiles": "false",
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &realmClientProfiles, nil
+}
+
+func (keycloakClient *KeycloakClient) UpdateRealmClientProfiles(ctx context.Context, realmId string, realmClientProfiles *RealmClientProfiles) error {
+	return keycloakClient.put(ctx, fmt.Sprintf("/realms/%s/client-policies/profiles", realmId), realmClientProfiles)
+}
+
+func (keycloakClient *KeycloakClient) GetRealmClientPolicies(ctx context.Context, realmId string) (*RealmClientPolicies, error) {
+	var realmClientPolicies RealmClientPolicies
+
+	err := keycloakClient.get(ctx, fmt.Sprintf("/realms/%s/client-policies/policies", realmId), &realmClientPolicies, map[string]string{
+		"include-global-policies": "false",
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &realmClientPolicies, nil
+}
+
+func (keycloakClient *KeycloakClient) UpdateRealmClientPolicies(ctx context.Context, realmId string, realmClientPolicies *RealmClientPolicies) error {
+	return keycloakClient.put(ctx, fmt.Sprintf("/realms/%s/client-policies/policies", realmId), realmClientPolicies)
+}
@@ -3,6 +3,8 @@ package keycloak
 import (
 	"context"
 	"fmt"
+	"net/url"
+	"strconv"
 )
 
 type RealmEventsConfig struct {
@@ -28,3 +30,147 @@ func (keycloakClient *KeycloakClient) GetRealmEventsConfig(ctx context.Context,
 func (keycloakClient *KeycloakClient) UpdateRealmEventsConfig(ctx context.Context, realmId string, realmEventsConfig *RealmEventsConfig) error {
 	return keycloakClient.put(ctx, fmt.Sprintf("/realms/%s/events/config", realmId), realmEventsConfig)
 }
+
+// RealmEvent is a login event, such as a login or a token refresh, stored when events are enabled for the realm
+type RealmEvent struct {
+	Time      int64             `json:"time"`
+	Type      string            `json:"type"`
+	RealmId   string            `json:"realmId"`
+	ClientId  string            `json:"clientId"`
+	UserId    string            `json:"userId"`
+	SessionId string            `json:"sessionId"`
+	IpAddress string            `json:"ipAddress"`
+	Error     string            `json:"error"`
+	Details   map[string]string `json:"details"`
+}
+
+// RealmEventsQuery filters the login events of a realm. Dates are formatted as yyyy-MM-dd, and a Max of 0 returns
+// keycloak's default page of 100 events.
+type RealmEventsQuery struct {
+	Types     []string
+	ClientId  string
+	UserId    string
+	IpAddress string
+	DateFrom  string
+	DateTo    string
+	First     int
+	Max       int
+}
+
+type AdminEventAuthDetails struct {
+	RealmId   string `json:"realmId"`
+	ClientId  string `json:"clientId"`
+	UserId    string `json:"userId"`
+	IpAddress string `json:"ipAddress"`
+}
+
+// AdminEvent is a change made through the admin API, stored when admin events are enabled for the realm
+type AdminEvent struct {
+	Time           int64                 `json:"time"`
+	RealmId        string                `json:"realmId"`
+	AuthDetails    AdminEventAuthDetails `json:"authDetails"`
+	OperationType  string                `json:"operationType"`
+	ResourceType   string                `json:"resourceType"`
+	ResourcePath   string                `json:"resourcePath"`
+	Representation string                `json:"representation"`
+	Error          string                `json:"error"`
+}
+
+// AdminEventsQuery filters the admin events of a realm, in the same way as RealmEventsQuery
+type AdminEventsQuery struct {
+	OperationTypes []string
+	ResourceTypes  []string
+	ResourcePath   string
+	AuthRealmId    string
+	AuthClientId   string
+	AuthUserId     string
+	AuthIpAddress  string
+	DateFrom       string
+	DateTo         string
+	First          int
+	Max            int
+}
+
+// eventQueryValues adds the attributes that both kinds of event queries share. The types are sent as repeated
+// parameters, which the params of get don't support, so queries are encoded into the path.
+func eventQueryValues(values url.Values, dateFrom, dateTo string, first, max int) string {
+	for key, value := range map[string]string{"dateFrom": dateFrom, "dateTo": dateTo} {
+		if value != "" {
+			values.Set(key, value)
+		}
+	}
+
+	if first != 0 {
+		values.Set("first", strconv.Itoa(first))
+	}
+
+	if max != 0 {
+		values.Set("max", strconv.Itoa(max))
+	}
+
+	return values.Encode()
+}
+
+func (query *RealmEventsQuery) encode() string {
+	values := url.Values{}
+	for _, eventType := range query.Types {
+		values.Add("type", eventType)
+	}
+
+	for key, value := range map[string]string{"client": query.ClientId, "user": query.UserId, "ipAddress": query.IpAddress} {
+		if value != "" {
+			values.Set(key, value)
+		}
+	}
+
+	return eventQueryValues(values, query.DateFrom, query.DateTo, query.First, query.Max)
+}
+
+func (query *AdminEventsQuery) encode() string {
+	values := url.Values{}
+	for _, operationType := range query.OperationTypes {
+		values.Add("operationTypes", operationType)
+	}
+
+	for _, resourceType := range query.ResourceTypes {
+		values.Add("resourceTypes", resourceType)
+	}
+
+	for key, value := range map[string]string{
+		"resourcePath":  query.ResourcePath,
+		"authRealm":     query.AuthRealmId,
+		"authClient":    query.AuthClientId,
+		"authUser":      query.AuthUserId,
+		"authIpAddress": query.AuthIpAddress,
+	} {
+		if value != "" {
+			values.Set(key, value)
+		}
+	}
+
+	return eventQueryValues(values, query.DateFrom, query.DateTo, query.First, query.Max)
+}
+
+// GetRealmEvents returns the login events of a realm that match the query, newest first
+func (keycloakClient *KeycloakClient) GetRealmEvents(ctx context.Context, realmId string, query *RealmEventsQuery) ([]*RealmEvent, error) {
+	var events []*RealmEvent
+
+	err := keycloakClient.get(ctx, fmt.Sprintf("/realms/%s/events?%s", realmId, query.encode()), &events, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return events, nil
+}
+
+// GetRealmAdminEvents returns the admin events of a realm that match the query, newest first
+func (keycloakClient *KeycloakClient) GetRealmAdminEvents(ctx context.Context, realmId string, query *AdminEventsQuery) ([]*AdminEvent, error) {
+	var events []*AdminEvent
+
+	err := keycloakClient.get(ctx, fmt.Sprintf("/realms/%s/admin-events?%s", realmId, query.encode()), &events, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return events, nil
+}
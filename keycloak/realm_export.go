@@ -0,0 +1,58 @@
+package keycloak
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+type RealmExportClient struct {
+	Id       string `json:"id"`
+	ClientId string `json:"clientId"`
+	Protocol string `json:"protocol"`
+}
+
+type RealmExport struct {
+	Realm   string                   `json:"realm"`
+	Clients []*RealmExportClient     `json:"clients"`
+	Groups  []*Group                 `json:"groups"`
+	Roles   *RealmPartialImportRoles `json:"roles"`
+
+	// the unmodified JSON document returned by keycloak
+	Raw string `json:"-"`
+}
+
+func (keycloakClient *KeycloakClient) GetRealmExport(ctx context.Context, realmId string, exportClients, exportGroupsAndRoles bool) (*RealmExport, error) {
+	// despite being read-only, the partial-export endpoint only accepts POST requests
+	body, err := keycloakClient.sendRaw(ctx, fmt.Sprintf("/realms/%s/partial-export?exportClients=%t&exportGroupsAndRoles=%t", realmId, exportClients, exportGroupsAndRoles), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var realmExport RealmExport
+	err = json.Unmarshal(body, &realmExport)
+	if err != nil {
+		return nil, err
+	}
+
+	realmExport.Raw = string(body)
+
+	return &realmExport, nil
+}
+
+// GroupPaths returns the paths of every group and subgroup within the export, in depth-first order
+func (realmExport *RealmExport) GroupPaths() []string {
+	var paths []string
+
+	var walk func(groups []*Group)
+	walk = func(groups []*Group) {
+		for _, group := range groups {
+			paths = append(paths, group.Path)
+			walk(group.SubGroups)
+		}
+	}
+
+	walk(realmExport.Groups)
+
+	return paths
+}
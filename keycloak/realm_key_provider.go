@@ -0,0 +1,104 @@
+package keycloak
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+)
+
+const keyProviderType = "org.keycloak.keys.KeyProvider"
+
+var ecdsaEllipticCurveAlgorithms = map[string]string{
+	"P-256": "ES256",
+	"P-384": "ES384",
+	"P-521": "ES512",
+}
+
+// RealmKeyProvider is a key provider of any type within a realm, such as the ones managed by the keystore resources
+type RealmKeyProvider struct {
+	Id         string
+	Name       string
+	RealmId    string
+	ProviderId string
+
+	Active    bool
+	Enabled   bool
+	Priority  int
+	Algorithm string
+}
+
+func convertFromComponentToRealmKeyProvider(component *component, realmId string) (*RealmKeyProvider, error) {
+	active, err := parseBoolAndTreatEmptyStringAsFalse(component.getConfig("active"))
+	if err != nil {
+		return nil, err
+	}
+
+	enabled, err := parseBoolAndTreatEmptyStringAsFalse(component.getConfig("enabled"))
+	if err != nil {
+		return nil, err
+	}
+
+	priority := 0 // Default priority
+	if component.getConfig("priority") != "" {
+		priority, err = strconv.Atoi(component.getConfig("priority"))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	algorithm := component.getConfig("algorithm")
+	if curve := component.getConfig("ecdsaEllipticCurveKey"); algorithm == "" && curve != "" {
+		algorithm = ecdsaEllipticCurveAlgorithms[curve]
+	}
+
+	return &RealmKeyProvider{
+		Id:         component.Id,
+		Name:       component.Name,
+		RealmId:    realmId,
+		ProviderId: component.ProviderId,
+
+		Active:    active,
+		Enabled:   enabled,
+		Priority:  priority,
+		Algorithm: algorithm,
+	}, nil
+}
+
+func (keycloakClient *KeycloakClient) GetRealmKeyProviders(ctx context.Context, realmId string) ([]*RealmKeyProvider, error) {
+	var components []*component
+
+	err := keycloakClient.get(ctx, fmt.Sprintf("/realms/%s/components", realmId), &components, map[string]string{
+		"type": keyProviderType,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var keyProviders []*RealmKeyProvider
+	for _, component := range components {
+		keyProvider, err := convertFromComponentToRealmKeyProvider(component, realmId)
+		if err != nil {
+			return nil, err
+		}
+
+		keyProviders = append(keyProviders, keyProvider)
+	}
+
+	return keyProviders, nil
+}
+
+// SetRealmKeyProviderActive changes whether the keys of a provider are used for signing and encryption. Inactive keys
+// are passive, they are still used to verify signatures and decrypt.
+func (keycloakClient *KeycloakClient) SetRealmKeyProviderActive(ctx context.Context, realmId, id string, active bool) error {
+	var component *component
+
+	err := keycloakClient.get(ctx, fmt.Sprintf("/realms/%s/components/%s", realmId, id), &component, nil)
+	if err != nil {
+		return err
+	}
+
+	// secrets such as private keys are masked in the response, keycloak keeps their values when they are sent back
+	component.Config["active"] = []string{strconv.FormatBool(active)}
+
+	return keycloakClient.put(ctx, fmt.Sprintf("/realms/%s/components/%s", realmId, id), component)
+}
@@ -0,0 +1,37 @@
+package keycloak
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+)
+
+func (keycloakClient *KeycloakClient) GetRealmLocalizationTexts(ctx context.Context, realmId, locale string) (map[string]string, error) {
+	var texts map[string]string
+
+	err := keycloakClient.get(ctx, fmt.Sprintf("/realms/%s/localization/%s", realmId, locale), &texts, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return texts, nil
+}
+
+// UpdateRealmLocalizationTexts creates or updates the given texts in a single request, texts which are not part of the map are left untouched
+func (keycloakClient *KeycloakClient) UpdateRealmLocalizationTexts(ctx context.Context, realmId, locale string, texts map[string]string) error {
+	if len(texts) == 0 {
+		return nil
+	}
+
+	_, _, err := keycloakClient.post(ctx, fmt.Sprintf("/realms/%s/localization/%s", realmId, locale), texts)
+
+	return err
+}
+
+func (keycloakClient *KeycloakClient) DeleteRealmLocalizationText(ctx context.Context, realmId, locale, key string) error {
+	return keycloakClient.delete(ctx, fmt.Sprintf("/realms/%s/localization/%s/%s", realmId, locale, url.PathEscape(key)), nil)
+}
+
+func (keycloakClient *KeycloakClient) DeleteRealmLocalizationTexts(ctx context.Context, realmId, locale string) error {
+	return keycloakClient.delete(ctx, fmt.Sprintf("/realms/%s/localization/%s", realmId, locale), nil)
+}
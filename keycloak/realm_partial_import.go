@@ -0,0 +1,146 @@
+package keycloak
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+const (
+	PartialImportIfResourceExistsFail      = "FAIL"
+	PartialImportIfResourceExistsSkip      = "SKIP"
+	PartialImportIfResourceExistsOverwrite = "OVERWRITE"
+)
+
+// RealmPartialImportRoles holds the realm and client roles of a partial import, client roles are keyed by client id
+type RealmPartialImportRoles struct {
+	Realm  []*Role            `json:"realm,omitempty"`
+	Client map[string][]*Role `json:"client,omitempty"`
+}
+
+// RealmPartialImport is the subset of a realm representation that is understood by the partialImport endpoint.
+// It is only used to validate an import before it is sent, the original JSON document is sent as-is so that
+// attributes which are not modeled by this provider are not lost.
+type RealmPartialImport struct {
+	Users             []*User                  `json:"users,omitempty"`
+	Groups            []*Group                 `json:"groups,omitempty"`
+	Clients           []*OpenidClient          `json:"clients,omitempty"`
+	IdentityProviders []*IdentityProvider      `json:"identityProviders,omitempty"`
+	Roles             *RealmPartialImportRoles `json:"roles,omitempty"`
+}
+
+type RealmPartialImportResult struct {
+	Action       string `json:"action"`
+	ResourceType string `json:"resourceType"`
+	ResourceName string `json:"resourceName"`
+	Id           string `json:"id"`
+}
+
+type RealmPartialImportResults struct {
+	Overwritten int                         `json:"overwritten"`
+	Added       int                         `json:"added"`
+	Skipped     int                         `json:"skipped"`
+	Results     []*RealmPartialImportResult `json:"results"`
+}
+
+// ValidateRealmPartialImport decodes the given realm representation into the provider's types and checks that every
+// object within it can be identified by Keycloak.
+func ValidateRealmPartialImport(realmId string, representation []byte) error {
+	var realm Realm
+	if err := json.Unmarshal(representation, &realm); err != nil {
+		return fmt.Errorf("validation error: unable to parse realm representation: %s", err)
+	}
+
+	if realm.Realm != "" && realm.Realm != realmId {
+		return fmt.Errorf("validation error: realm representation is for realm \"%s\", but is being imported into realm \"%s\"", realm.Realm, realmId)
+	}
+
+	var partialImport RealmPartialImport
+	if err := json.Unmarshal(representation, &partialImport); err != nil {
+		return fmt.Errorf("validation error: unable to parse realm representation: %s", err)
+	}
+
+	for _, user := range partialImport.Users {
+		if user.Username == "" {
+			return fmt.Errorf("validation error: every user must have a username")
+		}
+	}
+
+	for _, group := range partialImport.Groups {
+		if err := validateRealmPartialImportGroup(group); err != nil {
+			return err
+		}
+	}
+
+	for _, client := range partialImport.Clients {
+		if client.ClientId == "" {
+			return fmt.Errorf("validation error: every client must have a clientId")
+		}
+	}
+
+	for _, identityProvider := range partialImport.IdentityProviders {
+		if identityProvider.Alias == "" {
+			return fmt.Errorf("validation error: every identity provider must have an alias")
+		}
+		if identityProvider.ProviderId == "" {
+			return fmt.Errorf("validation error: identity provider \"%s\" must have a providerId", identityProvider.Alias)
+		}
+	}
+
+	if partialImport.Roles != nil {
+		for _, role := range partialImport.Roles.Realm {
+			if role.Name == "" {
+				return fmt.Errorf("validation error: every realm role must have a name")
+			}
+		}
+		for clientId, roles := range partialImport.Roles.Client {
+			for _, role := range roles {
+				if role.Name == "" {
+					return fmt.Errorf("validation error: every role of client \"%s\" must have a name", clientId)
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+func validateRealmPartialImportGroup(group *Group) error {
+	if group.Name == "" {
+		return fmt.Errorf("validation error: every group must have a name")
+	}
+
+	for _, subGroup := range group.SubGroups {
+		if err := validateRealmPartialImportGroup(subGroup); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (keycloakClient *KeycloakClient) NewRealmPartialImport(ctx context.Context, realmId, ifResourceExists string, representation []byte) (*RealmPartialImportResults, error) {
+	if err := ValidateRealmPartialImport(realmId, representation); err != nil {
+		return nil, err
+	}
+
+	var partialImport map[string]interface{}
+	if err := json.Unmarshal(representation, &partialImport); err != nil {
+		return nil, err
+	}
+
+	partialImport["ifResourceExists"] = ifResourceExists
+
+	body, _, err := keycloakClient.post(ctx, fmt.Sprintf("/realms/%s/partialImport", realmId), partialImport)
+	if err != nil {
+		return nil, err
+	}
+
+	var results RealmPartialImportResults
+	err = json.Unmarshal(body, &results)
+	if err != nil {
+		return nil, err
+	}
+
+	return &results, nil
+}
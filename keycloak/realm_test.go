@@ -0,0 +1,40 @@
+package keycloak
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseRealmPasswordPolicy(t *testing.T) {
+	policies := ParseRealmPasswordPolicy("length(12) and  notUsername(undefined) and regexPattern(^[a-z]+(x|y)$) and notEmail")
+
+	expected := []RealmPasswordPolicy{
+		{Id: "length", Value: "12"},
+		{Id: "notUsername", Value: "undefined"},
+		{Id: "regexPattern", Value: "^[a-z]+(x|y)$"},
+		{Id: "notEmail"},
+	}
+	if !reflect.DeepEqual(policies, expected) {
+		t.Errorf("expected %v, got %v", expected, policies)
+	}
+
+	if policies := ParseRealmPasswordPolicy(""); len(policies) != 0 {
+		t.Errorf("expected no policies, got %v", policies)
+	}
+}
+
+func TestFormatRealmPasswordPolicy(t *testing.T) {
+	formatted := FormatRealmPasswordPolicy([]RealmPasswordPolicy{
+		{Id: "upperCase", Value: "1"},
+		{Id: "notUsername"},
+		{Id: "length", Value: "12"},
+	})
+
+	if formatted != "length(12) and notUsername and upperCase(1)" {
+		t.Errorf("expected the policies to be sorted by id, got %s", formatted)
+	}
+
+	if reparsed := FormatRealmPasswordPolicy(ParseRealmPasswordPolicy(formatted)); reparsed != formatted {
+		t.Errorf("expected formatting a parsed policy to give it back, got %s", reparsed)
+	}
+}
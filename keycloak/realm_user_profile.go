@@ -23,14 +23,16 @@ type RealmUserProfileSelector struct {
 type RealmUserProfileValidationConfig map[string]interface{}
 
 type RealmUserProfileAttribute struct {
-	Annotations map[string]interface{}                      `json:"annotations,omitempty"`
-	DisplayName string                                      `json:"displayName,omitempty"`
-	Group       string                                      `json:"group,omitempty"`
-	Name        string                                      `json:"name"`
-	Permissions *RealmUserProfilePermissions                `json:"permissions,omitempty"`
-	Required    *RealmUserProfileRequired                   `json:"required,omitempty"`
-	Selector    *RealmUserProfileSelector                   `json:"selector,omitempty"`
-	Validations map[string]RealmUserProfileValidationConfig `json:"validations,omitempty"`
+	Annotations  map[string]interface{}                      `json:"annotations,omitempty"`
+	DefaultValue string                                      `json:"defaultValue,omitempty"`
+	DisplayName  string                                      `json:"displayName,omitempty"`
+	Group        string                                      `json:"group,omitempty"`
+	Multivalued  bool                                        `json:"multivalued,omitempty"`
+	Name         string                                      `json:"name"`
+	Permissions  *RealmUserProfilePermissions                `json:"permissions,omitempty"`
+	Required     *RealmUserProfileRequired                   `json:"required,omitempty"`
+	Selector     *RealmUserProfileSelector                   `json:"selector,omitempty"`
+	Validations  map[string]RealmUserProfileValidationConfig `json:"validations,omitempty"`
 }
 
 type RealmUserProfileGroup struct {
@@ -43,13 +45,21 @@ type RealmUserProfileGroup struct {
 type RealmUserProfile struct {
 	Attributes []*RealmUserProfileAttribute `json:"attributes"`
 	Groups     []*RealmUserProfileGroup     `json:"groups,omitempty"`
+	// UnmanagedAttributePolicy is one of ENABLED, ADMIN_VIEW or ADMIN_EDIT, attributes that aren't part of the profile
+	// are dropped when it is empty
+	UnmanagedAttributePolicy string `json:"unmanagedAttributePolicy,omitempty"`
 }
 
 func (keycloakClient *KeycloakClient) UpdateRealmUserProfile(ctx context.Context, realmId string, realmUserProfile *RealmUserProfile) error {
+	unlock := keycloakClient.lockRealm(realmId)
+	defer unlock()
+
 	return keycloakClient.put(ctx, fmt.Sprintf("/realms/%s/users/profile", realmId), realmUserProfile)
 }
 
-func (keycloakClient *KeycloakClient) GetRealmUserProfile(ctx context.Context, realmId string) (*RealmUserProfile, error) {
+// getRealmUserProfile returns the user profile as keycloak sends it, unlike GetRealmUserProfile it doesn't encode list
+// validations and object annotations as json strings, so the profile can be sent back unchanged
+func (keycloakClient *KeycloakClient) getRealmUserProfile(ctx context.Context, realmId string) (*RealmUserProfile, error) {
 	var realmUserProfile RealmUserProfile
 	body, err := keycloakClient.getRaw(ctx, fmt.Sprintf("/realms/%s/users/profile", realmId), nil)
 	if err != nil {
@@ -65,6 +75,31 @@ func (keycloakClient *KeycloakClient) GetRealmUserProfile(ctx context.Context, r
 		return nil, err
 	}
 
+	return &realmUserProfile, nil
+}
+
+// updateRealmUserProfile reads the user profile, lets update change it and writes it back while holding the realm's
+// lock, so concurrent updates of different attributes and groups aren't lost
+func (keycloakClient *KeycloakClient) updateRealmUserProfile(ctx context.Context, realmId string, update func(*RealmUserProfile)) error {
+	unlock := keycloakClient.lockRealm(realmId)
+	defer unlock()
+
+	realmUserProfile, err := keycloakClient.getRealmUserProfile(ctx, realmId)
+	if err != nil {
+		return err
+	}
+
+	update(realmUserProfile)
+
+	return keycloakClient.put(ctx, fmt.Sprintf("/realms/%s/users/profile", realmId), realmUserProfile)
+}
+
+func (keycloakClient *KeycloakClient) GetRealmUserProfile(ctx context.Context, realmId string) (*RealmUserProfile, error) {
+	realmUserProfile, err := keycloakClient.getRealmUserProfile(ctx, realmId)
+	if err != nil {
+		return nil, err
+	}
+
 	for _, attr := range realmUserProfile.Attributes {
 		if attr.Validations != nil {
 			for name, config := range attr.Validations {
@@ -110,5 +145,94 @@ func (keycloakClient *KeycloakClient) GetRealmUserProfile(ctx context.Context, r
 			}
 		}
 	}
-	return &realmUserProfile, nil
+	return realmUserProfile, nil
+}
+
+// GetRealmUserProfileAttribute returns the attribute with the given name, or nil when the user profile doesn't have it
+func (keycloakClient *KeycloakClient) GetRealmUserProfileAttribute(ctx context.Context, realmId, name string) (*RealmUserProfileAttribute, error) {
+	realmUserProfile, err := keycloakClient.GetRealmUserProfile(ctx, realmId)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, attribute := range realmUserProfile.Attributes {
+		if attribute.Name == name {
+			return attribute, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// PutRealmUserProfileAttribute replaces the attribute with the same name, or adds it after the other attributes when
+// the user profile doesn't have it yet
+func (keycloakClient *KeycloakClient) PutRealmUserProfileAttribute(ctx context.Context, realmId string, attribute *RealmUserProfileAttribute) error {
+	return keycloakClient.updateRealmUserProfile(ctx, realmId, func(realmUserProfile *RealmUserProfile) {
+		for i, existing := range realmUserProfile.Attributes {
+			if existing.Name == attribute.Name {
+				realmUserProfile.Attributes[i] = attribute
+				return
+			}
+		}
+
+		realmUserProfile.Attributes = append(realmUserProfile.Attributes, attribute)
+	})
+}
+
+func (keycloakClient *KeycloakClient) DeleteRealmUserProfileAttribute(ctx context.Context, realmId, name string) error {
+	return keycloakClient.updateRealmUserProfile(ctx, realmId, func(realmUserProfile *RealmUserProfile) {
+		attributes := make([]*RealmUserProfileAttribute, 0, len(realmUserProfile.Attributes))
+		for _, attribute := range realmUserProfile.Attributes {
+			if attribute.Name != name {
+				attributes = append(attributes, attribute)
+			}
+		}
+
+		realmUserProfile.Attributes = attributes
+	})
+}
+
+// GetRealmUserProfileGroup returns the group with the given name, or nil when the user profile doesn't have it
+func (keycloakClient *KeycloakClient) GetRealmUserProfileGroup(ctx context.Context, realmId, name string) (*RealmUserProfileGroup, error) {
+	realmUserProfile, err := keycloakClient.GetRealmUserProfile(ctx, realmId)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, group := range realmUserProfile.Groups {
+		if group.Name == name {
+			return group, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// PutRealmUserProfileGroup replaces the group with the same name, or adds it after the other groups when the user
+// profile doesn't have it yet
+func (keycloakClient *KeycloakClient) PutRealmUserProfileGroup(ctx context.Context, realmId string, group *RealmUserProfileGroup) error {
+	return keycloakClient.updateRealmUserProfile(ctx, realmId, func(realmUserProfile *RealmUserProfile) {
+		for i, existing := range realmUserProfile.Groups {
+			if existing.Name == group.Name {
+				realmUserProfile.Groups[i] = group
+				return
+			}
+		}
+
+		realmUserProfile.Groups = append(realmUserProfile.Groups, group)
+	})
+}
+
+// DeleteRealmUserProfileGroup removes the group, keycloak refuses this while an attribute still belongs to it
+func (keycloakClient *KeycloakClient) DeleteRealmUserProfileGroup(ctx context.Context, realmId, name string) error {
+	return keycloakClient.updateRealmUserProfile(ctx, realmId, func(realmUserProfile *RealmUserProfile) {
+		groups := make([]*RealmUserProfileGroup, 0, len(realmUserProfile.Groups))
+		for _, group := range realmUserProfile.Groups {
+			if group.Name != name {
+				groups = append(groups, group)
+			}
+		}
+
+		realmUserProfile.Groups = groups
+	})
 }
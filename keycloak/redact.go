@@ -0,0 +1,153 @@
+package keycloak
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+const redactedValue = "**********"
+
+// DefaultRedactedFields are the form fields, json attributes and headers whose values are masked in debug logs and
+// cassettes, compared case insensitively
+var DefaultRedactedFields = []string{
+	// tokens and client credentials
+	"access_token",
+	"assertion",
+	"authorization",
+	"client_assertion",
+	"client_secret",
+	"cookie",
+	"id_token",
+	"refresh_token",
+	"registrationAccessToken",
+	"set-cookie",
+	"subject_token",
+	"token",
+	// secrets within representations, such as client, identity provider, ldap, smtp and keystore settings
+	"authTokenClientSecret",
+	"bindCredential",
+	"clientSecret",
+	"keyPassword",
+	"keystorePassword",
+	"password",
+	"privateKey",
+	"secret",
+}
+
+// redactor masks the values of sensitive fields in the requests and responses that are logged
+type redactor struct {
+	fields map[string]bool
+}
+
+var defaultRedactor = newRedactor(nil)
+
+func newRedactor(additionalFields []string) *redactor {
+	fields := map[string]bool{}
+	for _, field := range append(append([]string{}, DefaultRedactedFields...), additionalFields...) {
+		fields[strings.ToLower(field)] = true
+	}
+
+	return &redactor{fields: fields}
+}
+
+func (redactor *redactor) isSensitive(field string) bool {
+	return redactor.fields[strings.ToLower(field)]
+}
+
+// form encodes form data with the values of sensitive fields masked
+func (redactor *redactor) form(values url.Values) string {
+	redacted := url.Values{}
+	for key, keyValues := range values {
+		for _, value := range keyValues {
+			if redactor.isSensitive(key) && value != "" {
+				value = redactedValue
+			}
+			redacted.Add(key, value)
+		}
+	}
+
+	return redacted.Encode()
+}
+
+// headers returns the headers of a request or response as a map, with the values of sensitive headers masked
+func (redactor *redactor) headers(headers http.Header) map[string]string {
+	redacted := map[string]string{}
+	for header := range headers {
+		value := headers.Get(header)
+		if redactor.isSensitive(header) && value != "" {
+			value = redactedValue
+		}
+		redacted[header] = value
+	}
+
+	return redacted
+}
+
+// body masks the values of sensitive attributes in a json body. Other bodies are returned as they are.
+func (redactor *redactor) body(body []byte) string {
+	if len(body) == 0 {
+		return ""
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return string(body)
+	}
+
+	redacted, err := json.Marshal(redactor.value(value))
+	if err != nil {
+		return string(body)
+	}
+
+	return string(redacted)
+}
+
+func (redactor *redactor) value(value interface{}) interface{} {
+	switch value := value.(type) {
+	case map[string]interface{}:
+		// credentials are represented as {"type": "password", "value": "..."}, client secrets as {"type": "secret", ...}
+		if value["type"] == "password" || value["type"] == "secret" {
+			if _, ok := value["value"]; ok {
+				value["value"] = mask(value["value"])
+			}
+		}
+
+		for key, attribute := range value {
+			if redactor.isSensitive(key) {
+				value[key] = mask(attribute)
+			} else {
+				value[key] = redactor.value(attribute)
+			}
+		}
+	case []interface{}:
+		for i := range value {
+			value[i] = redactor.value(value[i])
+		}
+	}
+
+	return value
+}
+
+// mask keeps the shape of a secret, so component config values remain lists
+func mask(value interface{}) interface{} {
+	switch value := value.(type) {
+	case string:
+		if value == "" {
+			return value
+		}
+		return redactedValue
+	case []interface{}:
+		for i := range value {
+			value[i] = mask(value[i])
+		}
+		return value
+	default:
+		return value
+	}
+}
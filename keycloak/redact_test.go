@@ -0,0 +1,119 @@
+package keycloak
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+)
+
+func TestRedactorMasksJsonBodies(t *testing.T) {
+	redactor := newRedactor([]string{"apiKey"})
+
+	body := redactor.body([]byte(`{
+		"clientId": "backend",
+		"secret": "client-secret",
+		"smtpServer": {"host": "smtp.example.com", "password": "smtp-password"},
+		"config": {"bindCredential": ["bind-password"], "connectionUrl": ["ldap://openldap"], "ApiKey": ["custom"]},
+		"credentials": [{"type": "password", "value": "user-password", "temporary": false}],
+		"emptySecret": {"secret": ""}
+	}`))
+
+	for _, secret := range []string{"client-secret", "smtp-password", "bind-password", "user-password", "custom"} {
+		if strings.Contains(body, secret) {
+			t.Errorf("expected %s to be masked, got %s", secret, body)
+		}
+	}
+	for _, value := range []string{"backend", "smtp.example.com", `"bindCredential":["**********"]`, "ldap://openldap", `"temporary":false`, `"secret":""`} {
+		if !strings.Contains(body, value) {
+			t.Errorf("expected %s to be kept, got %s", value, body)
+		}
+	}
+
+	if body := redactor.body([]byte("not json")); body != "not json" {
+		t.Errorf("expected other bodies to be kept, got %s", body)
+	}
+}
+
+func TestRedactorMasksSmtpTokenClientSecret(t *testing.T) {
+	body := defaultRedactor.body([]byte(`{
+		"realm": "test",
+		"smtpServer": {"host": "smtp.example.com", "authType": "token", "authTokenClientId": "mailer", "authTokenClientSecret": "xoauth2-secret"}
+	}`))
+
+	if strings.Contains(body, "xoauth2-secret") {
+		t.Errorf("expected the token client secret to be masked, got %s", body)
+	}
+	if !strings.Contains(body, `"authTokenClientId":"mailer"`) {
+		t.Errorf("expected the token client id to be kept, got %s", body)
+	}
+}
+
+func TestRedactorMasksFormDataAndHeaders(t *testing.T) {
+	redactor := newRedactor([]string{"X-Api-Key"})
+
+	form := redactor.form(url.Values{
+		"grant_type":    {"password"},
+		"client_id":     {"terraform"},
+		"client_secret": {"client-secret"},
+		"username":      {"admin"},
+		"password":      {"admin-password"},
+	})
+	if strings.Contains(form, "client-secret") || strings.Contains(form, "admin-password") || !strings.Contains(form, "username=admin") {
+		t.Errorf("unexpected form %s", form)
+	}
+
+	headers := redactor.headers(http.Header{
+		"Authorization": {"Bearer token"},
+		"X-Api-Key":     {"api-key"},
+		"Accept":        {"application/json"},
+	})
+	if headers["Authorization"] != redactedValue || headers["X-Api-Key"] != redactedValue || headers["Accept"] != "application/json" {
+		t.Errorf("unexpected headers %v", headers)
+	}
+}
+
+func TestKeycloakClientDebugLogsAreRedacted(t *testing.T) {
+	var output bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &output)
+
+	_, server := newFakeKeycloakClient(t)
+
+	keycloakClient, err := NewKeycloakClient(ctx, KeycloakClientConfig{
+		Url:                      server.URL,
+		ClientId:                 "terraform",
+		ClientSecret:             "client-secret",
+		Realm:                    "master",
+		InitialLogin:             true,
+		ClientTimeout:            5,
+		AdditionalHeaders:        map[string]string{"X-Api-Key": "api-key"},
+		AdditionalRedactedFields: []string{"x-api-key"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := keycloakClient.NewRealm(ctx, &Realm{Realm: "test", Enabled: true, SmtpServer: SmtpServer{Host: "smtp.example.com", Password: "smtp-password"}}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := keycloakClient.GetRealm(ctx, "test"); err != nil {
+		t.Fatal(err)
+	}
+
+	logs := output.String()
+	accessToken := keycloakClient.clientCredentials.AccessToken
+	refreshToken := keycloakClient.clientCredentials.RefreshToken
+
+	for _, secret := range []string{"client-secret", "smtp-password", "api-key", accessToken, refreshToken} {
+		if strings.Contains(logs, secret) {
+			t.Errorf("expected %s to be masked in the debug logs, got %s", secret, logs)
+		}
+	}
+	if !strings.Contains(logs, "smtp.example.com") || !strings.Contains(logs, "client_id=terraform") {
+		t.Errorf("expected the requests to be logged, got %s", logs)
+	}
+}
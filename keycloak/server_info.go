@@ -18,16 +18,64 @@ type ProviderType struct {
 type Provider struct {
 }
 
+type ProtocolMapperType struct {
+	Id         string           `json:"id"`
+	Name       string           `json:"name"`
+	Category   string           `json:"category"`
+	HelpText   string           `json:"helpText"`
+	Priority   int              `json:"priority"`
+	Properties []ConfigProperty `json:"properties"`
+}
+
+type ConfigProperty struct {
+	Name         string      `json:"name"`
+	Label        string      `json:"label"`
+	HelpText     string      `json:"helpText"`
+	Type         string      `json:"type"`
+	DefaultValue interface{} `json:"defaultValue"`
+	Options      []string    `json:"options"`
+	Secret       bool        `json:"secret"`
+	Required     bool        `json:"required"`
+	ReadOnly     bool        `json:"readOnly"`
+}
+
+type PasswordPolicyType struct {
+	Id                string `json:"id"`
+	DisplayName       string `json:"displayName"`
+	ConfigType        string `json:"configType"`
+	DefaultValue      string `json:"defaultValue"`
+	MultipleSupported bool   `json:"multipleSupported"`
+}
+
 type Theme struct {
 	Name    string   `json:"name"`
 	Locales []string `json:"locales,omitempty"`
 }
 
+type ProfileInfo struct {
+	Name                 string   `json:"name"`
+	DisabledFeatures     []string `json:"disabledFeatures"`
+	PreviewFeatures      []string `json:"previewFeatures"`
+	ExperimentalFeatures []string `json:"experimentalFeatures"`
+}
+
+type FeatureInfo struct {
+	Name    string `json:"name"`
+	Label   string `json:"label"`
+	Type    string `json:"type"`
+	Enabled bool   `json:"enabled"`
+}
+
 type ServerInfo struct {
 	SystemInfo     SystemInfo                 `json:"systemInfo"`
+	ProfileInfo    ProfileInfo                `json:"profileInfo"`
+	Features       []FeatureInfo              `json:"features"`
 	ComponentTypes map[string][]ComponentType `json:"componentTypes"`
 	ProviderTypes  map[string]ProviderType    `json:"providers"`
 	Themes         map[string][]Theme         `json:"themes"`
+
+	ProtocolMapperTypes map[string][]ProtocolMapperType `json:"protocolMapperTypes"`
+	PasswordPolicies    []PasswordPolicyType            `json:"passwordPolicies"`
 }
 
 func (serverInfo *ServerInfo) ThemeIsInstalled(t, themeName string) bool {
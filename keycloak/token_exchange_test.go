@@ -0,0 +1,135 @@
+package keycloak
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestKeycloakClientTokenExchangeRereadsSubjectTokenFile(t *testing.T) {
+	var tokenRequests []*http.Request
+	var authorizationHeaders []string
+
+	server := newTestKeycloakServer(t, &tokenRequests, &authorizationHeaders)
+	server.Start()
+	defer server.Close()
+
+	subjectTokenFile := filepath.Join(t.TempDir(), "token")
+	if err := os.WriteFile(subjectTokenFile, []byte("first-token\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	keycloakClient, err := NewKeycloakClient(ctx, KeycloakClientConfig{
+		Url:               server.URL,
+		ClientId:          "terraform",
+		Realm:             "master",
+		SubjectTokenGrant: SubjectTokenGrantTokenExchange,
+		SubjectTokenFile:  subjectTokenFile,
+		SubjectIssuer:     "github",
+		InitialLogin:      true,
+		ClientTimeout:     5,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the token is rotated on disk before it expires
+	if err := os.WriteFile(subjectTokenFile, []byte("second-token"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := keycloakClient.refresh(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(tokenRequests) != 2 {
+		t.Fatalf("expected a login and a refresh token request, got %d", len(tokenRequests))
+	}
+
+	for i, expectedSubjectToken := range []string{"first-token", "second-token"} {
+		form := tokenRequests[i].PostForm
+
+		if form.Get("grant_type") != grantTypeTokenExchange || form.Get("client_id") != "terraform" {
+			t.Errorf("unexpected token request %v", form)
+		}
+		if form.Get("subject_token") != expectedSubjectToken {
+			t.Errorf("expected subject token %s, got %s", expectedSubjectToken, form.Get("subject_token"))
+		}
+		if form.Get("subject_token_type") != DefaultSubjectTokenType || form.Get("subject_issuer") != "github" {
+			t.Errorf("unexpected token request %v", form)
+		}
+		if form.Has("client_secret") {
+			t.Errorf("expected no client secret to be sent, got %v", form)
+		}
+	}
+
+	if keycloakClient.clientCredentials.AccessToken != "token-2" {
+		t.Errorf("expected the refreshed access token to be used, got %s", keycloakClient.clientCredentials.AccessToken)
+	}
+}
+
+func TestKeycloakClientJwtBearerGrant(t *testing.T) {
+	var tokenRequests []*http.Request
+	var authorizationHeaders []string
+
+	server := newTestKeycloakServer(t, &tokenRequests, &authorizationHeaders)
+	server.Start()
+	defer server.Close()
+
+	ctx := context.Background()
+	_, err := NewKeycloakClient(ctx, KeycloakClientConfig{
+		Url:               server.URL,
+		ClientId:          "terraform",
+		ClientSecret:      "secret",
+		Realm:             "master",
+		SubjectTokenGrant: SubjectTokenGrantJwtBearer,
+		SubjectToken:      "workload-token",
+		InitialLogin:      true,
+		ClientTimeout:     5,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(tokenRequests) != 1 {
+		t.Fatalf("expected one token request, got %d", len(tokenRequests))
+	}
+
+	form := tokenRequests[0].PostForm
+	if form.Get("grant_type") != grantTypeJwtBearer || form.Get("assertion") != "workload-token" || form.Get("client_secret") != "secret" {
+		t.Errorf("unexpected token request %v", form)
+	}
+	if form.Has("subject_token") {
+		t.Errorf("expected no subject token for the jwt-bearer grant, got %v", form)
+	}
+	if len(authorizationHeaders) != 1 || authorizationHeaders[0] != "Bearer token-1" {
+		t.Errorf("expected the exchanged access token to be used, got %v", authorizationHeaders)
+	}
+}
+
+func TestKeycloakClientMissingSubjectTokenFile(t *testing.T) {
+	var tokenRequests []*http.Request
+	var authorizationHeaders []string
+
+	server := newTestKeycloakServer(t, &tokenRequests, &authorizationHeaders)
+	server.Start()
+	defer server.Close()
+
+	_, err := NewKeycloakClient(context.Background(), KeycloakClientConfig{
+		Url:              server.URL,
+		ClientId:         "terraform",
+		Realm:            "master",
+		SubjectTokenFile: filepath.Join(t.TempDir(), "missing"),
+		InitialLogin:     true,
+		ClientTimeout:    5,
+	})
+	if err == nil {
+		t.Fatal("expected an error when the subject token file does not exist")
+	}
+	if len(tokenRequests) != 0 {
+		t.Errorf("expected no token requests, got %d", len(tokenRequests))
+	}
+}
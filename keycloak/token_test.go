@@ -0,0 +1,307 @@
+package keycloak
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+// tokenTestServer issues access tokens and only accepts the most recently issued one, so it can tell how often the
+// client logged in and how many requests were rejected. When refreshExpiresIn is set, it issues refresh tokens as well.
+type tokenTestServer struct {
+	*httptest.Server
+
+	mutex              sync.Mutex
+	expiresIn          int
+	refreshExpiresIn   int
+	validToken         string
+	validRefreshToken  string
+	tokenRequests      int
+	refreshRequests    int
+	serverInfoRequests int
+	rejectedRequests   int
+}
+
+func newTokenTestServer(expiresIn int) *tokenTestServer {
+	server := &tokenTestServer{expiresIn: expiresIn}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/realms/master/protocol/openid-connect/token", func(w http.ResponseWriter, r *http.Request) {
+		server.mutex.Lock()
+		defer server.mutex.Unlock()
+
+		server.tokenRequests++
+		if r.PostFormValue("grant_type") == "refresh_token" {
+			server.refreshRequests++
+			if r.PostFormValue("refresh_token") != server.validRefreshToken {
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusBadRequest)
+				fmt.Fprint(w, `{"error":"invalid_grant","error_description":"Session not active"}`)
+				return
+			}
+		}
+		server.validToken = fmt.Sprintf("token-%d", server.tokenRequests)
+
+		w.Header().Set("Content-Type", "application/json")
+		if server.refreshExpiresIn == 0 {
+			fmt.Fprintf(w, `{"access_token":"%s","expires_in":%d,"token_type":"Bearer"}`, server.validToken, server.expiresIn)
+			return
+		}
+
+		server.validRefreshToken = fmt.Sprintf("refresh-token-%d", server.tokenRequests)
+		fmt.Fprintf(w, `{"access_token":"%s","expires_in":%d,"refresh_token":"%s","refresh_expires_in":%d,"token_type":"Bearer"}`, server.validToken, server.expiresIn, server.validRefreshToken, server.refreshExpiresIn)
+	})
+	mux.HandleFunc("/admin/", func(w http.ResponseWriter, r *http.Request) {
+		server.mutex.Lock()
+		defer server.mutex.Unlock()
+
+		if r.Header.Get("Authorization") != "Bearer "+server.validToken {
+			server.rejectedRequests++
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/admin/serverinfo" {
+			server.serverInfoRequests++
+			fmt.Fprint(w, `{"systemInfo":{"version":"26.0.0"}}`)
+			return
+		}
+
+		fmt.Fprint(w, `{"id":"test","realm":"test"}`)
+	})
+
+	server.Server = httptest.NewServer(mux)
+
+	return server
+}
+
+// revoke invalidates the current access token, as if it had expired or the session had been logged out
+func (server *tokenTestServer) revoke() {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+
+	server.validToken = ""
+}
+
+// endSession invalidates the current access and refresh tokens, as if the session had been logged out
+func (server *tokenTestServer) endSession() {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+
+	server.validToken = ""
+	server.validRefreshToken = ""
+}
+
+func (server *tokenTestServer) refreshCount() int {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+
+	return server.refreshRequests
+}
+
+func (server *tokenTestServer) counts() (int, int, int) {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+
+	return server.tokenRequests, server.serverInfoRequests, server.rejectedRequests
+}
+
+func newTokenTestClient(t *testing.T, server *tokenTestServer, initialLogin bool) *KeycloakClient {
+	keycloakClient, err := NewKeycloakClient(context.Background(), KeycloakClientConfig{
+		Url:           server.URL,
+		ClientId:      "terraform",
+		ClientSecret:  "secret",
+		Realm:         "master",
+		InitialLogin:  initialLogin,
+		ClientTimeout: 5,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return keycloakClient
+}
+
+// newTokenTestUserClient logs in with the password grant, which keycloak issues refresh tokens for
+func newTokenTestUserClient(t *testing.T, server *tokenTestServer) *KeycloakClient {
+	keycloakClient, err := NewKeycloakClient(context.Background(), KeycloakClientConfig{
+		Url:           server.URL,
+		ClientId:      "admin-cli",
+		Realm:         "master",
+		Username:      "admin",
+		Password:      "password",
+		InitialLogin:  true,
+		ClientTimeout: 5,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return keycloakClient
+}
+
+func getRealmConcurrently(t *testing.T, keycloakClient *KeycloakClient, count int) {
+	var wg sync.WaitGroup
+	for i := 0; i < count; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			if _, err := keycloakClient.GetRealm(context.Background(), "test"); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestKeycloakClientConcurrentRequestsRefreshOnce(t *testing.T) {
+	server := newTokenTestServer(300)
+	defer server.Close()
+
+	keycloakClient := newTokenTestClient(t, server, true)
+
+	server.revoke()
+	getRealmConcurrently(t, keycloakClient, 20)
+
+	tokenRequests, _, rejectedRequests := server.counts()
+	if tokenRequests != 2 {
+		t.Errorf("expected the rejected token to be refreshed once, got %d token requests", tokenRequests)
+	}
+	if rejectedRequests == 0 {
+		t.Errorf("expected requests with the revoked token to be rejected")
+	}
+}
+
+func TestKeycloakClientConcurrentRequestsLogInOnce(t *testing.T) {
+	server := newTokenTestServer(300)
+	defer server.Close()
+
+	keycloakClient := newTokenTestClient(t, server, false)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			if _, err := keycloakClient.VersionIsGreaterThanOrEqualTo(context.Background(), Version_26); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	getRealmConcurrently(t, keycloakClient, 10)
+
+	tokenRequests, serverInfoRequests, rejectedRequests := server.counts()
+	if tokenRequests != 1 || serverInfoRequests != 1 || rejectedRequests != 0 {
+		t.Errorf("expected a single login and version lookup, got %d token requests, %d server info requests and %d rejected requests", tokenRequests, serverInfoRequests, rejectedRequests)
+	}
+}
+
+func TestKeycloakClientRefreshesBeforeExpiry(t *testing.T) {
+	// the token is refreshed halfway through its lifespan, since that is shorter than the refresh skew
+	server := newTokenTestServer(2)
+	defer server.Close()
+
+	keycloakClient := newTokenTestClient(t, server, true)
+
+	getRealmConcurrently(t, keycloakClient, 5)
+	if tokenRequests, _, _ := server.counts(); tokenRequests != 1 {
+		t.Fatalf("expected the access token to be used until it is about to expire, got %d token requests", tokenRequests)
+	}
+
+	time.Sleep(time.Millisecond * 1100)
+
+	getRealmConcurrently(t, keycloakClient, 10)
+
+	tokenRequests, _, rejectedRequests := server.counts()
+	if tokenRequests != 2 {
+		t.Errorf("expected the access token to be refreshed once before it expired, got %d token requests", tokenRequests)
+	}
+	if rejectedRequests != 0 {
+		t.Errorf("expected no requests to be rejected, got %d", rejectedRequests)
+	}
+}
+
+func TestKeycloakClientRefreshesWithRefreshToken(t *testing.T) {
+	server := newTokenTestServer(300)
+	server.refreshExpiresIn = 1800
+	defer server.Close()
+
+	keycloakClient := newTokenTestUserClient(t, server)
+
+	server.revoke()
+	getRealmConcurrently(t, keycloakClient, 5)
+
+	if tokenRequests, _, _ := server.counts(); tokenRequests != 2 {
+		t.Errorf("expected the rejected token to be refreshed once, got %d token requests", tokenRequests)
+	}
+	if refreshRequests := server.refreshCount(); refreshRequests != 1 {
+		t.Errorf("expected the refresh token to be used, got %d refresh requests", refreshRequests)
+	}
+}
+
+func TestKeycloakClientLogsInAgainWhenRefreshTokenExpired(t *testing.T) {
+	server := newTokenTestServer(300)
+	server.refreshExpiresIn = 1800
+	defer server.Close()
+
+	keycloakClient := newTokenTestUserClient(t, server)
+
+	keycloakClient.tokenMutex.Lock()
+	keycloakClient.refreshTokenExpiresAt = time.Now().Add(-time.Second)
+	keycloakClient.tokenMutex.Unlock()
+
+	server.revoke()
+	getRealmConcurrently(t, keycloakClient, 5)
+
+	if tokenRequests, _, _ := server.counts(); tokenRequests != 2 {
+		t.Errorf("expected a single login, got %d token requests", tokenRequests)
+	}
+	if refreshRequests := server.refreshCount(); refreshRequests != 0 {
+		t.Errorf("expected the expired refresh token not to be used, got %d refresh requests", refreshRequests)
+	}
+}
+
+func TestKeycloakClientLogsInAgainWhenRefreshTokenRejected(t *testing.T) {
+	server := newTokenTestServer(300)
+	server.refreshExpiresIn = 1800
+	defer server.Close()
+
+	keycloakClient := newTokenTestUserClient(t, server)
+
+	server.endSession()
+	getRealmConcurrently(t, keycloakClient, 5)
+
+	if tokenRequests, _, rejectedRequests := server.counts(); tokenRequests != 3 || rejectedRequests == 0 {
+		t.Errorf("expected a refresh and a login after the session ended, got %d token requests and %d rejected requests", tokenRequests, rejectedRequests)
+	}
+	if refreshRequests := server.refreshCount(); refreshRequests != 1 {
+		t.Errorf("expected the refresh token to be tried once, got %d refresh requests", refreshRequests)
+	}
+}
+
+func TestKeycloakClientClientCredentialsIgnoreRefreshToken(t *testing.T) {
+	server := newTokenTestServer(300)
+	server.refreshExpiresIn = 1800
+	defer server.Close()
+
+	keycloakClient := newTokenTestClient(t, server, true)
+
+	server.revoke()
+	getRealmConcurrently(t, keycloakClient, 5)
+
+	if tokenRequests, _, _ := server.counts(); tokenRequests != 2 {
+		t.Errorf("expected a single login, got %d token requests", tokenRequests)
+	}
+	if refreshRequests := server.refreshCount(); refreshRequests != 0 {
+		t.Errorf("expected the client to log in again instead of using the refresh token, got %d refresh requests", refreshRequests)
+	}
+}
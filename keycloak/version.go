@@ -22,14 +22,21 @@ const (
 	Version_17 Version = "17.0.0"
 	Version_18 Version = "18.0.0"
 	Version_19 Version = "19.0.0"
+	Version_20 Version = "20.0.0"
+	Version_21 Version = "21.0.0"
+	Version_22 Version = "22.0.0"
+	Version_23 Version = "23.0.0"
+	Version_24 Version = "24.0.0"
+	Version_25 Version = "25.0.0"
+	Version_26 Version = "26.0.0"
+
+	Version_26_1 Version = "26.1.0"
 )
 
 func (keycloakClient *KeycloakClient) VersionIsGreaterThanOrEqualTo(ctx context.Context, versionString Version) (bool, error) {
-	if keycloakClient.version == nil {
-		err := keycloakClient.login(ctx)
-		if err != nil {
-			return false, err
-		}
+	serverVersion, err := keycloakClient.getVersion(ctx)
+	if err != nil {
+		return false, err
 	}
 
 	v, err := version.NewVersion(string(versionString))
@@ -37,15 +44,13 @@ func (keycloakClient *KeycloakClient) VersionIsGreaterThanOrEqualTo(ctx context.
 		return false, nil
 	}
 
-	return keycloakClient.version.GreaterThanOrEqual(v), nil
+	return serverVersion.GreaterThanOrEqual(v), nil
 }
 
 func (keycloakClient *KeycloakClient) VersionIsLessThanOrEqualTo(ctx context.Context, versionString Version) (bool, error) {
-	if keycloakClient.version == nil {
-		err := keycloakClient.login(ctx)
-		if err != nil {
-			return false, err
-		}
+	serverVersion, err := keycloakClient.getVersion(ctx)
+	if err != nil {
+		return false, err
 	}
 
 	v, err := version.NewVersion(string(versionString))
@@ -53,5 +58,5 @@ func (keycloakClient *KeycloakClient) VersionIsLessThanOrEqualTo(ctx context.Con
 		return false, nil
 	}
 
-	return keycloakClient.version.LessThanOrEqual(v), nil
+	return serverVersion.LessThanOrEqual(v), nil
 }
@@ -1,12 +1,27 @@
 package main
 
 import (
+	"context"
+	"fmt"
+	"os"
+
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/plugin"
+	"github.com/keycloak/terraform-provider-keycloak/generator"
 	"github.com/keycloak/terraform-provider-keycloak/provider"
 )
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "generate" {
+		err := generator.Run(context.Background(), provider.KeycloakProvider(nil), os.Args[2:], os.Stdout, os.Stderr)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+
+		return
+	}
+
 	plugin.Serve(&plugin.ServeOpts{
 		ProviderFunc: func() *schema.Provider {
 			return provider.KeycloakProvider(nil)
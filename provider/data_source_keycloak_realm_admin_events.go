@@ -0,0 +1,160 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/keycloak/terraform-provider-keycloak/keycloak"
+)
+
+var keycloakRealmAdminEventsOperationTypes = []string{"CREATE", "UPDATE", "DELETE", "ACTION"}
+
+func dataSourceKeycloakRealmAdminEvents() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceKeycloakRealmAdminEventsRead,
+		Schema: realmEventsQuerySchema(map[string]*schema.Schema{
+			"operation_types": {
+				Type: schema.TypeSet,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringInSlice(keycloakRealmAdminEventsOperationTypes, false),
+				},
+				Optional:    true,
+				Description: "Only return events of these operations.",
+			},
+			"resource_types": {
+				Type:        schema.TypeSet,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Optional:    true,
+				Description: "Only return events about these types of resources, e.g. USER or CLIENT.",
+			},
+			"resource_path": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return events about resources with this path, e.g. users/* for any user.",
+			},
+			"auth_realm_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return events of admins of this realm, by its id.",
+			},
+			"auth_client_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return events of admins that used this client, by its id.",
+			},
+			"auth_user_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return events of this admin, by its id.",
+			},
+			"auth_ip_address": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"events": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"time": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"operation_type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"resource_type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"resource_path": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"representation": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"error": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"auth_realm_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"auth_client_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"auth_user_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"auth_ip_address": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		}),
+	}
+}
+
+func getRealmAdminEventsQueryFromData(data *schema.ResourceData) *keycloak.AdminEventsQuery {
+	return &keycloak.AdminEventsQuery{
+		OperationTypes: interfaceSliceToStringSlice(data.Get("operation_types").(*schema.Set).List()),
+		ResourceTypes:  interfaceSliceToStringSlice(data.Get("resource_types").(*schema.Set).List()),
+		ResourcePath:   data.Get("resource_path").(string),
+		AuthRealmId:    data.Get("auth_realm_id").(string),
+		AuthClientId:   data.Get("auth_client_id").(string),
+		AuthUserId:     data.Get("auth_user_id").(string),
+		AuthIpAddress:  data.Get("auth_ip_address").(string),
+		DateFrom:       data.Get("date_from").(string),
+		DateTo:         data.Get("date_to").(string),
+		First:          data.Get("first").(int),
+		Max:            data.Get("max").(int),
+	}
+}
+
+func setRealmAdminEventsData(data *schema.ResourceData, realmId string, events []*keycloak.AdminEvent) {
+	data.SetId(realmId)
+
+	var eventsData []interface{}
+	for _, event := range events {
+		eventsData = append(eventsData, map[string]interface{}{
+			"time":            int(event.Time),
+			"operation_type":  event.OperationType,
+			"resource_type":   event.ResourceType,
+			"resource_path":   event.ResourcePath,
+			"representation":  event.Representation,
+			"error":           event.Error,
+			"auth_realm_id":   event.AuthDetails.RealmId,
+			"auth_client_id":  event.AuthDetails.ClientId,
+			"auth_user_id":    event.AuthDetails.UserId,
+			"auth_ip_address": event.AuthDetails.IpAddress,
+		})
+	}
+
+	data.Set("events", eventsData)
+}
+
+func dataSourceKeycloakRealmAdminEventsRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	realmId := data.Get("realm_id").(string)
+
+	events, err := keycloakClient.GetRealmAdminEvents(ctx, realmId, getRealmAdminEventsQueryFromData(data))
+	if err != nil {
+		return diagnosticsFromError(err, data)
+	}
+
+	setRealmAdminEventsData(data, realmId, events)
+
+	return nil
+}
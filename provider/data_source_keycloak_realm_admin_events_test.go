@@ -0,0 +1,91 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccKeycloakDataSourceRealmAdminEvents_basic(t *testing.T) {
+	t.Parallel()
+
+	realmName := acctest.RandomWithPrefix("tf-acc")
+	username := acctest.RandomWithPrefix("tf-acc")
+	dataSourceName := "data.keycloak_realm_admin_events.admin_events"
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		PreCheck:          func() { testAccPreCheck(t) },
+		CheckDestroy:      testAccCheckKeycloakRealmDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKeycloakDataSourceRealmAdminEvents_user(realmName, username),
+			},
+			// the data source is added once the user exists, so its creation has been recorded
+			{
+				Config: testAccKeycloakDataSourceRealmAdminEvents_basic(realmName, username),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "events.#", "1"),
+					resource.TestCheckResourceAttr(dataSourceName, "events.0.operation_type", "CREATE"),
+					resource.TestCheckResourceAttr(dataSourceName, "events.0.resource_type", "USER"),
+					resource.TestMatchResourceAttr(dataSourceName, "events.0.resource_path", regexp.MustCompile("^users/")),
+				),
+			},
+		},
+	})
+}
+
+func TestAccKeycloakDataSourceRealmAdminEvents_invalidOperationType(t *testing.T) {
+	t.Parallel()
+
+	realmName := acctest.RandomWithPrefix("tf-acc")
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		PreCheck:          func() { testAccPreCheck(t) },
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+data "keycloak_realm_admin_events" "admin_events" {
+	realm_id        = "%s"
+	operation_types = ["READ"]
+}
+				`, realmName),
+				ExpectError: regexp.MustCompile("expected operation_types.* to be one of"),
+			},
+		},
+	})
+}
+
+func testAccKeycloakDataSourceRealmAdminEvents_user(realm, username string) string {
+	return fmt.Sprintf(`
+resource "keycloak_realm" "realm" {
+	realm = "%s"
+}
+
+resource "keycloak_realm_events" "realm_events" {
+	realm_id             = keycloak_realm.realm.id
+	admin_events_enabled = true
+}
+
+resource "keycloak_user" "user" {
+	realm_id = keycloak_realm_events.realm_events.realm_id
+	username = "%s"
+}
+	`, realm, username)
+}
+
+func testAccKeycloakDataSourceRealmAdminEvents_basic(realm, username string) string {
+	return fmt.Sprintf(`
+%s
+
+data "keycloak_realm_admin_events" "admin_events" {
+	realm_id        = keycloak_user.user.realm_id
+	operation_types = ["CREATE"]
+	resource_types  = ["USER"]
+}
+	`, testAccKeycloakDataSourceRealmAdminEvents_user(realm, username))
+}
@@ -0,0 +1,54 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/keycloak/terraform-provider-keycloak/keycloak"
+	"github.com/keycloak/terraform-provider-keycloak/keycloak/keycloaktest"
+)
+
+func TestRealmAdminEventsDataSourceRead(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	server := keycloaktest.NewServer()
+	t.Cleanup(server.Close)
+
+	keycloakClient := newFakeKeycloakClientForServer(t, server)
+	if err := keycloakClient.NewRealm(ctx, &keycloak.Realm{Realm: "test", Enabled: true}); err != nil {
+		t.Fatal(err)
+	}
+
+	authDetails := map[string]interface{}{"realmId": "master", "clientId": "admin-cli-id", "userId": "admin-id", "ipAddress": "127.0.0.1"}
+	server.AddAdminEvent("test", map[string]interface{}{"time": 1000, "operationType": "CREATE", "resourceType": "USER", "resourcePath": "users/alice", "authDetails": authDetails})
+	server.AddAdminEvent("test", map[string]interface{}{"time": 2000, "operationType": "UPDATE", "resourceType": "USER", "resourcePath": "users/alice", "authDetails": authDetails})
+	server.AddAdminEvent("test", map[string]interface{}{"time": 3000, "operationType": "CREATE", "resourceType": "CLIENT", "resourcePath": "clients/app", "representation": `{"clientId":"app"}`})
+
+	data := schema.TestResourceDataRaw(t, dataSourceKeycloakRealmAdminEvents().Schema, map[string]interface{}{
+		"realm_id":        "test",
+		"operation_types": []interface{}{"CREATE"},
+	})
+	if diags := dataSourceKeycloakRealmAdminEventsRead(ctx, data, keycloakClient); diags.HasError() {
+		t.Fatal(diags)
+	}
+
+	if data.Get("events.#") != 2 || data.Get("events.0.representation") != `{"clientId":"app"}` || data.Get("events.1.auth_user_id") != "admin-id" {
+		t.Errorf("expected the create events newest first, got %v", data.Get("events"))
+	}
+
+	data = schema.TestResourceDataRaw(t, dataSourceKeycloakRealmAdminEvents().Schema, map[string]interface{}{
+		"realm_id":       "test",
+		"resource_types": []interface{}{"USER"},
+		"auth_user_id":   "admin-id",
+	})
+	if diags := dataSourceKeycloakRealmAdminEventsRead(ctx, data, keycloakClient); diags.HasError() {
+		t.Fatal(diags)
+	}
+
+	if data.Get("events.#") != 2 || data.Get("events.0.operation_type") != "UPDATE" || data.Get("events.0.auth_ip_address") != "127.0.0.1" {
+		t.Errorf("expected the user events of the admin, got %v", data.Get("events"))
+	}
+}
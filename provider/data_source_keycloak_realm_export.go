@@ -0,0 +1,86 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/keycloak/terraform-provider-keycloak/keycloak"
+)
+
+func dataSourceKeycloakRealmExport() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceKeycloakRealmExportRead,
+		Schema: map[string]*schema.Schema{
+			"realm_id": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"export_clients": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "When true, the clients of the realm are included in the export.",
+			},
+			"export_groups_and_roles": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "When true, the groups and roles of the realm are included in the export.",
+			},
+			"realm_json": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The realm representation returned by Keycloak. Secrets are masked by Keycloak.",
+			},
+			"client_ids": {
+				Type:     schema.TypeList,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Computed: true,
+			},
+			"role_names": {
+				Type:     schema.TypeList,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Computed: true,
+			},
+			"group_paths": {
+				Type:     schema.TypeList,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceKeycloakRealmExportRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	realmId := data.Get("realm_id").(string)
+	exportClients := data.Get("export_clients").(bool)
+	exportGroupsAndRoles := data.Get("export_groups_and_roles").(bool)
+
+	realmExport, err := keycloakClient.GetRealmExport(ctx, realmId, exportClients, exportGroupsAndRoles)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	var clientIds []string
+	for _, client := range realmExport.Clients {
+		clientIds = append(clientIds, client.ClientId)
+	}
+
+	var roleNames []string
+	if realmExport.Roles != nil {
+		for _, role := range realmExport.Roles.Realm {
+			roleNames = append(roleNames, role.Name)
+		}
+	}
+
+	data.SetId(realmId)
+	data.Set("realm_json", realmExport.Raw)
+	data.Set("client_ids", clientIds)
+	data.Set("role_names", roleNames)
+	data.Set("group_paths", realmExport.GroupPaths())
+
+	return nil
+}
@@ -0,0 +1,109 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccKeycloakDataSourceRealmExport_basic(t *testing.T) {
+	t.Parallel()
+	dataSourceName := "data.keycloak_realm_export.export"
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		PreCheck:          func() { testAccPreCheck(t) },
+		Steps: []resource.TestStep{
+			{
+				Config: testDataSourceKeycloakRealmExport_basic(false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "realm_id", testAccRealm.Realm),
+					resource.TestCheckResourceAttrSet(dataSourceName, "realm_json"),
+					resource.TestCheckResourceAttr(dataSourceName, "client_ids.#", "0"),
+					resource.TestCheckResourceAttr(dataSourceName, "group_paths.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccKeycloakDataSourceRealmExport_clientsGroupsAndRoles(t *testing.T) {
+	t.Parallel()
+	dataSourceName := "data.keycloak_realm_export.export"
+	clientId := acctest.RandomWithPrefix("tf-acc")
+	groupName := acctest.RandomWithPrefix("tf-acc")
+	roleName := acctest.RandomWithPrefix("tf-acc")
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		PreCheck:          func() { testAccPreCheck(t) },
+		Steps: []resource.TestStep{
+			{
+				Config: testDataSourceKeycloakRealmExport_clientsGroupsAndRoles(clientId, groupName, roleName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckTypeSetElemAttr(dataSourceName, "client_ids.*", clientId),
+					resource.TestCheckTypeSetElemAttr(dataSourceName, "group_paths.*", "/"+groupName),
+					resource.TestCheckTypeSetElemAttr(dataSourceName, "group_paths.*", "/"+groupName+"/child"),
+					resource.TestCheckTypeSetElemAttr(dataSourceName, "role_names.*", roleName),
+				),
+			},
+		},
+	})
+}
+
+func testDataSourceKeycloakRealmExport_basic(exportClients bool) string {
+	return fmt.Sprintf(`
+data "keycloak_realm" "realm" {
+	realm = "%s"
+}
+
+data "keycloak_realm_export" "export" {
+	realm_id       = data.keycloak_realm.realm.id
+	export_clients = %t
+}
+	`, testAccRealm.Realm, exportClients)
+}
+
+func testDataSourceKeycloakRealmExport_clientsGroupsAndRoles(clientId, groupName, roleName string) string {
+	return fmt.Sprintf(`
+data "keycloak_realm" "realm" {
+	realm = "%s"
+}
+
+resource "keycloak_openid_client" "client" {
+	realm_id    = data.keycloak_realm.realm.id
+	client_id   = "%s"
+	access_type = "CONFIDENTIAL"
+}
+
+resource "keycloak_group" "group" {
+	realm_id = data.keycloak_realm.realm.id
+	name     = "%s"
+}
+
+resource "keycloak_group" "child" {
+	realm_id  = data.keycloak_realm.realm.id
+	parent_id = keycloak_group.group.id
+	name      = "child"
+}
+
+resource "keycloak_role" "role" {
+	realm_id = data.keycloak_realm.realm.id
+	name     = "%s"
+}
+
+data "keycloak_realm_export" "export" {
+	realm_id                = data.keycloak_realm.realm.id
+	export_clients          = true
+	export_groups_and_roles = true
+
+	depends_on = [
+		keycloak_openid_client.client,
+		keycloak_group.child,
+		keycloak_role.role,
+	]
+}
+	`, testAccRealm.Realm, clientId, groupName, roleName)
+}
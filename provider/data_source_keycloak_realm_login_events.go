@@ -0,0 +1,166 @@
+package provider
+
+import (
+	"context"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/keycloak/terraform-provider-keycloak/keycloak"
+)
+
+var keycloakRealmEventsDatePattern = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}$`)
+
+// realmEventsQuerySchema adds the date range and paging arguments that both event data sources share
+func realmEventsQuerySchema(schemaMap map[string]*schema.Schema) map[string]*schema.Schema {
+	schemaMap["realm_id"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Required: true,
+	}
+	schemaMap["date_from"] = &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		ValidateFunc: validation.StringMatch(keycloakRealmEventsDatePattern, "expected a date formatted as yyyy-MM-dd"),
+		Description:  "Only return events from this date on, formatted as yyyy-MM-dd.",
+	}
+	schemaMap["date_to"] = &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		ValidateFunc: validation.StringMatch(keycloakRealmEventsDatePattern, "expected a date formatted as yyyy-MM-dd"),
+		Description:  "Only return events up to and including this date, formatted as yyyy-MM-dd.",
+	}
+	schemaMap["first"] = &schema.Schema{
+		Type:         schema.TypeInt,
+		Optional:     true,
+		Default:      0,
+		ValidateFunc: validation.IntAtLeast(0),
+		Description:  "The number of matching events to skip, newest first.",
+	}
+	schemaMap["max"] = &schema.Schema{
+		Type:         schema.TypeInt,
+		Optional:     true,
+		Default:      100,
+		ValidateFunc: validation.IntAtLeast(1),
+		Description:  "The maximum number of events to return.",
+	}
+
+	return schemaMap
+}
+
+func dataSourceKeycloakRealmLoginEvents() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceKeycloakRealmLoginEventsRead,
+		Schema: realmEventsQuerySchema(map[string]*schema.Schema{
+			"types": {
+				Type:        schema.TypeSet,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Optional:    true,
+				Description: "Only return events of these types, e.g. LOGIN or LOGIN_ERROR.",
+			},
+			"client_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return events of this client, by its client id.",
+			},
+			"user_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return events of this user, by its id.",
+			},
+			"ip_address": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"events": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"time": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"client_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"user_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"session_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"ip_address": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"error": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"details": {
+							Type:     schema.TypeMap,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+							Computed: true,
+						},
+					},
+				},
+			},
+		}),
+	}
+}
+
+func getRealmEventsQueryFromData(data *schema.ResourceData) *keycloak.RealmEventsQuery {
+	return &keycloak.RealmEventsQuery{
+		Types:     interfaceSliceToStringSlice(data.Get("types").(*schema.Set).List()),
+		ClientId:  data.Get("client_id").(string),
+		UserId:    data.Get("user_id").(string),
+		IpAddress: data.Get("ip_address").(string),
+		DateFrom:  data.Get("date_from").(string),
+		DateTo:    data.Get("date_to").(string),
+		First:     data.Get("first").(int),
+		Max:       data.Get("max").(int),
+	}
+}
+
+func setRealmLoginEventsData(data *schema.ResourceData, realmId string, events []*keycloak.RealmEvent) {
+	data.SetId(realmId)
+
+	var eventsData []interface{}
+	for _, event := range events {
+		eventsData = append(eventsData, map[string]interface{}{
+			"time":       int(event.Time),
+			"type":       event.Type,
+			"client_id":  event.ClientId,
+			"user_id":    event.UserId,
+			"session_id": event.SessionId,
+			"ip_address": event.IpAddress,
+			"error":      event.Error,
+			"details":    event.Details,
+		})
+	}
+
+	data.Set("events", eventsData)
+}
+
+func dataSourceKeycloakRealmLoginEventsRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	realmId := data.Get("realm_id").(string)
+
+	events, err := keycloakClient.GetRealmEvents(ctx, realmId, getRealmEventsQueryFromData(data))
+	if err != nil {
+		return diagnosticsFromError(err, data)
+	}
+
+	setRealmLoginEventsData(data, realmId, events)
+
+	return nil
+}
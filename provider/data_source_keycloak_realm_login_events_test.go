@@ -0,0 +1,80 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccKeycloakDataSourceRealmLoginEvents_basic(t *testing.T) {
+	t.Parallel()
+
+	realmName := acctest.RandomWithPrefix("tf-acc")
+	dataSourceName := "data.keycloak_realm_login_events.login_events"
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		PreCheck:          func() { testAccPreCheck(t) },
+		CheckDestroy:      testAccCheckKeycloakRealmDestroy(),
+		Steps: []resource.TestStep{
+			// nobody has logged in to the new realm yet
+			{
+				Config: testAccKeycloakDataSourceRealmLoginEvents_basic(realmName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "id", realmName),
+					resource.TestCheckResourceAttr(dataSourceName, "events.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccKeycloakDataSourceRealmLoginEvents_invalidDate(t *testing.T) {
+	t.Parallel()
+
+	realmName := acctest.RandomWithPrefix("tf-acc")
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		PreCheck:          func() { testAccPreCheck(t) },
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccKeycloakDataSourceRealmLoginEvents_dateFrom(realmName, "01/01/2025"),
+				ExpectError: regexp.MustCompile("expected a date formatted as yyyy-MM-dd"),
+			},
+		},
+	})
+}
+
+func testAccKeycloakDataSourceRealmLoginEvents_basic(realm string) string {
+	return fmt.Sprintf(`
+resource "keycloak_realm" "realm" {
+	realm = "%s"
+}
+
+resource "keycloak_realm_events" "realm_events" {
+	realm_id       = keycloak_realm.realm.id
+	events_enabled = true
+}
+
+data "keycloak_realm_login_events" "login_events" {
+	realm_id  = keycloak_realm_events.realm_events.realm_id
+	types     = ["LOGIN", "LOGIN_ERROR"]
+	client_id = "account"
+	date_from = "2020-01-01"
+	max       = 10
+}
+	`, realm)
+}
+
+func testAccKeycloakDataSourceRealmLoginEvents_dateFrom(realm, dateFrom string) string {
+	return fmt.Sprintf(`
+data "keycloak_realm_login_events" "login_events" {
+	realm_id  = "%s"
+	date_from = "%s"
+}
+	`, realm, dateFrom)
+}
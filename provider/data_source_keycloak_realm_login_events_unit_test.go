@@ -0,0 +1,58 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/keycloak/terraform-provider-keycloak/keycloak"
+	"github.com/keycloak/terraform-provider-keycloak/keycloak/keycloaktest"
+)
+
+func TestRealmLoginEventsDataSourceRead(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	server := keycloaktest.NewServer()
+	t.Cleanup(server.Close)
+
+	keycloakClient := newFakeKeycloakClientForServer(t, server)
+	if err := keycloakClient.NewRealm(ctx, &keycloak.Realm{Realm: "test", Enabled: true}); err != nil {
+		t.Fatal(err)
+	}
+
+	server.AddEvent("test", map[string]interface{}{"time": 1000, "type": "LOGIN", "clientId": "account", "userId": "alice"})
+	server.AddEvent("test", map[string]interface{}{"time": 2000, "type": "LOGIN_ERROR", "clientId": "account", "error": "invalid_user_credentials"})
+	server.AddEvent("test", map[string]interface{}{"time": 3000, "type": "CODE_TO_TOKEN", "clientId": "account", "userId": "alice"})
+	server.AddEvent("test", map[string]interface{}{"time": 4000, "type": "LOGIN", "clientId": "admin-cli", "userId": "bob", "details": map[string]interface{}{"username": "bob"}})
+
+	data := schema.TestResourceDataRaw(t, dataSourceKeycloakRealmLoginEvents().Schema, map[string]interface{}{
+		"realm_id": "test",
+		"types":    []interface{}{"LOGIN", "LOGIN_ERROR"},
+	})
+	if diags := dataSourceKeycloakRealmLoginEventsRead(ctx, data, keycloakClient); diags.HasError() {
+		t.Fatal(diags)
+	}
+
+	if data.Id() != "test" || data.Get("events.#") != 3 {
+		t.Fatalf("expected the login and login error events, got %v", data.Get("events"))
+	}
+	if data.Get("events.0.time") != 4000 || data.Get("events.0.details.username") != "bob" || data.Get("events.2.user_id") != "alice" {
+		t.Errorf("expected the events newest first, got %v", data.Get("events"))
+	}
+
+	data = schema.TestResourceDataRaw(t, dataSourceKeycloakRealmLoginEvents().Schema, map[string]interface{}{
+		"realm_id":  "test",
+		"client_id": "account",
+		"first":     1,
+		"max":       1,
+	})
+	if diags := dataSourceKeycloakRealmLoginEventsRead(ctx, data, keycloakClient); diags.HasError() {
+		t.Fatal(diags)
+	}
+
+	if data.Get("events.#") != 1 || data.Get("events.0.type") != "LOGIN_ERROR" || data.Get("events.0.error") != "invalid_user_credentials" {
+		t.Errorf("expected the second event of the client, got %v", data.Get("events"))
+	}
+}
@@ -0,0 +1,341 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/keycloak/terraform-provider-keycloak/keycloak"
+)
+
+func dataSourceKeycloakServerInfo() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceKeycloakServerInfoRead,
+		Schema: map[string]*schema.Schema{
+			"version": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"keycloak_version": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"distribution": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"enabled_features": {
+				Type:     schema.TypeList,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Computed: true,
+			},
+			"disabled_features": {
+				Type:     schema.TypeList,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Computed: true,
+			},
+			"themes": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"names": {
+							Type:     schema.TypeList,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+							Computed: true,
+						},
+					},
+				},
+			},
+			"providers": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"ids": {
+							Type:     schema.TypeList,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+							Computed: true,
+						},
+					},
+				},
+			},
+			"protocol_mapper_types": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"protocol": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"category": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"help_text": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"properties": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"name": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"label": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"help_text": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"type": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"default_value": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"options": {
+										Type:     schema.TypeList,
+										Elem:     &schema.Schema{Type: schema.TypeString},
+										Computed: true,
+									},
+									"secret": {
+										Type:     schema.TypeBool,
+										Computed: true,
+									},
+									"required": {
+										Type:     schema.TypeBool,
+										Computed: true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			"password_policy_types": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"display_name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"config_type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"default_value": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"multiple_supported": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func sortedKeys[V any](values map[string]V) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+// configPropertyDefaultValue converts the default value of a config property to a string, as it can be of any type
+func configPropertyDefaultValue(value interface{}) string {
+	switch value := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return value
+	default:
+		encoded, err := json.Marshal(value)
+		if err != nil {
+			return fmt.Sprintf("%v", value)
+		}
+		return string(encoded)
+	}
+}
+
+func flattenServerInfoThemes(themes map[string][]keycloak.Theme) []interface{} {
+	var result []interface{}
+	for _, themeType := range sortedKeys(themes) {
+		var names []string
+		for _, theme := range themes[themeType] {
+			names = append(names, theme.Name)
+		}
+		sort.Strings(names)
+
+		result = append(result, map[string]interface{}{
+			"type":  themeType,
+			"names": names,
+		})
+	}
+
+	return result
+}
+
+func flattenServerInfoProviders(providerTypes map[string]keycloak.ProviderType) []interface{} {
+	var result []interface{}
+	for _, providerType := range sortedKeys(providerTypes) {
+		result = append(result, map[string]interface{}{
+			"type": providerType,
+			"ids":  sortedKeys(providerTypes[providerType].Providers),
+		})
+	}
+
+	return result
+}
+
+func flattenServerInfoProtocolMapperTypes(protocolMapperTypes map[string][]keycloak.ProtocolMapperType) []interface{} {
+	var result []interface{}
+	for _, protocol := range sortedKeys(protocolMapperTypes) {
+		// the server info is shared by the provider, so it is sorted in a copy
+		mapperTypes := append([]keycloak.ProtocolMapperType{}, protocolMapperTypes[protocol]...)
+		sort.Slice(mapperTypes, func(i, j int) bool {
+			return mapperTypes[i].Id < mapperTypes[j].Id
+		})
+
+		for _, mapperType := range mapperTypes {
+			var properties []interface{}
+			for _, property := range mapperType.Properties {
+				properties = append(properties, map[string]interface{}{
+					"name":          property.Name,
+					"label":         property.Label,
+					"help_text":     property.HelpText,
+					"type":          property.Type,
+					"default_value": configPropertyDefaultValue(property.DefaultValue),
+					"options":       property.Options,
+					"secret":        property.Secret,
+					"required":      property.Required,
+				})
+			}
+
+			result = append(result, map[string]interface{}{
+				"protocol":   protocol,
+				"id":         mapperType.Id,
+				"name":       mapperType.Name,
+				"category":   mapperType.Category,
+				"help_text":  mapperType.HelpText,
+				"properties": properties,
+			})
+		}
+	}
+
+	return result
+}
+
+func flattenServerInfoPasswordPolicyTypes(passwordPolicies []keycloak.PasswordPolicyType) []interface{} {
+	passwordPolicies = append([]keycloak.PasswordPolicyType{}, passwordPolicies...)
+	sort.Slice(passwordPolicies, func(i, j int) bool {
+		return passwordPolicies[i].Id < passwordPolicies[j].Id
+	})
+
+	var result []interface{}
+	for _, passwordPolicy := range passwordPolicies {
+		result = append(result, map[string]interface{}{
+			"id":                 passwordPolicy.Id,
+			"display_name":       passwordPolicy.DisplayName,
+			"config_type":        passwordPolicy.ConfigType,
+			"default_value":      passwordPolicy.DefaultValue,
+			"multiple_supported": passwordPolicy.MultipleSupported,
+		})
+	}
+
+	return result
+}
+
+func setServerInfoData(data *schema.ResourceData, capabilities *keycloak.ServerCapabilities) error {
+	serverInfo := capabilities.ServerInfo
+
+	enabledFeatures := []string{}
+	disabledFeatures := []string{}
+	if serverInfo.Features != nil {
+		for _, feature := range serverInfo.Features {
+			if feature.Enabled {
+				enabledFeatures = append(enabledFeatures, feature.Name)
+			} else {
+				disabledFeatures = append(disabledFeatures, feature.Name)
+			}
+		}
+	} else {
+		// older versions of keycloak only report the features that are disabled
+		disabledFeatures = append(disabledFeatures, serverInfo.ProfileInfo.DisabledFeatures...)
+	}
+	sort.Strings(enabledFeatures)
+	sort.Strings(disabledFeatures)
+
+	data.SetId(capabilities.ServerVersion)
+
+	values := map[string]interface{}{
+		"version":               capabilities.ServerVersion,
+		"keycloak_version":      capabilities.Version.String(),
+		"distribution":          capabilities.Distribution,
+		"enabled_features":      enabledFeatures,
+		"disabled_features":     disabledFeatures,
+		"themes":                flattenServerInfoThemes(serverInfo.Themes),
+		"providers":             flattenServerInfoProviders(serverInfo.ProviderTypes),
+		"protocol_mapper_types": flattenServerInfoProtocolMapperTypes(serverInfo.ProtocolMapperTypes),
+		"password_policy_types": flattenServerInfoPasswordPolicyTypes(serverInfo.PasswordPolicies),
+	}
+
+	for _, attribute := range sortedKeys(values) {
+		if err := data.Set(attribute, values[attribute]); err != nil {
+			return fmt.Errorf("could not set '%s': %+v", attribute, err)
+		}
+	}
+
+	return nil
+}
+
+func dataSourceKeycloakServerInfoRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	capabilities, err := keycloakClient.GetServerCapabilities(ctx)
+	if err != nil {
+		return diagnosticsFromError(err, data)
+	}
+
+	return diag.FromErr(setServerInfoData(data, capabilities))
+}
@@ -0,0 +1,48 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccKeycloakDataSourceServerInfo_basic(t *testing.T) {
+	t.Parallel()
+	dataSourceName := "data.keycloak_server_info.server_info"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKeycloakServerInfoConfig(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(dataSourceName, "version"),
+					resource.TestCheckResourceAttrSet(dataSourceName, "keycloak_version"),
+					resource.TestCheckResourceAttr(dataSourceName, "distribution", "keycloak"),
+					resource.TestCheckTypeSetElemNestedAttrs(dataSourceName, "themes.*", map[string]string{
+						"type": "login",
+					}),
+					resource.TestCheckTypeSetElemNestedAttrs(dataSourceName, "providers.*", map[string]string{
+						"type": "password-policy",
+					}),
+					resource.TestCheckTypeSetElemNestedAttrs(dataSourceName, "protocol_mapper_types.*", map[string]string{
+						"protocol": "openid-connect",
+						"id":       "oidc-hardcoded-claim-mapper",
+					}),
+					resource.TestCheckTypeSetElemNestedAttrs(dataSourceName, "password_policy_types.*", map[string]string{
+						"id": "length",
+					}),
+					resource.TestCheckResourceAttrSet(dataSourceName, "disabled_features.#"),
+				),
+			},
+		},
+	})
+}
+
+func testAccKeycloakServerInfoConfig() string {
+	return `
+data "keycloak_server_info" "server_info" {
+}
+`
+}
@@ -0,0 +1,51 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/keycloak/terraform-provider-keycloak/keycloak/keycloaktest"
+)
+
+func TestServerInfoDataSourceRead(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	server := keycloaktest.NewServer()
+	t.Cleanup(server.Close)
+	server.SetFeature("TOKEN_EXCHANGE", true)
+
+	keycloakClient := newFakeKeycloakClientForServer(t, server)
+
+	data := schema.TestResourceDataRaw(t, dataSourceKeycloakServerInfo().Schema, map[string]interface{}{})
+	if diags := dataSourceKeycloakServerInfoRead(ctx, data, keycloakClient); diags.HasError() {
+		t.Fatal(diags)
+	}
+
+	if data.Id() != keycloaktest.DefaultVersion || data.Get("keycloak_version") != keycloaktest.DefaultVersion || data.Get("distribution") != "keycloak" {
+		t.Errorf("unexpected version %s, %s", data.Get("version"), data.Get("distribution"))
+	}
+
+	enabledFeatures := data.Get("enabled_features").([]interface{})
+	if !Contains(enabledFeatures, "TOKEN_EXCHANGE") || Contains(data.Get("disabled_features").([]interface{}), "TOKEN_EXCHANGE") {
+		t.Errorf("expected token exchange to be enabled, got %v", enabledFeatures)
+	}
+
+	if data.Get("themes.2.type") != "email" || !Contains(data.Get("themes.2.names").([]interface{}), "keycloak") {
+		t.Errorf("expected the themes to be sorted by type, got %v", data.Get("themes"))
+	}
+
+	if data.Get("providers.0.type") != "password-policy" || !Contains(data.Get("providers.0.ids").([]interface{}), "length") {
+		t.Errorf("unexpected providers %v", data.Get("providers"))
+	}
+
+	if data.Get("protocol_mapper_types.0.id") != "oidc-hardcoded-claim-mapper" || data.Get("protocol_mapper_types.0.properties.1.default_value") != "true" {
+		t.Errorf("unexpected protocol mapper types %v", data.Get("protocol_mapper_types"))
+	}
+
+	if data.Get("password_policy_types.#") != 2 || data.Get("password_policy_types.0.default_value") != "8" {
+		t.Errorf("unexpected password policy types %v", data.Get("password_policy_types"))
+	}
+}
@@ -0,0 +1,107 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/keycloak/terraform-provider-keycloak/keycloak"
+)
+
+// keycloakFieldAttributes maps the fields named in keycloak's validation errors to the attributes of this provider,
+// where the attribute isn't just the field in snake case
+var keycloakFieldAttributes = map[string]string{
+	"redirectUris":           "valid_redirect_uris",
+	"postLogoutRedirectUris": "valid_post_logout_redirect_uris",
+}
+
+var camelCaseBoundaryPattern = regexp.MustCompile(`([a-z0-9])([A-Z])`)
+
+// diagnosticsFromError converts an error into diagnostics. When keycloak rejected a request because of specific fields,
+// each of them becomes its own diagnostic that points at the matching attribute of the resource's configuration.
+func diagnosticsFromError(err error, data *schema.ResourceData) diag.Diagnostics {
+	var unsupportedFeatureError *keycloak.UnsupportedFeatureError
+	if errors.As(err, &unsupportedFeatureError) {
+		return unsupportedFeatureDiagnostics(unsupportedFeatureError)
+	}
+
+	apiError, ok := keycloak.AsApiError(err)
+	if !ok {
+		return diag.FromErr(err)
+	}
+
+	fieldErrors := apiError.FieldErrors()
+	if len(fieldErrors) == 0 {
+		return diag.Diagnostics{
+			{
+				Severity: diag.Error,
+				Summary:  apiError.Description(),
+				Detail:   apiError.Message,
+			},
+		}
+	}
+
+	var diags diag.Diagnostics
+	for _, fieldError := range fieldErrors {
+		diagnostic := diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("%s: %s", fieldError.Field, fieldError.ErrorMessage),
+			Detail:   apiError.Message,
+		}
+
+		if attribute, ok := attributeForKeycloakField(data, fieldError.Field); ok {
+			diagnostic.Summary = fmt.Sprintf("%s: %s", attribute, fieldError.ErrorMessage)
+			diagnostic.AttributePath = cty.GetAttrPath(attribute)
+		}
+
+		diags = append(diags, diagnostic)
+	}
+
+	return diags
+}
+
+// requireFeature returns diagnostics when a feature that a resource depends on isn't available on the server
+func requireFeature(ctx context.Context, keycloakClient *keycloak.KeycloakClient, feature keycloak.Feature) diag.Diagnostics {
+	err := keycloakClient.RequireFeature(ctx, feature)
+	if err != nil {
+		return diagnosticsFromError(err, nil)
+	}
+
+	return nil
+}
+
+func unsupportedFeatureDiagnostics(err *keycloak.UnsupportedFeatureError) diag.Diagnostics {
+	return diag.Diagnostics{
+		{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("feature %s is not supported by the server", err.Feature),
+			Detail:   err.Error(),
+		},
+	}
+}
+
+// attributeForKeycloakField finds the attribute of the resource being configured that corresponds to a field of a
+// keycloak representation
+func attributeForKeycloakField(data *schema.ResourceData, field string) (string, bool) {
+	if data == nil {
+		return "", false
+	}
+
+	attribute, ok := keycloakFieldAttributes[field]
+	if !ok {
+		attribute = strings.ToLower(camelCaseBoundaryPattern.ReplaceAllString(field, "${1}_${2}"))
+	}
+
+	// the type of the config is derived from the resource's schema, even when there is no config yet
+	configType := data.GetRawConfig().Type()
+	if !configType.IsObjectType() || !configType.HasAttribute(attribute) {
+		return "", false
+	}
+
+	return attribute, true
+}
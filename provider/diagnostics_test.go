@@ -0,0 +1,134 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/keycloak/terraform-provider-keycloak/keycloak"
+	"github.com/keycloak/terraform-provider-keycloak/keycloak/keycloaktest"
+)
+
+func TestDiagnosticsFromErrorSetsAttributePaths(t *testing.T) {
+	t.Parallel()
+
+	data := schema.TestResourceDataRaw(t, resourceKeycloakOpenidClient().Schema, map[string]interface{}{})
+
+	diags := diagnosticsFromError(&keycloak.ApiError{
+		Code:    400,
+		Message: "error sending PUT request to /admin/realms/test/clients/1234: 400 Bad Request.",
+		Errors: []keycloak.ApiFieldError{
+			{Field: "redirectUris", ErrorMessage: "invalid URI"},
+			{Field: "rootUrl", ErrorMessage: "invalid URL"},
+			{Field: "somethingElse", ErrorMessage: "invalid value"},
+		},
+	}, data)
+
+	if len(diags) != 3 {
+		t.Fatalf("expected a diagnostic per field, got %v", diags)
+	}
+
+	for i, expected := range []struct {
+		summary       string
+		attributePath cty.Path
+	}{
+		{"valid_redirect_uris: invalid URI", cty.GetAttrPath("valid_redirect_uris")},
+		{"root_url: invalid URL", cty.GetAttrPath("root_url")},
+		{"somethingElse: invalid value", nil},
+	} {
+		if diags[i].Summary != expected.summary || !diags[i].AttributePath.Equals(expected.attributePath) {
+			t.Errorf("expected %q at %#v, got %q at %#v", expected.summary, expected.attributePath, diags[i].Summary, diags[i].AttributePath)
+		}
+	}
+}
+
+func TestDiagnosticsFromErrorWithoutFields(t *testing.T) {
+	t.Parallel()
+
+	diags := diagnosticsFromError(&keycloak.ApiError{Code: 409, Message: "error sending POST request", ErrorMessage: "Client backend already exists"}, nil)
+	if len(diags) != 1 || diags[0].Summary != "Client backend already exists" || !strings.Contains(diags[0].Detail, "error sending POST request") {
+		t.Errorf("unexpected diagnostics %v", diags)
+	}
+
+	diags = diagnosticsFromError(errors.New("validation error: something is wrong"), nil)
+	if len(diags) != 1 || diags[0].Summary != "validation error: something is wrong" {
+		t.Errorf("expected other errors to be returned as they are, got %v", diags)
+	}
+}
+
+func TestRealmResourceCreateReportsConflicts(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	keycloakClient := newFakeKeycloakClient(t)
+
+	if err := keycloakClient.NewRealm(ctx, &keycloak.Realm{Realm: "test", Enabled: true}); err != nil {
+		t.Fatal(err)
+	}
+
+	data := schema.TestResourceDataRaw(t, resourceKeycloakRealm().Schema, map[string]interface{}{
+		"realm": "test",
+	})
+
+	diags := resourceKeycloakRealmCreate(ctx, data, keycloakClient)
+	if !diags.HasError() || diags[0].Summary != "Conflict detected. See logs for details" || !strings.Contains(diags[0].Detail, "409 Conflict") {
+		t.Errorf("expected the realm to conflict with the existing one, got %v", diags)
+	}
+}
+
+func TestOrganizationResourceCreateReportsDisabledFeature(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	server := keycloaktest.NewServer()
+	t.Cleanup(server.Close)
+	server.SetFeature("ORGANIZATION", false)
+
+	keycloakClient := newFakeKeycloakClientForServer(t, server)
+
+	data := schema.TestResourceDataRaw(t, resourceKeycloakOrganization().Schema, map[string]interface{}{
+		"realm_id": "master",
+		"name":     "example",
+	})
+
+	diags := resourceKeycloakOrganizationCreate(ctx, data, keycloakClient)
+	if len(diags) != 1 || diags[0].Summary != "feature ORGANIZATION is not supported by the server" || !strings.Contains(diags[0].Detail, "--features=organization") {
+		t.Errorf("expected a diagnostic for the disabled feature, got %v", diags)
+	}
+}
+
+func TestRealmClientPolicyResourcesCreateReportDisabledFeature(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	server := keycloaktest.NewServer()
+	t.Cleanup(server.Close)
+	server.SetFeature("CLIENT_POLICIES", false)
+
+	keycloakClient := newFakeKeycloakClientForServer(t, server)
+
+	for name, resource := range map[string]struct {
+		schema *schema.Resource
+		create schema.CreateContextFunc
+	}{
+		"keycloak_realm_client_policies": {resourceKeycloakRealmClientPolicies(), resourceKeycloakRealmClientPoliciesCreate},
+		"keycloak_realm_client_profiles": {resourceKeycloakRealmClientProfiles(), resourceKeycloakRealmClientProfilesCreate},
+	} {
+		data := schema.TestResourceDataRaw(t, resource.schema.Schema, map[string]interface{}{
+			"realm_id": "master",
+		})
+
+		diags := resource.create(ctx, data, keycloakClient)
+		if len(diags) != 1 || diags[0].Summary != "feature CLIENT_POLICIES is not supported by the server" {
+			t.Errorf("%s: expected a diagnostic for the disabled feature, got %v", name, diags)
+		}
+		if data.Id() != "" {
+			t.Errorf("%s: expected no id to be set, got %s", name, data.Id())
+		}
+	}
+}
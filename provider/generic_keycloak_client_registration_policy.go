@@ -0,0 +1,151 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/keycloak/terraform-provider-keycloak/keycloak"
+)
+
+var keycloakClientRegistrationPolicySubTypes = []string{"anonymous", "authenticated"}
+
+type clientRegistrationPolicyDataGetterFunc func(data *schema.ResourceData) *keycloak.ClientRegistrationPolicy
+type clientRegistrationPolicyDataSetterFunc func(data *schema.ResourceData, policy *keycloak.ClientRegistrationPolicy)
+
+func resourceKeycloakClientRegistrationPolicy() *schema.Resource {
+	return &schema.Resource{
+		DeleteContext: resourceKeycloakClientRegistrationPolicyDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceKeycloakClientRegistrationPolicyImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"realm_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"sub_type": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice(keycloakClientRegistrationPolicySubTypes, false),
+				Description:  "Whether the policy applies to anonymous registration requests or to requests authenticated with an initial access token or a bearer token.",
+			},
+		},
+	}
+}
+
+func getClientRegistrationPolicyFromData(data *schema.ResourceData, providerId string) *keycloak.ClientRegistrationPolicy {
+	return &keycloak.ClientRegistrationPolicy{
+		Id:         data.Id(),
+		Name:       data.Get("name").(string),
+		RealmId:    data.Get("realm_id").(string),
+		SubType:    data.Get("sub_type").(string),
+		ProviderId: providerId,
+		Config:     map[string][]string{},
+	}
+}
+
+func setClientRegistrationPolicyData(data *schema.ResourceData, policy *keycloak.ClientRegistrationPolicy) {
+	data.SetId(policy.Id)
+	data.Set("realm_id", policy.RealmId)
+	data.Set("name", policy.Name)
+	data.Set("sub_type", policy.SubType)
+}
+
+func getClientRegistrationPolicyConfigBool(policy *keycloak.ClientRegistrationPolicy, key string) bool {
+	values := policy.Config[key]
+
+	return len(values) != 0 && values[0] == "true"
+}
+
+func resourceKeycloakClientRegistrationPolicyCreate(getPolicyFromData clientRegistrationPolicyDataGetterFunc, setDataFromPolicy clientRegistrationPolicyDataSetterFunc) func(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return func(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+		keycloakClient := meta.(*keycloak.KeycloakClient)
+
+		policy := getPolicyFromData(data)
+
+		err := keycloakClient.NewClientRegistrationPolicy(ctx, policy)
+		if err != nil {
+			return diagnosticsFromError(err, data)
+		}
+
+		setDataFromPolicy(data, policy)
+
+		return resourceKeycloakClientRegistrationPolicyRead(policy.ProviderId, setDataFromPolicy)(ctx, data, meta)
+	}
+}
+
+func resourceKeycloakClientRegistrationPolicyRead(providerId string, setDataFromPolicy clientRegistrationPolicyDataSetterFunc) func(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return func(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+		keycloakClient := meta.(*keycloak.KeycloakClient)
+
+		realmId := data.Get("realm_id").(string)
+		id := data.Id()
+
+		policy, err := keycloakClient.GetClientRegistrationPolicy(ctx, realmId, id)
+		if err != nil {
+			return handleNotFoundError(ctx, err, data)
+		}
+
+		if policy.ProviderId != providerId {
+			return diag.Errorf("client registration policy %s is a %s policy, expected a %s policy", id, policy.ProviderId, providerId)
+		}
+
+		setDataFromPolicy(data, policy)
+
+		return nil
+	}
+}
+
+func resourceKeycloakClientRegistrationPolicyUpdate(getPolicyFromData clientRegistrationPolicyDataGetterFunc, setDataFromPolicy clientRegistrationPolicyDataSetterFunc) func(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return func(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+		keycloakClient := meta.(*keycloak.KeycloakClient)
+
+		policy := getPolicyFromData(data)
+
+		err := keycloakClient.UpdateClientRegistrationPolicy(ctx, policy)
+		if err != nil {
+			return diagnosticsFromError(err, data)
+		}
+
+		setDataFromPolicy(data, policy)
+
+		return nil
+	}
+}
+
+func resourceKeycloakClientRegistrationPolicyDelete(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	realmId := data.Get("realm_id").(string)
+	id := data.Id()
+
+	err := keycloakClient.DeleteClientRegistrationPolicy(ctx, realmId, id)
+	if err != nil {
+		return diagnosticsFromError(err, data)
+	}
+
+	return nil
+}
+
+func resourceKeycloakClientRegistrationPolicyImport(_ context.Context, d *schema.ResourceData, _ interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.Split(d.Id(), "/")
+
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("Invalid import. Supported import formats: {{realmId}}/{{clientRegistrationPolicyId}}")
+	}
+
+	d.Set("realm_id", parts[0])
+	d.SetId(parts[1])
+
+	return []*schema.ResourceData{d}, nil
+}
@@ -0,0 +1,75 @@
+package provider
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/keycloak/terraform-provider-keycloak/keycloak"
+)
+
+func TestClientRegistrationPolicyResources(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	keycloakClient := newFakeKeycloakClient(t)
+
+	if err := keycloakClient.NewRealm(ctx, &keycloak.Realm{Realm: "test", Enabled: true}); err != nil {
+		t.Fatal(err)
+	}
+
+	trustedHostsResource := resourceKeycloakClientRegistrationTrustedHostsPolicy()
+	trustedHosts := schema.TestResourceDataRaw(t, trustedHostsResource.Schema, map[string]interface{}{
+		"realm_id":               "test",
+		"name":                   "Trusted Hosts",
+		"sub_type":               "anonymous",
+		"trusted_hosts":          []interface{}{"example.com"},
+		"client_uris_must_match": false,
+	})
+	if diags := trustedHostsResource.CreateContext(ctx, trustedHosts, keycloakClient); diags.HasError() {
+		t.Fatal(diags)
+	}
+
+	policy, err := keycloakClient.GetClientRegistrationPolicy(ctx, "test", trustedHosts.Id())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if policy.ProviderId != "trusted-hosts" || policy.Config["host-sending-registration-request-must-match"][0] != "true" || policy.Config["client-uris-must-match"][0] != "false" {
+		t.Errorf("unexpected policy %+v", policy)
+	}
+	if !trustedHosts.Get("host_sending_registration_request_must_match").(bool) || trustedHosts.Get("client_uris_must_match").(bool) {
+		t.Errorf("expected the booleans to be read back")
+	}
+
+	maxClientsResource := resourceKeycloakClientRegistrationMaxClientsPolicy()
+	maxClients := schema.TestResourceDataRaw(t, maxClientsResource.Schema, map[string]interface{}{
+		"realm_id": "test",
+		"name":     "Max Clients",
+		"sub_type": "authenticated",
+	})
+	if diags := maxClientsResource.CreateContext(ctx, maxClients, keycloakClient); diags.HasError() {
+		t.Fatal(diags)
+	}
+	if maxClients.Get("max_clients").(int) != 200 {
+		t.Errorf("expected the default of 200 clients, got %d", maxClients.Get("max_clients").(int))
+	}
+
+	// importing a policy into the resource of another type fails instead of overwriting its config
+	consentRequiredResource := resourceKeycloakClientRegistrationConsentRequiredPolicy()
+	consentRequired := schema.TestResourceDataRaw(t, consentRequiredResource.Schema, map[string]interface{}{
+		"realm_id": "test",
+	})
+	consentRequired.SetId(maxClients.Id())
+	diags := consentRequiredResource.ReadContext(ctx, consentRequired, keycloakClient)
+	if !diags.HasError() || !strings.Contains(diags[0].Summary, "is a max-clients policy, expected a consent-required policy") {
+		t.Errorf("expected the policy type to be checked, got %v", diags)
+	}
+
+	if diags := maxClientsResource.DeleteContext(ctx, maxClients, keycloakClient); diags.HasError() {
+		t.Fatal(diags)
+	}
+	if diags := maxClientsResource.ReadContext(ctx, maxClients, keycloakClient); diags.HasError() || maxClients.Id() != "" {
+		t.Errorf("expected the deleted policy to be removed from state, got %v", diags)
+	}
+}
@@ -196,14 +196,14 @@ func resourceKeycloakIdentityProviderCreate(getIdentityProviderFromData identity
 		keycloakClient := meta.(*keycloak.KeycloakClient)
 		identityProvider, err := getIdentityProviderFromData(data)
 		if err != nil {
-			return diag.FromErr(err)
+			return diagnosticsFromError(err, data)
 		}
 
 		if err = keycloakClient.NewIdentityProvider(ctx, identityProvider); err != nil {
-			return diag.FromErr(err)
+			return diagnosticsFromError(err, data)
 		}
 		if err = setDataFromIdentityProvider(data, identityProvider); err != nil {
-			return diag.FromErr(err)
+			return diagnosticsFromError(err, data)
 		}
 		return resourceKeycloakIdentityProviderRead(setDataFromIdentityProvider)(ctx, data, meta)
 	}
@@ -228,12 +228,31 @@ func resourceKeycloakIdentityProviderUpdate(getIdentityProviderFromData identity
 		keycloakClient := meta.(*keycloak.KeycloakClient)
 		identityProvider, err := getIdentityProviderFromData(data)
 		if err != nil {
-			return diag.FromErr(err)
+			return diagnosticsFromError(err, data)
+		}
+
+		// the identity provider could be linked to an organization by keycloak_organization_identity_provider,
+		// so carry over the organization settings that are not managed by this resource
+		existingIdentityProvider, err := keycloakClient.GetIdentityProvider(ctx, identityProvider.Realm, identityProvider.Alias)
+		if err != nil {
+			return diagnosticsFromError(err, data)
+		}
+
+		identityProvider.OrganizationId = existingIdentityProvider.OrganizationId
+		if existingIdentityProvider.Config != nil && identityProvider.Config != nil {
+			for key, value := range existingIdentityProvider.Config.ExtraConfig {
+				if _, ok := identityProvider.Config.ExtraConfig[key]; !ok && keycloak.IsOrganizationConfigKey(key) {
+					if identityProvider.Config.ExtraConfig == nil {
+						identityProvider.Config.ExtraConfig = map[string]interface{}{}
+					}
+					identityProvider.Config.ExtraConfig[key] = value
+				}
+			}
 		}
 
 		err = keycloakClient.UpdateIdentityProvider(ctx, identityProvider)
 		if err != nil {
-			return diag.FromErr(err)
+			return diagnosticsFromError(err, data)
 		}
 
 		return diag.FromErr(setDataFromIdentityProvider(data, identityProvider))
@@ -107,11 +107,11 @@ func resourceKeycloakIdentityProviderMapperCreate(getIdentityProviderMapperFromD
 		}
 
 		if err = keycloakClient.NewIdentityProviderMapper(ctx, identityProvider); err != nil {
-			return diag.FromErr(err)
+			return diagnosticsFromError(err, data)
 		}
 
 		if err = setDataFromIdentityProviderMapper(data, identityProvider); err != nil {
-			return diag.FromErr(err)
+			return diagnosticsFromError(err, data)
 		}
 
 		return resourceKeycloakIdentityProviderMapperRead(setDataFromIdentityProviderMapper)(ctx, data, meta)
@@ -132,7 +132,7 @@ func resourceKeycloakIdentityProviderMapperRead(setDataFromIdentityProviderMappe
 		}
 
 		if err = setDataFromIdentityProviderMapper(data, identityProvider); err != nil {
-			return diag.FromErr(err)
+			return diagnosticsFromError(err, data)
 		}
 
 		return nil
@@ -149,11 +149,11 @@ func resourceKeycloakIdentityProviderMapperUpdate(getIdentityProviderMapperFromD
 		}
 
 		if err = keycloakClient.UpdateIdentityProviderMapper(ctx, identityProvider); err != nil {
-			return diag.FromErr(err)
+			return diagnosticsFromError(err, data)
 		}
 
 		if err = setDataFromIdentityProviderMapper(data, identityProvider); err != nil {
-			return diag.FromErr(err)
+			return diagnosticsFromError(err, data)
 		}
 
 		return nil
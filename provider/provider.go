@@ -3,9 +3,11 @@ package provider
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/meta"
 	"github.com/keycloak/terraform-provider-keycloak/keycloak"
 )
@@ -19,7 +21,11 @@ func KeycloakProvider(client *keycloak.KeycloakClient) *schema.Provider {
 			"keycloak_openid_client_scope":                dataSourceKeycloakOpenidClientScope(),
 			"keycloak_openid_client_service_account_user": dataSourceKeycloakOpenidClientServiceAccountUser(),
 			"keycloak_realm":                              dataSourceKeycloakRealm(),
+			"keycloak_realm_export":                       dataSourceKeycloakRealmExport(),
+			"keycloak_realm_admin_events":                 dataSourceKeycloakRealmAdminEvents(),
 			"keycloak_realm_keys":                         dataSourceKeycloakRealmKeys(),
+			"keycloak_realm_login_events":                 dataSourceKeycloakRealmLoginEvents(),
+			"keycloak_server_info":                        dataSourceKeycloakServerInfo(),
 			"keycloak_role":                               dataSourceKeycloakRole(),
 			"keycloak_user":                               dataSourceKeycloakUser(),
 			"keycloak_user_realm_roles":                   dataSourceKeycloakUserRealmRoles(),
@@ -30,97 +36,119 @@ func KeycloakProvider(client *keycloak.KeycloakClient) *schema.Provider {
 			"keycloak_client_description_converter":       dataSourceKeycloakClientDescriptionConverter(),
 		},
 		ResourcesMap: map[string]*schema.Resource{
-			"keycloak_realm":                                             resourceKeycloakRealm(),
-			"keycloak_realm_events":                                      resourceKeycloakRealmEvents(),
-			"keycloak_realm_keystore_aes_generated":                      resourceKeycloakRealmKeystoreAesGenerated(),
-			"keycloak_realm_keystore_ecdsa_generated":                    resourceKeycloakRealmKeystoreEcdsaGenerated(),
-			"keycloak_realm_keystore_hmac_generated":                     resourceKeycloakRealmKeystoreHmacGenerated(),
-			"keycloak_realm_keystore_java_keystore":                      resourceKeycloakRealmKeystoreJavaKeystore(),
-			"keycloak_realm_keystore_rsa":                                resourceKeycloakRealmKeystoreRsa(),
-			"keycloak_realm_keystore_rsa_generated":                      resourceKeycloakRealmKeystoreRsaGenerated(),
-			"keycloak_realm_user_profile":                                resourceKeycloakRealmUserProfile(),
-			"keycloak_required_action":                                   resourceKeycloakRequiredAction(),
-			"keycloak_group":                                             resourceKeycloakGroup(),
-			"keycloak_group_memberships":                                 resourceKeycloakGroupMemberships(),
-			"keycloak_default_groups":                                    resourceKeycloakDefaultGroups(),
-			"keycloak_default_roles":                                     resourceKeycloakDefaultRoles(),
-			"keycloak_group_roles":                                       resourceKeycloakGroupRoles(),
-			"keycloak_user":                                              resourceKeycloakUser(),
-			"keycloak_user_roles":                                        resourceKeycloakUserRoles(),
-			"keycloak_openid_client":                                     resourceKeycloakOpenidClient(),
-			"keycloak_openid_client_scope":                               resourceKeycloakOpenidClientScope(),
-			"keycloak_ldap_user_federation":                              resourceKeycloakLdapUserFederation(),
-			"keycloak_ldap_user_attribute_mapper":                        resourceKeycloakLdapUserAttributeMapper(),
-			"keycloak_ldap_group_mapper":                                 resourceKeycloakLdapGroupMapper(),
-			"keycloak_ldap_role_mapper":                                  resourceKeycloakLdapRoleMapper(),
-			"keycloak_ldap_hardcoded_role_mapper":                        resourceKeycloakLdapHardcodedRoleMapper(),
-			"keycloak_ldap_hardcoded_attribute_mapper":                   resourceKeycloakLdapHardcodedAttributeMapper(),
-			"keycloak_ldap_hardcoded_group_mapper":                       resourceKeycloakLdapHardcodedGroupMapper(),
-			"keycloak_ldap_msad_user_account_control_mapper":             resourceKeycloakLdapMsadUserAccountControlMapper(),
-			"keycloak_ldap_msad_lds_user_account_control_mapper":         resourceKeycloakLdapMsadLdsUserAccountControlMapper(),
-			"keycloak_ldap_full_name_mapper":                             resourceKeycloakLdapFullNameMapper(),
-			"keycloak_ldap_custom_mapper":                                resourceKeycloakLdapCustomMapper(),
-			"keycloak_custom_user_federation":                            resourceKeycloakCustomUserFederation(),
-			"keycloak_openid_user_attribute_protocol_mapper":             resourceKeycloakOpenIdUserAttributeProtocolMapper(),
-			"keycloak_openid_user_property_protocol_mapper":              resourceKeycloakOpenIdUserPropertyProtocolMapper(),
-			"keycloak_openid_group_membership_protocol_mapper":           resourceKeycloakOpenIdGroupMembershipProtocolMapper(),
-			"keycloak_openid_full_name_protocol_mapper":                  resourceKeycloakOpenIdFullNameProtocolMapper(),
-			"keycloak_openid_hardcoded_claim_protocol_mapper":            resourceKeycloakOpenIdHardcodedClaimProtocolMapper(),
-			"keycloak_openid_audience_protocol_mapper":                   resourceKeycloakOpenIdAudienceProtocolMapper(),
-			"keycloak_openid_audience_resolve_protocol_mapper":           resourceKeycloakOpenIdAudienceResolveProtocolMapper(),
-			"keycloak_openid_hardcoded_role_protocol_mapper":             resourceKeycloakOpenIdHardcodedRoleProtocolMapper(),
-			"keycloak_openid_user_realm_role_protocol_mapper":            resourceKeycloakOpenIdUserRealmRoleProtocolMapper(),
-			"keycloak_openid_user_client_role_protocol_mapper":           resourceKeycloakOpenIdUserClientRoleProtocolMapper(),
-			"keycloak_openid_user_session_note_protocol_mapper":          resourceKeycloakOpenIdUserSessionNoteProtocolMapper(),
-			"keycloak_openid_script_protocol_mapper":                     resourceKeycloakOpenIdScriptProtocolMapper(),
-			"keycloak_openid_client_default_scopes":                      resourceKeycloakOpenidClientDefaultScopes(),
-			"keycloak_openid_client_optional_scopes":                     resourceKeycloakOpenidClientOptionalScopes(),
-			"keycloak_saml_client":                                       resourceKeycloakSamlClient(),
-			"keycloak_saml_client_scope":                                 resourceKeycloakSamlClientScope(),
-			"keycloak_saml_client_default_scopes":                        resourceKeycloakSamlClientDefaultScopes(),
-			"keycloak_generic_client_protocol_mapper":                    resourceKeycloakGenericClientProtocolMapper(),
-			"keycloak_generic_client_role_mapper":                        resourceKeycloakGenericClientRoleMapper(),
-			"keycloak_generic_protocol_mapper":                           resourceKeycloakGenericProtocolMapper(),
-			"keycloak_generic_role_mapper":                               resourceKeycloakGenericRoleMapper(),
-			"keycloak_saml_user_attribute_protocol_mapper":               resourceKeycloakSamlUserAttributeProtocolMapper(),
-			"keycloak_saml_user_property_protocol_mapper":                resourceKeycloakSamlUserPropertyProtocolMapper(),
-			"keycloak_saml_script_protocol_mapper":                       resourceKeycloakSamlScriptProtocolMapper(),
-			"keycloak_hardcoded_attribute_identity_provider_mapper":      resourceKeycloakHardcodedAttributeIdentityProviderMapper(),
-			"keycloak_hardcoded_role_identity_provider_mapper":           resourceKeycloakHardcodedRoleIdentityProviderMapper(),
-			"keycloak_attribute_importer_identity_provider_mapper":       resourceKeycloakAttributeImporterIdentityProviderMapper(),
-			"keycloak_attribute_to_role_identity_provider_mapper":        resourceKeycloakAttributeToRoleIdentityProviderMapper(),
-			"keycloak_user_template_importer_identity_provider_mapper":   resourceKeycloakUserTemplateImporterIdentityProviderMapper(),
-			"keycloak_custom_identity_provider_mapper":                   resourceKeycloakCustomIdentityProviderMapper(),
-			"keycloak_saml_identity_provider":                            resourceKeycloakSamlIdentityProvider(),
-			"keycloak_oidc_google_identity_provider":                     resourceKeycloakOidcGoogleIdentityProvider(),
-			"keycloak_oidc_identity_provider":                            resourceKeycloakOidcIdentityProvider(),
-			"keycloak_openid_client_authorization_resource":              resourceKeycloakOpenidClientAuthorizationResource(),
-			"keycloak_openid_client_group_policy":                        resourceKeycloakOpenidClientAuthorizationGroupPolicy(),
-			"keycloak_openid_client_role_policy":                         resourceKeycloakOpenidClientAuthorizationRolePolicy(),
-			"keycloak_openid_client_aggregate_policy":                    resourceKeycloakOpenidClientAuthorizationAggregatePolicy(),
-			"keycloak_openid_client_js_policy":                           resourceKeycloakOpenidClientAuthorizationJSPolicy(),
-			"keycloak_openid_client_time_policy":                         resourceKeycloakOpenidClientAuthorizationTimePolicy(),
-			"keycloak_openid_client_user_policy":                         resourceKeycloakOpenidClientAuthorizationUserPolicy(),
-			"keycloak_openid_client_client_policy":                       resourceKeycloakOpenidClientAuthorizationClientPolicy(),
-			"keycloak_openid_client_authorization_scope":                 resourceKeycloakOpenidClientAuthorizationScope(),
-			"keycloak_openid_client_authorization_permission":            resourceKeycloakOpenidClientAuthorizationPermission(),
-			"keycloak_openid_client_service_account_role":                resourceKeycloakOpenidClientServiceAccountRole(),
-			"keycloak_openid_client_service_account_realm_role":          resourceKeycloakOpenidClientServiceAccountRealmRole(),
-			"keycloak_role":                                              resourceKeycloakRole(),
-			"keycloak_authentication_flow":                               resourceKeycloakAuthenticationFlow(),
-			"keycloak_authentication_subflow":                            resourceKeycloakAuthenticationSubFlow(),
-			"keycloak_authentication_execution":                          resourceKeycloakAuthenticationExecution(),
-			"keycloak_authentication_execution_config":                   resourceKeycloakAuthenticationExecutionConfig(),
-			"keycloak_identity_provider_token_exchange_scope_permission": resourceKeycloakIdentityProviderTokenExchangeScopePermission(),
-			"keycloak_openid_client_permissions":                         resourceKeycloakOpenidClientPermissions(),
-			"keycloak_users_permissions":                                 resourceKeycloakUsersPermissions(),
-			"keycloak_user_groups":                                       resourceKeycloakUserGroups(),
-			"keycloak_group_permissions":                                 resourceKeycloakGroupPermissions(),
-			"keycloak_authentication_bindings":                           resourceKeycloakAuthenticationBindings(),
+			"keycloak_realm":                                               resourceKeycloakRealm(),
+			"keycloak_realm_events":                                        resourceKeycloakRealmEvents(),
+			"keycloak_realm_default_client_scopes":                         resourceKeycloakRealmDefaultClientScopes(),
+			"keycloak_realm_optional_client_scopes":                        resourceKeycloakRealmOptionalClientScopes(),
+			"keycloak_realm_key_rotation":                                  resourceKeycloakRealmKeyRotation(),
+			"keycloak_realm_keystore_aes_generated":                        resourceKeycloakRealmKeystoreAesGenerated(),
+			"keycloak_realm_keystore_ecdh_generated":                       resourceKeycloakRealmKeystoreEcdhGenerated(),
+			"keycloak_realm_keystore_ecdsa_generated":                      resourceKeycloakRealmKeystoreEcdsaGenerated(),
+			"keycloak_realm_keystore_eddsa_generated":                      resourceKeycloakRealmKeystoreEddsaGenerated(),
+			"keycloak_realm_keystore_hmac_generated":                       resourceKeycloakRealmKeystoreHmacGenerated(),
+			"keycloak_realm_keystore_java_keystore":                        resourceKeycloakRealmKeystoreJavaKeystore(),
+			"keycloak_realm_keystore_pkcs12":                               resourceKeycloakRealmKeystorePkcs12(),
+			"keycloak_realm_keystore_rsa":                                  resourceKeycloakRealmKeystoreRsa(),
+			"keycloak_realm_keystore_rsa_enc_generated":                    resourceKeycloakRealmKeystoreRsaEncGenerated(),
+			"keycloak_realm_keystore_rsa_generated":                        resourceKeycloakRealmKeystoreRsaGenerated(),
+			"keycloak_realm_partial_import":                                resourceKeycloakRealmPartialImport(),
+			"keycloak_realm_localization":                                  resourceKeycloakRealmLocalization(),
+			"keycloak_realm_user_profile":                                  resourceKeycloakRealmUserProfile(),
+			"keycloak_realm_user_profile_attribute":                        resourceKeycloakRealmUserProfileAttribute(),
+			"keycloak_realm_user_profile_group":                            resourceKeycloakRealmUserProfileGroup(),
+			"keycloak_realm_client_policies":                               resourceKeycloakRealmClientPolicies(),
+			"keycloak_realm_client_profiles":                               resourceKeycloakRealmClientProfiles(),
+			"keycloak_required_action":                                     resourceKeycloakRequiredAction(),
+			"keycloak_organization":                                        resourceKeycloakOrganization(),
+			"keycloak_organization_members":                                resourceKeycloakOrganizationMembers(),
+			"keycloak_organization_identity_provider":                      resourceKeycloakOrganizationIdentityProvider(),
+			"keycloak_group":                                               resourceKeycloakGroup(),
+			"keycloak_group_memberships":                                   resourceKeycloakGroupMemberships(),
+			"keycloak_default_groups":                                      resourceKeycloakDefaultGroups(),
+			"keycloak_default_roles":                                       resourceKeycloakDefaultRoles(),
+			"keycloak_group_roles":                                         resourceKeycloakGroupRoles(),
+			"keycloak_user":                                                resourceKeycloakUser(),
+			"keycloak_user_roles":                                          resourceKeycloakUserRoles(),
+			"keycloak_openid_client":                                       resourceKeycloakOpenidClient(),
+			"keycloak_openid_client_scope":                                 resourceKeycloakOpenidClientScope(),
+			"keycloak_client_initial_access_token":                         resourceKeycloakClientInitialAccessToken(),
+			"keycloak_client_registration_trusted_hosts_policy":            resourceKeycloakClientRegistrationTrustedHostsPolicy(),
+			"keycloak_client_registration_max_clients_policy":              resourceKeycloakClientRegistrationMaxClientsPolicy(),
+			"keycloak_client_registration_allowed_client_scopes_policy":    resourceKeycloakClientRegistrationAllowedClientScopesPolicy(),
+			"keycloak_client_registration_allowed_protocol_mappers_policy": resourceKeycloakClientRegistrationAllowedProtocolMappersPolicy(),
+			"keycloak_client_registration_consent_required_policy":         resourceKeycloakClientRegistrationConsentRequiredPolicy(),
+			"keycloak_ldap_user_federation":                                resourceKeycloakLdapUserFederation(),
+			"keycloak_ldap_user_attribute_mapper":                          resourceKeycloakLdapUserAttributeMapper(),
+			"keycloak_ldap_group_mapper":                                   resourceKeycloakLdapGroupMapper(),
+			"keycloak_ldap_role_mapper":                                    resourceKeycloakLdapRoleMapper(),
+			"keycloak_ldap_hardcoded_role_mapper":                          resourceKeycloakLdapHardcodedRoleMapper(),
+			"keycloak_ldap_hardcoded_attribute_mapper":                     resourceKeycloakLdapHardcodedAttributeMapper(),
+			"keycloak_ldap_hardcoded_group_mapper":                         resourceKeycloakLdapHardcodedGroupMapper(),
+			"keycloak_ldap_msad_user_account_control_mapper":               resourceKeycloakLdapMsadUserAccountControlMapper(),
+			"keycloak_ldap_msad_lds_user_account_control_mapper":           resourceKeycloakLdapMsadLdsUserAccountControlMapper(),
+			"keycloak_ldap_full_name_mapper":                               resourceKeycloakLdapFullNameMapper(),
+			"keycloak_ldap_custom_mapper":                                  resourceKeycloakLdapCustomMapper(),
+			"keycloak_custom_user_federation":                              resourceKeycloakCustomUserFederation(),
+			"keycloak_openid_user_attribute_protocol_mapper":               resourceKeycloakOpenIdUserAttributeProtocolMapper(),
+			"keycloak_openid_user_property_protocol_mapper":                resourceKeycloakOpenIdUserPropertyProtocolMapper(),
+			"keycloak_openid_group_membership_protocol_mapper":             resourceKeycloakOpenIdGroupMembershipProtocolMapper(),
+			"keycloak_openid_full_name_protocol_mapper":                    resourceKeycloakOpenIdFullNameProtocolMapper(),
+			"keycloak_openid_hardcoded_claim_protocol_mapper":              resourceKeycloakOpenIdHardcodedClaimProtocolMapper(),
+			"keycloak_openid_audience_protocol_mapper":                     resourceKeycloakOpenIdAudienceProtocolMapper(),
+			"keycloak_openid_audience_resolve_protocol_mapper":             resourceKeycloakOpenIdAudienceResolveProtocolMapper(),
+			"keycloak_openid_hardcoded_role_protocol_mapper":               resourceKeycloakOpenIdHardcodedRoleProtocolMapper(),
+			"keycloak_openid_user_realm_role_protocol_mapper":              resourceKeycloakOpenIdUserRealmRoleProtocolMapper(),
+			"keycloak_openid_user_client_role_protocol_mapper":             resourceKeycloakOpenIdUserClientRoleProtocolMapper(),
+			"keycloak_openid_user_session_note_protocol_mapper":            resourceKeycloakOpenIdUserSessionNoteProtocolMapper(),
+			"keycloak_openid_script_protocol_mapper":                       resourceKeycloakOpenIdScriptProtocolMapper(),
+			"keycloak_openid_client_default_scopes":                        resourceKeycloakOpenidClientDefaultScopes(),
+			"keycloak_openid_client_optional_scopes":                       resourceKeycloakOpenidClientOptionalScopes(),
+			"keycloak_saml_client":                                         resourceKeycloakSamlClient(),
+			"keycloak_saml_client_scope":                                   resourceKeycloakSamlClientScope(),
+			"keycloak_saml_client_default_scopes":                          resourceKeycloakSamlClientDefaultScopes(),
+			"keycloak_generic_client_protocol_mapper":                      resourceKeycloakGenericClientProtocolMapper(),
+			"keycloak_generic_client_role_mapper":                          resourceKeycloakGenericClientRoleMapper(),
+			"keycloak_generic_protocol_mapper":                             resourceKeycloakGenericProtocolMapper(),
+			"keycloak_generic_role_mapper":                                 resourceKeycloakGenericRoleMapper(),
+			"keycloak_saml_user_attribute_protocol_mapper":                 resourceKeycloakSamlUserAttributeProtocolMapper(),
+			"keycloak_saml_user_property_protocol_mapper":                  resourceKeycloakSamlUserPropertyProtocolMapper(),
+			"keycloak_saml_script_protocol_mapper":                         resourceKeycloakSamlScriptProtocolMapper(),
+			"keycloak_hardcoded_attribute_identity_provider_mapper":        resourceKeycloakHardcodedAttributeIdentityProviderMapper(),
+			"keycloak_hardcoded_role_identity_provider_mapper":             resourceKeycloakHardcodedRoleIdentityProviderMapper(),
+			"keycloak_attribute_importer_identity_provider_mapper":         resourceKeycloakAttributeImporterIdentityProviderMapper(),
+			"keycloak_attribute_to_role_identity_provider_mapper":          resourceKeycloakAttributeToRoleIdentityProviderMapper(),
+			"keycloak_user_template_importer_identity_provider_mapper":     resourceKeycloakUserTemplateImporterIdentityProviderMapper(),
+			"keycloak_custom_identity_provider_mapper":                     resourceKeycloakCustomIdentityProviderMapper(),
+			"keycloak_saml_identity_provider":                              resourceKeycloakSamlIdentityProvider(),
+			"keycloak_oidc_google_identity_provider":                       resourceKeycloakOidcGoogleIdentityProvider(),
+			"keycloak_oidc_identity_provider":                              resourceKeycloakOidcIdentityProvider(),
+			"keycloak_openid_client_authorization_resource":                resourceKeycloakOpenidClientAuthorizationResource(),
+			"keycloak_openid_client_group_policy":                          resourceKeycloakOpenidClientAuthorizationGroupPolicy(),
+			"keycloak_openid_client_role_policy":                           resourceKeycloakOpenidClientAuthorizationRolePolicy(),
+			"keycloak_openid_client_aggregate_policy":                      resourceKeycloakOpenidClientAuthorizationAggregatePolicy(),
+			"keycloak_openid_client_js_policy":                             resourceKeycloakOpenidClientAuthorizationJSPolicy(),
+			"keycloak_openid_client_time_policy":                           resourceKeycloakOpenidClientAuthorizationTimePolicy(),
+			"keycloak_openid_client_user_policy":                           resourceKeycloakOpenidClientAuthorizationUserPolicy(),
+			"keycloak_openid_client_client_policy":                         resourceKeycloakOpenidClientAuthorizationClientPolicy(),
+			"keycloak_openid_client_authorization_scope":                   resourceKeycloakOpenidClientAuthorizationScope(),
+			"keycloak_openid_client_authorization_permission":              resourceKeycloakOpenidClientAuthorizationPermission(),
+			"keycloak_openid_client_service_account_role":                  resourceKeycloakOpenidClientServiceAccountRole(),
+			"keycloak_openid_client_service_account_realm_role":            resourceKeycloakOpenidClientServiceAccountRealmRole(),
+			"keycloak_role":                                                resourceKeycloakRole(),
+			"keycloak_authentication_flow":                                 resourceKeycloakAuthenticationFlow(),
+			"keycloak_authentication_subflow":                              resourceKeycloakAuthenticationSubFlow(),
+			"keycloak_authentication_execution":                            resourceKeycloakAuthenticationExecution(),
+			"keycloak_authentication_execution_config":                     resourceKeycloakAuthenticationExecutionConfig(),
+			"keycloak_identity_provider_token_exchange_scope_permission":   resourceKeycloakIdentityProviderTokenExchangeScopePermission(),
+			"keycloak_openid_client_permissions":                           resourceKeycloakOpenidClientPermissions(),
+			"keycloak_users_permissions":                                   resourceKeycloakUsersPermissions(),
+			"keycloak_user_groups":                                         resourceKeycloakUserGroups(),
+			"keycloak_group_permissions":                                   resourceKeycloakGroupPermissions(),
+			"keycloak_authentication_bindings":                             resourceKeycloakAuthenticationBindings(),
 		},
 		Schema: map[string]*schema.Schema{
 			"client_id": {
-				Required:    true,
+				Optional:    true,
 				Type:        schema.TypeString,
 				DefaultFunc: schema.EnvDefaultFunc("KEYCLOAK_CLIENT_ID", nil),
 			},
@@ -129,6 +157,71 @@ func KeycloakProvider(client *keycloak.KeycloakClient) *schema.Provider {
 				Type:        schema.TypeString,
 				DefaultFunc: schema.EnvDefaultFunc("KEYCLOAK_CLIENT_SECRET", nil),
 			},
+			"client_authenticator": {
+				Optional:     true,
+				Type:         schema.TypeString,
+				Description:  "How the client authenticates itself when requesting a token. One of `client-secret`, `client-jwt`, `client-secret-jwt` or `client-x509`",
+				DefaultFunc:  schema.EnvDefaultFunc("KEYCLOAK_CLIENT_AUTHENTICATOR", keycloak.ClientAuthenticatorClientSecret),
+				ValidateFunc: validation.StringInSlice(keycloak.ClientAuthenticators, false),
+			},
+			"jwt_signing_key": {
+				Optional:    true,
+				Type:        schema.TypeString,
+				Sensitive:   true,
+				Description: "The PEM encoded private key used to sign client assertions when `client_authenticator` is `client-jwt`",
+				DefaultFunc: schema.EnvDefaultFunc("KEYCLOAK_JWT_SIGNING_KEY", ""),
+			},
+			"jwt_signing_key_id": {
+				Optional:    true,
+				Type:        schema.TypeString,
+				Description: "The key ID (`kid`) included in the header of signed client assertions",
+				DefaultFunc: schema.EnvDefaultFunc("KEYCLOAK_JWT_SIGNING_KEY_ID", ""),
+			},
+			"jwt_signing_alg": {
+				Optional:    true,
+				Type:        schema.TypeString,
+				Description: "The algorithm used to sign client assertions. Defaults to `RS256` or `ES256` for `client-jwt`, depending on the key, and `HS256` for `client-secret-jwt`",
+				DefaultFunc: schema.EnvDefaultFunc("KEYCLOAK_JWT_SIGNING_ALG", ""),
+			},
+			"access_token": {
+				Optional:    true,
+				Type:        schema.TypeString,
+				Sensitive:   true,
+				Description: "A pre-issued access token. When set, the provider does not log in, and the token cannot be refreshed",
+				DefaultFunc: schema.EnvDefaultFunc("KEYCLOAK_ACCESS_TOKEN", ""),
+			},
+			"subject_token": {
+				Optional:    true,
+				Type:        schema.TypeString,
+				Sensitive:   true,
+				Description: "A token issued by another identity provider, which is exchanged for an access token during login",
+				DefaultFunc: schema.EnvDefaultFunc("KEYCLOAK_SUBJECT_TOKEN", ""),
+			},
+			"subject_token_file": {
+				Optional:    true,
+				Type:        schema.TypeString,
+				Description: "The path to a file containing the subject token. The file is read again whenever the provider logs in, so rotated tokens are picked up",
+				DefaultFunc: schema.EnvDefaultFunc("KEYCLOAK_SUBJECT_TOKEN_FILE", ""),
+			},
+			"subject_token_grant": {
+				Optional:     true,
+				Type:         schema.TypeString,
+				Description:  "The grant used to log in with the subject token. One of `token-exchange` or `jwt-bearer`",
+				DefaultFunc:  schema.EnvDefaultFunc("KEYCLOAK_SUBJECT_TOKEN_GRANT", keycloak.SubjectTokenGrantTokenExchange),
+				ValidateFunc: validation.StringInSlice(keycloak.SubjectTokenGrants, false),
+			},
+			"subject_token_type": {
+				Optional:    true,
+				Type:        schema.TypeString,
+				Description: "The type of the subject token sent in a token exchange",
+				DefaultFunc: schema.EnvDefaultFunc("KEYCLOAK_SUBJECT_TOKEN_TYPE", keycloak.DefaultSubjectTokenType),
+			},
+			"subject_issuer": {
+				Optional:    true,
+				Type:        schema.TypeString,
+				Description: "The alias of the identity provider that issued the subject token, for exchanging tokens issued outside of Keycloak",
+				DefaultFunc: schema.EnvDefaultFunc("KEYCLOAK_SUBJECT_ISSUER", ""),
+			},
 			"username": {
 				Optional:    true,
 				Type:        schema.TypeString,
@@ -162,6 +255,43 @@ func KeycloakProvider(client *keycloak.KeycloakClient) *schema.Provider {
 				Description: "Timeout (in seconds) of the Keycloak client",
 				DefaultFunc: schema.EnvDefaultFunc("KEYCLOAK_CLIENT_TIMEOUT", 15),
 			},
+			"max_retries": {
+				Optional:     true,
+				Type:         schema.TypeInt,
+				Description:  "The maximum number of times a failed request is retried",
+				DefaultFunc:  schema.EnvDefaultFunc("KEYCLOAK_MAX_RETRIES", keycloak.DefaultMaxRetries),
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"min_backoff": {
+				Optional:     true,
+				Type:         schema.TypeInt,
+				Description:  "The minimum time (in seconds) to wait before retrying a request",
+				DefaultFunc:  schema.EnvDefaultFunc("KEYCLOAK_MIN_BACKOFF", int(keycloak.DefaultMinBackoff/time.Second)),
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"max_backoff": {
+				Optional:     true,
+				Type:         schema.TypeInt,
+				Description:  "The maximum time (in seconds) to wait before retrying a request",
+				DefaultFunc:  schema.EnvDefaultFunc("KEYCLOAK_MAX_BACKOFF", int(keycloak.DefaultMaxBackoff/time.Second)),
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"retryable_status_codes": {
+				Optional:    true,
+				Type:        schema.TypeSet,
+				Description: "The HTTP status codes that cause a request to be retried. Defaults to 429, 500, 502, 503 and 504",
+				Elem: &schema.Schema{
+					Type:         schema.TypeInt,
+					ValidateFunc: validation.IntBetween(400, 599),
+				},
+			},
+			"requests_per_second": {
+				Optional:     true,
+				Type:         schema.TypeFloat,
+				Description:  "The maximum number of requests per second sent to Keycloak, shared by all resources. Unlimited when 0",
+				DefaultFunc:  schema.EnvDefaultFunc("KEYCLOAK_REQUESTS_PER_SECOND", 0.0),
+				ValidateFunc: validation.FloatAtLeast(0),
+			},
 			"root_ca_certificate": {
 				Optional:    true,
 				Type:        schema.TypeString,
@@ -174,10 +304,23 @@ func KeycloakProvider(client *keycloak.KeycloakClient) *schema.Provider {
 				Description: "Allows ignoring insecure certificates when set to true. Defaults to false. Disabling security check is dangerous and should be avoided.",
 				Default:     false,
 			},
+			"tls_client_certificate": {
+				Optional:    true,
+				Type:        schema.TypeString,
+				Description: "The PEM encoded client certificate used for mutual TLS",
+				DefaultFunc: schema.EnvDefaultFunc("KEYCLOAK_TLS_CLIENT_CERTIFICATE", ""),
+			},
+			"tls_client_private_key": {
+				Optional:    true,
+				Type:        schema.TypeString,
+				Sensitive:   true,
+				Description: "The PEM encoded private key of the client certificate used for mutual TLS",
+				DefaultFunc: schema.EnvDefaultFunc("KEYCLOAK_TLS_CLIENT_PRIVATE_KEY", ""),
+			},
 			"red_hat_sso": {
 				Optional:    true,
 				Type:        schema.TypeBool,
-				Description: "When true, the provider will treat the Keycloak instance as a Red Hat SSO server, specifically when parsing the version returned from the /serverinfo API endpoint.",
+				Description: "When true, the provider will treat the Keycloak instance as a Red Hat SSO server, specifically when parsing the version returned from the /serverinfo API endpoint. Versions of Red Hat SSO 7 ending in .GA are detected without it.",
 				Default:     false,
 			},
 			"base_path": {
@@ -192,6 +335,12 @@ func KeycloakProvider(client *keycloak.KeycloakClient) *schema.Provider {
 					Type: schema.TypeString,
 				},
 			},
+			"additional_redacted_fields": {
+				Optional:    true,
+				Type:        schema.TypeSet,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Names of additional form fields, JSON attributes or headers whose values are masked in debug logs, on top of the built-in list of secrets",
+			},
 		},
 	}
 
@@ -204,6 +353,16 @@ func KeycloakProvider(client *keycloak.KeycloakClient) *schema.Provider {
 		basePath := data.Get("base_path").(string)
 		clientId := data.Get("client_id").(string)
 		clientSecret := data.Get("client_secret").(string)
+		clientAuthenticator := data.Get("client_authenticator").(string)
+		jwtSigningKey := data.Get("jwt_signing_key").(string)
+		jwtSigningKeyId := data.Get("jwt_signing_key_id").(string)
+		jwtSigningAlg := data.Get("jwt_signing_alg").(string)
+		accessToken := data.Get("access_token").(string)
+		subjectToken := data.Get("subject_token").(string)
+		subjectTokenFile := data.Get("subject_token_file").(string)
+		subjectTokenGrant := data.Get("subject_token_grant").(string)
+		subjectTokenType := data.Get("subject_token_type").(string)
+		subjectIssuer := data.Get("subject_issuer").(string)
 		username := data.Get("username").(string)
 		password := data.Get("password").(string)
 		realm := data.Get("realm").(string)
@@ -211,17 +370,68 @@ func KeycloakProvider(client *keycloak.KeycloakClient) *schema.Provider {
 		clientTimeout := data.Get("client_timeout").(int)
 		tlsInsecureSkipVerify := data.Get("tls_insecure_skip_verify").(bool)
 		rootCaCertificate := data.Get("root_ca_certificate").(string)
+		tlsClientCertificate := data.Get("tls_client_certificate").(string)
+		tlsClientPrivateKey := data.Get("tls_client_private_key").(string)
+		maxRetries := data.Get("max_retries").(int)
+		minBackoff := time.Second * time.Duration(data.Get("min_backoff").(int))
+		maxBackoff := time.Second * time.Duration(data.Get("max_backoff").(int))
+		requestsPerSecond := data.Get("requests_per_second").(float64)
+		retryableStatusCodes := keycloak.DefaultRetryableStatusCodes
+		if v, ok := data.GetOk("retryable_status_codes"); ok {
+			retryableStatusCodes = nil
+			for _, statusCode := range v.(*schema.Set).List() {
+				retryableStatusCodes = append(retryableStatusCodes, statusCode.(int))
+			}
+		}
 		redHatSSO := data.Get("red_hat_sso").(bool)
 		additionalHeaders := make(map[string]string)
 		for k, v := range data.Get("additional_headers").(map[string]interface{}) {
 			additionalHeaders[k] = v.(string)
 		}
 
+		var additionalRedactedFields []string
+		for _, field := range data.Get("additional_redacted_fields").(*schema.Set).List() {
+			additionalRedactedFields = append(additionalRedactedFields, field.(string))
+		}
+
 		var diags diag.Diagnostics
 
 		userAgent := fmt.Sprintf("HashiCorp Terraform/%s (+https://www.terraform.io) Terraform Plugin SDK/%s", provider.TerraformVersion, meta.SDKVersionString())
 
-		keycloakClient, err := keycloak.NewKeycloakClient(ctx, url, basePath, clientId, clientSecret, realm, username, password, initialLogin, clientTimeout, rootCaCertificate, tlsInsecureSkipVerify, userAgent, redHatSSO, additionalHeaders)
+		keycloakClient, err := keycloak.NewKeycloakClient(ctx, keycloak.KeycloakClientConfig{
+			Url:                      url,
+			BasePath:                 basePath,
+			ClientId:                 clientId,
+			ClientSecret:             clientSecret,
+			ClientAuthenticator:      clientAuthenticator,
+			JwtSigningAlg:            jwtSigningAlg,
+			JwtSigningKey:            jwtSigningKey,
+			JwtSigningKeyId:          jwtSigningKeyId,
+			Realm:                    realm,
+			Username:                 username,
+			Password:                 password,
+			AccessToken:              accessToken,
+			SubjectTokenGrant:        subjectTokenGrant,
+			SubjectToken:             subjectToken,
+			SubjectTokenFile:         subjectTokenFile,
+			SubjectTokenType:         subjectTokenType,
+			SubjectIssuer:            subjectIssuer,
+			InitialLogin:             initialLogin,
+			ClientTimeout:            clientTimeout,
+			CaCert:                   rootCaCertificate,
+			TlsInsecureSkipVerify:    tlsInsecureSkipVerify,
+			TlsClientCert:            tlsClientCertificate,
+			TlsClientPrivateKey:      tlsClientPrivateKey,
+			MaxRetries:               maxRetries,
+			MinBackoff:               minBackoff,
+			MaxBackoff:               maxBackoff,
+			RetryableStatusCodes:     retryableStatusCodes,
+			RequestsPerSecond:        requestsPerSecond,
+			UserAgent:                userAgent,
+			RedHatSSO:                redHatSSO,
+			AdditionalHeaders:        additionalHeaders,
+			AdditionalRedactedFields: additionalRedactedFields,
+		})
 		if err != nil {
 			diags = append(diags, diag.Diagnostic{
 				Severity: diag.Error,
@@ -4,10 +4,12 @@ import (
 	"context"
 	"fmt"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/meta"
 	"github.com/keycloak/terraform-provider-keycloak/keycloak"
 	"os"
+	"strings"
 	"testing"
 )
 
@@ -18,6 +20,10 @@ var testAccRealm *keycloak.Realm
 var testAccRealmTwo *keycloak.Realm
 var testAccRealmUserFederation *keycloak.Realm
 var testCtx context.Context
+var cassetteRecorder *keycloak.CassetteRecorder
+
+// testCassetteDir holds the cassettes recorded with KEYCLOAK_CASSETTE_MODE=record, one per acceptance test
+const testCassetteDir = "testdata/cassettes"
 
 var requiredEnvironmentVariables = []string{
 	"KEYCLOAK_CLIENT_ID",
@@ -28,10 +34,40 @@ var requiredEnvironmentVariables = []string{
 
 func init() {
 	testCtx = context.Background()
+
+	// with cassettes, the client logs in lazily so that the login is recorded to (or replayed from) the first cassette
+	initialLogin := true
+	keycloakUrl := os.Getenv("KEYCLOAK_URL")
+	if cassetteMode := os.Getenv("KEYCLOAK_CASSETTE_MODE"); cassetteMode != "" {
+		cassetteRecorder, _ = keycloak.NewCassetteRecorder(testCassetteDir, cassetteMode)
+		initialLogin = false
+
+		// replayed requests are matched by their path, so the host doesn't matter
+		if cassetteMode == keycloak.CassetteModeReplay && keycloakUrl == "" {
+			keycloakUrl = "http://localhost:8080"
+		}
+	}
+
 	userAgent := fmt.Sprintf("HashiCorp Terraform/%s (+https://www.terraform.io) Terraform Plugin SDK/%s", schema.Provider{}.TerraformVersion, meta.SDKVersionString())
-	keycloakClient, _ = keycloak.NewKeycloakClient(testCtx, os.Getenv("KEYCLOAK_URL"), "", os.Getenv("KEYCLOAK_CLIENT_ID"), os.Getenv("KEYCLOAK_CLIENT_SECRET"), os.Getenv("KEYCLOAK_REALM"), "", "", true, 5, "", false, userAgent, false, map[string]string{
-		"foo": "bar",
+	keycloakClient, _ = keycloak.NewKeycloakClient(testCtx, keycloak.KeycloakClientConfig{
+		Url:                  keycloakUrl,
+		ClientId:             os.Getenv("KEYCLOAK_CLIENT_ID"),
+		ClientSecret:         os.Getenv("KEYCLOAK_CLIENT_SECRET"),
+		Realm:                os.Getenv("KEYCLOAK_REALM"),
+		InitialLogin:         initialLogin,
+		ClientTimeout:        5,
+		MaxRetries:           keycloak.DefaultMaxRetries,
+		MinBackoff:           keycloak.DefaultMinBackoff,
+		MaxBackoff:           keycloak.DefaultMaxBackoff,
+		RetryableStatusCodes: keycloak.DefaultRetryableStatusCodes,
+		UserAgent:            userAgent,
+		AdditionalHeaders: map[string]string{
+			"foo": "bar",
+		},
 	})
+	if keycloakClient != nil && cassetteRecorder != nil {
+		keycloakClient.UseCassetteRecorder(cassetteRecorder)
+	}
 	testAccProvider = KeycloakProvider(keycloakClient)
 	testAccProviderFactories = map[string]func() (*schema.Provider, error){
 		"keycloak": func() (*schema.Provider, error) {
@@ -41,12 +77,26 @@ func init() {
 }
 
 func TestMain(m *testing.M) {
+	// unit tests run against the fake admin api in keycloaktest, so there is nothing to set up without TF_ACC
+	if os.Getenv(resource.EnvTfAcc) == "" {
+		os.Exit(m.Run())
+	}
+
+	if os.Getenv("KEYCLOAK_CASSETTE_MODE") != "" && cassetteRecorder == nil {
+		fmt.Printf("KEYCLOAK_CASSETTE_MODE must be one of %s\n", strings.Join(keycloak.CassetteModes, ", "))
+		os.Exit(1)
+	}
+
+	startTestMainCassette("TestMain")
 	testAccRealm = createTestRealm(testCtx)
 	testAccRealmTwo = createTestRealm(testCtx)
 	testAccRealmUserFederation = createTestRealm(testCtx)
+	stopTestMainCassette()
 
 	code := m.Run()
 
+	startTestMainCassette("TestMainCleanup")
+
 	err := keycloakClient.DeleteRealm(testCtx, testAccRealm.Realm)
 	if err != nil {
 		os.Exit(1)
@@ -62,6 +112,8 @@ func TestMain(m *testing.M) {
 		os.Exit(1)
 	}
 
+	stopTestMainCassette()
+
 	os.Exit(code)
 }
 
@@ -90,9 +142,53 @@ func TestProvider(t *testing.T) {
 }
 
 func testAccPreCheck(t *testing.T) {
+	if cassetteRecorder != nil {
+		useTestCassette(t)
+		// replayed tests don't need a keycloak server, or the credentials for one
+		if cassetteRecorder.Mode() == keycloak.CassetteModeReplay {
+			return
+		}
+	}
+
 	for _, requiredEnvironmentVariable := range requiredEnvironmentVariables {
 		if value := os.Getenv(requiredEnvironmentVariable); value == "" {
 			t.Fatalf("%s must be set before running acceptance tests.", requiredEnvironmentVariable)
 		}
 	}
 }
+
+// useTestCassette records the requests sent during a test to a cassette named after it, or replays them from it. The
+// cassette is shared by the provider and the test's check functions, so tests have to run with -parallel 1.
+func useTestCassette(t *testing.T) {
+	if err := cassetteRecorder.Start(t.Name()); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() {
+		if err := cassetteRecorder.Stop(); err != nil {
+			t.Errorf("failed to save cassette: %s", err)
+		}
+	})
+}
+
+func startTestMainCassette(name string) {
+	if cassetteRecorder == nil {
+		return
+	}
+
+	if err := cassetteRecorder.Start(name); err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+}
+
+func stopTestMainCassette() {
+	if cassetteRecorder == nil {
+		return
+	}
+
+	if err := cassetteRecorder.Stop(); err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+}
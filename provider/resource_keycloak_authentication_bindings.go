@@ -83,24 +83,24 @@ func resourceKeycloakAuthenticationBindingsCreate(ctx context.Context, data *sch
 
 	realm, err := keycloakClient.GetRealm(ctx, data.Get("realm_id").(string))
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	setRealmFlowBindings(data, realm)
 
 	err = keycloakClient.ValidateRealm(ctx, realm)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	err = keycloakClient.UpdateRealm(ctx, realm)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	realm, err = keycloakClient.GetRealm(ctx, realm.Realm)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	setAuthenticationBindingsData(data, realm)
@@ -126,14 +126,14 @@ func resourceKeycloakAuthenticationBindingsDelete(ctx context.Context, data *sch
 
 	realm, err := keycloakClient.GetRealm(ctx, data.Id())
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	resetAuthenticationBindingsForRealm(realm)
 
 	err = keycloakClient.UpdateRealm(ctx, realm)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	return nil
@@ -144,19 +144,19 @@ func resourceKeycloakAuthenticationBindingsUpdate(ctx context.Context, data *sch
 
 	realm, err := keycloakClient.GetRealm(ctx, data.Id())
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	setRealmFlowBindings(data, realm)
 
 	err = keycloakClient.ValidateRealm(ctx, realm)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	err = keycloakClient.UpdateRealm(ctx, realm)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	setAuthenticationBindingsData(data, realm)
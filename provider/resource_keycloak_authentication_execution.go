@@ -82,7 +82,7 @@ func resourceKeycloakAuthenticationExecutionCreate(ctx context.Context, data *sc
 
 	err := keycloakClient.NewAuthenticationExecution(ctx, authenticationExecution)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	mapFromAuthenticationExecutionToData(data, authenticationExecution)
@@ -114,7 +114,7 @@ func resourceKeycloakAuthenticationExecutionUpdate(ctx context.Context, data *sc
 
 	err := keycloakClient.UpdateAuthenticationExecution(ctx, authenticationExecution)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	mapFromAuthenticationExecutionToData(data, authenticationExecution)
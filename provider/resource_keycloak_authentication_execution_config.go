@@ -74,7 +74,7 @@ func resourceKeycloakAuthenticationExecutionConfigCreate(ctx context.Context, da
 
 	id, err := keycloakClient.NewAuthenticationExecutionConfig(ctx, config)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	data.SetId(id)
@@ -108,7 +108,7 @@ func resourceKeycloakAuthenticationExecutionConfigUpdate(ctx context.Context, da
 
 	err := keycloakClient.UpdateAuthenticationExecutionConfig(ctx, config)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	return resourceKeycloakAuthenticationExecutionConfigRead(ctx, data, meta)
@@ -77,7 +77,7 @@ func resourceKeycloakAuthenticationFlowCreate(ctx context.Context, data *schema.
 
 	err := keycloakClient.NewAuthenticationFlow(ctx, authenticationFlow)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	mapFromAuthenticationFlowToData(data, authenticationFlow)
@@ -107,7 +107,7 @@ func resourceKeycloakAuthenticationFlowUpdate(ctx context.Context, data *schema.
 
 	err := keycloakClient.UpdateAuthenticationFlow(ctx, authenticationFlow)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	mapFromAuthenticationFlowToData(data, authenticationFlow)
@@ -96,7 +96,7 @@ func resourceKeycloakAuthenticationSubFlowCreate(ctx context.Context, data *sche
 
 	err := keycloakClient.NewAuthenticationSubFlow(ctx, authenticationFlow)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 	mapFromAuthenticationSubFlowToData(data, authenticationFlow)
 	return resourceKeycloakAuthenticationSubFlowRead(ctx, data, meta)
@@ -124,7 +124,7 @@ func resourceKeycloakAuthenticationSubFlowUpdate(ctx context.Context, data *sche
 
 	err := keycloakClient.UpdateAuthenticationSubFlow(ctx, authenticationFlow)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 	mapFromAuthenticationSubFlowToData(data, authenticationFlow)
 	return nil
@@ -0,0 +1,125 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/keycloak/terraform-provider-keycloak/keycloak"
+)
+
+// resourceKeycloakClientInitialAccessToken can't be imported or updated, keycloak only returns the token when it is
+// created and has no endpoint to change it afterward.
+func resourceKeycloakClientInitialAccessToken() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceKeycloakClientInitialAccessTokenCreate,
+		ReadContext:   resourceKeycloakClientInitialAccessTokenRead,
+		DeleteContext: resourceKeycloakClientInitialAccessTokenDelete,
+		Schema: map[string]*schema.Schema{
+			"realm_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"expiration": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ForceNew:     true,
+				Default:      0,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "Number of seconds the token is valid for, 0 when it doesn't expire.",
+			},
+			"client_count": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ForceNew:     true,
+				Default:      1,
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  "Number of clients that can be registered with the token.",
+			},
+			"remaining_count": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"timestamp": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Time the token was created at, in seconds since the epoch.",
+			},
+			"token": {
+				Type:      schema.TypeString,
+				Computed:  true,
+				Sensitive: true,
+			},
+		},
+	}
+}
+
+func setClientInitialAccessTokenData(data *schema.ResourceData, token *keycloak.ClientInitialAccessToken) {
+	data.SetId(token.Id)
+
+	data.Set("realm_id", token.RealmId)
+	data.Set("expiration", token.Expiration)
+	data.Set("client_count", token.Count)
+	data.Set("remaining_count", token.RemainingCount)
+	data.Set("timestamp", token.Timestamp)
+}
+
+func resourceKeycloakClientInitialAccessTokenCreate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	token := &keycloak.ClientInitialAccessToken{
+		RealmId:    data.Get("realm_id").(string),
+		Expiration: data.Get("expiration").(int),
+		Count:      data.Get("client_count").(int),
+	}
+
+	err := keycloakClient.NewClientInitialAccessToken(ctx, token)
+	if err != nil {
+		return diagnosticsFromError(err, data)
+	}
+
+	setClientInitialAccessTokenData(data, token)
+	data.Set("token", token.Token)
+
+	return resourceKeycloakClientInitialAccessTokenRead(ctx, data, meta)
+}
+
+func resourceKeycloakClientInitialAccessTokenRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	realmId := data.Get("realm_id").(string)
+
+	token, err := keycloakClient.GetClientInitialAccessToken(ctx, realmId, data.Id())
+	if err != nil {
+		return handleNotFoundError(ctx, err, data)
+	}
+
+	// a token that has been used up or has expired is gone, so a new one is created in its place
+	if token == nil {
+		tflog.Warn(ctx, "Removing client initial access token from state as it has been used up or has expired", map[string]interface{}{
+			"id": data.Id(),
+		})
+		data.SetId("")
+		return nil
+	}
+
+	setClientInitialAccessTokenData(data, token)
+
+	return nil
+}
+
+func resourceKeycloakClientInitialAccessTokenDelete(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	realmId := data.Get("realm_id").(string)
+
+	err := keycloakClient.DeleteClientInitialAccessToken(ctx, realmId, data.Id())
+	if err != nil && !keycloak.ErrorIs404(err) {
+		return diagnosticsFromError(err, data)
+	}
+
+	return nil
+}
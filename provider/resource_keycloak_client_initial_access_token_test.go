@@ -0,0 +1,86 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccKeycloakClientInitialAccessToken_basic(t *testing.T) {
+	t.Parallel()
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		PreCheck:          func() { testAccPreCheck(t) },
+		CheckDestroy:      testAccCheckKeycloakClientInitialAccessTokenDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config: testKeycloakClientInitialAccessToken_basic(3),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckKeycloakClientInitialAccessTokenExists("keycloak_client_initial_access_token.token"),
+					resource.TestCheckResourceAttrSet("keycloak_client_initial_access_token.token", "token"),
+					resource.TestCheckResourceAttr("keycloak_client_initial_access_token.token", "remaining_count", "3"),
+				),
+			},
+			{
+				Config: testKeycloakClientInitialAccessToken_basic(5),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckKeycloakClientInitialAccessTokenExists("keycloak_client_initial_access_token.token"),
+					resource.TestCheckResourceAttr("keycloak_client_initial_access_token.token", "remaining_count", "5"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckKeycloakClientInitialAccessTokenExists(resourceName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("resource not found: %s", resourceName)
+		}
+
+		token, err := keycloakClient.GetClientInitialAccessToken(testCtx, rs.Primary.Attributes["realm_id"], rs.Primary.ID)
+		if err != nil {
+			return fmt.Errorf("error getting client initial access token %s: %s", rs.Primary.ID, err)
+		}
+		if token == nil {
+			return fmt.Errorf("client initial access token %s does not exist", rs.Primary.ID)
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckKeycloakClientInitialAccessTokenDestroy() resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		for _, rs := range s.RootModule().Resources {
+			if rs.Type != "keycloak_client_initial_access_token" {
+				continue
+			}
+
+			token, _ := keycloakClient.GetClientInitialAccessToken(testCtx, rs.Primary.Attributes["realm_id"], rs.Primary.ID)
+			if token != nil {
+				return fmt.Errorf("client initial access token %s still exists", rs.Primary.ID)
+			}
+		}
+
+		return nil
+	}
+}
+
+func testKeycloakClientInitialAccessToken_basic(count int) string {
+	return fmt.Sprintf(`
+data "keycloak_realm" "realm" {
+	realm = "%s"
+}
+
+resource "keycloak_client_initial_access_token" "token" {
+	realm_id     = data.keycloak_realm.realm.id
+	expiration   = 3600
+	client_count = %d
+}
+	`, testAccRealm.Realm, count)
+}
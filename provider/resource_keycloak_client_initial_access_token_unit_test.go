@@ -0,0 +1,48 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/keycloak/terraform-provider-keycloak/keycloak"
+)
+
+func TestClientInitialAccessTokenResource(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	keycloakClient := newFakeKeycloakClient(t)
+
+	if err := keycloakClient.NewRealm(ctx, &keycloak.Realm{Realm: "test", Enabled: true}); err != nil {
+		t.Fatal(err)
+	}
+
+	data := schema.TestResourceDataRaw(t, resourceKeycloakClientInitialAccessToken().Schema, map[string]interface{}{
+		"realm_id":     "test",
+		"expiration":   3600,
+		"client_count": 3,
+	})
+	if diags := resourceKeycloakClientInitialAccessTokenCreate(ctx, data, keycloakClient); diags.HasError() {
+		t.Fatal(diags)
+	}
+
+	// the token isn't listed by keycloak, so it has to survive the read after create
+	if data.Get("token").(string) == "" {
+		t.Error("expected the token to be kept in state")
+	}
+	if data.Get("remaining_count").(int) != 3 || data.Get("timestamp").(int) == 0 {
+		t.Errorf("expected the remaining count and timestamp to be read, got %d and %d", data.Get("remaining_count").(int), data.Get("timestamp").(int))
+	}
+
+	if err := keycloakClient.DeleteClientInitialAccessToken(ctx, "test", data.Id()); err != nil {
+		t.Fatal(err)
+	}
+
+	if diags := resourceKeycloakClientInitialAccessTokenRead(ctx, data, keycloakClient); diags.HasError() {
+		t.Fatal(diags)
+	}
+	if data.Id() != "" {
+		t.Errorf("expected a used up token to be removed from state, got id %s", data.Id())
+	}
+}
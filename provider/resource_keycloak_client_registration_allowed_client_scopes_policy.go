@@ -0,0 +1,47 @@
+package provider
+
+import (
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/keycloak/terraform-provider-keycloak/keycloak"
+)
+
+func resourceKeycloakClientRegistrationAllowedClientScopesPolicy() *schema.Resource {
+	policySchema := map[string]*schema.Schema{
+		"allowed_client_scopes": {
+			Type:        schema.TypeList,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+			Optional:    true,
+			Description: "Client scopes that registered clients may use, in addition to the default ones when allow_default_scopes is true.",
+		},
+		"allow_default_scopes": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     true,
+			Description: "When true, registered clients may use the default and optional client scopes of the realm.",
+		},
+	}
+	policyResource := resourceKeycloakClientRegistrationPolicy()
+	policyResource.Schema = mergeSchemas(policyResource.Schema, policySchema)
+	policyResource.CreateContext = resourceKeycloakClientRegistrationPolicyCreate(getClientRegistrationAllowedClientScopesPolicyFromData, setClientRegistrationAllowedClientScopesPolicyData)
+	policyResource.ReadContext = resourceKeycloakClientRegistrationPolicyRead("allowed-client-templates", setClientRegistrationAllowedClientScopesPolicyData)
+	policyResource.UpdateContext = resourceKeycloakClientRegistrationPolicyUpdate(getClientRegistrationAllowedClientScopesPolicyFromData, setClientRegistrationAllowedClientScopesPolicyData)
+	return policyResource
+}
+
+func getClientRegistrationAllowedClientScopesPolicyFromData(data *schema.ResourceData) *keycloak.ClientRegistrationPolicy {
+	// keycloak still calls this policy by the name client scopes had before they replaced client templates
+	policy := getClientRegistrationPolicyFromData(data, "allowed-client-templates")
+
+	policy.Config["allowed-client-scopes"] = interfaceSliceToStringSlice(data.Get("allowed_client_scopes").([]interface{}))
+	policy.Config["allow-default-scopes"] = []string{strconv.FormatBool(data.Get("allow_default_scopes").(bool))}
+
+	return policy
+}
+
+func setClientRegistrationAllowedClientScopesPolicyData(data *schema.ResourceData, policy *keycloak.ClientRegistrationPolicy) {
+	setClientRegistrationPolicyData(data, policy)
+	data.Set("allowed_client_scopes", policy.Config["allowed-client-scopes"])
+	data.Set("allow_default_scopes", getClientRegistrationPolicyConfigBool(policy, "allow-default-scopes"))
+}
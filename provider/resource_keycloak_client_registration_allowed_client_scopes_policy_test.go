@@ -0,0 +1,59 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccKeycloakClientRegistrationAllowedClientScopesPolicy_basic(t *testing.T) {
+	t.Parallel()
+
+	policyName := acctest.RandomWithPrefix("tf-acc")
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		PreCheck:          func() { testAccPreCheck(t) },
+		CheckDestroy:      testAccCheckKeycloakClientRegistrationPolicyDestroy("keycloak_client_registration_allowed_client_scopes_policy"),
+		Steps: []resource.TestStep{
+			{
+				Config: testKeycloakClientRegistrationAllowedClientScopesPolicy_basic(policyName, "profile"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckKeycloakClientRegistrationPolicyExists("keycloak_client_registration_allowed_client_scopes_policy.policy", "allowed-client-templates"),
+					resource.TestCheckResourceAttr("keycloak_client_registration_allowed_client_scopes_policy.policy", "allowed_client_scopes.0", "profile"),
+				),
+			},
+			{
+				Config: testKeycloakClientRegistrationAllowedClientScopesPolicy_basic(policyName, "email"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckKeycloakClientRegistrationPolicyExists("keycloak_client_registration_allowed_client_scopes_policy.policy", "allowed-client-templates"),
+					resource.TestCheckResourceAttr("keycloak_client_registration_allowed_client_scopes_policy.policy", "allowed_client_scopes.0", "email"),
+				),
+			},
+			{
+				ResourceName:        "keycloak_client_registration_allowed_client_scopes_policy.policy",
+				ImportState:         true,
+				ImportStateVerify:   true,
+				ImportStateIdPrefix: testAccRealm.Realm + "/",
+			},
+		},
+	})
+}
+
+func testKeycloakClientRegistrationAllowedClientScopesPolicy_basic(name, clientScope string) string {
+	return fmt.Sprintf(`
+data "keycloak_realm" "realm" {
+	realm = "%s"
+}
+
+resource "keycloak_client_registration_allowed_client_scopes_policy" "policy" {
+	realm_id              = data.keycloak_realm.realm.id
+	name                  = "%s"
+	sub_type              = "anonymous"
+	allowed_client_scopes = ["%s"]
+	allow_default_scopes  = false
+}
+	`, testAccRealm.Realm, name, clientScope)
+}
@@ -0,0 +1,36 @@
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/keycloak/terraform-provider-keycloak/keycloak"
+)
+
+func resourceKeycloakClientRegistrationAllowedProtocolMappersPolicy() *schema.Resource {
+	policySchema := map[string]*schema.Schema{
+		"allowed_protocol_mapper_types": {
+			Type:        schema.TypeList,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+			Optional:    true,
+			Description: "Provider ids of the protocol mappers that registered clients may use, such as oidc-full-name-mapper.",
+		},
+	}
+	policyResource := resourceKeycloakClientRegistrationPolicy()
+	policyResource.Schema = mergeSchemas(policyResource.Schema, policySchema)
+	policyResource.CreateContext = resourceKeycloakClientRegistrationPolicyCreate(getClientRegistrationAllowedProtocolMappersPolicyFromData, setClientRegistrationAllowedProtocolMappersPolicyData)
+	policyResource.ReadContext = resourceKeycloakClientRegistrationPolicyRead("allowed-protocol-mappers", setClientRegistrationAllowedProtocolMappersPolicyData)
+	policyResource.UpdateContext = resourceKeycloakClientRegistrationPolicyUpdate(getClientRegistrationAllowedProtocolMappersPolicyFromData, setClientRegistrationAllowedProtocolMappersPolicyData)
+	return policyResource
+}
+
+func getClientRegistrationAllowedProtocolMappersPolicyFromData(data *schema.ResourceData) *keycloak.ClientRegistrationPolicy {
+	policy := getClientRegistrationPolicyFromData(data, "allowed-protocol-mappers")
+
+	policy.Config["allowed-protocol-mapper-types"] = interfaceSliceToStringSlice(data.Get("allowed_protocol_mapper_types").([]interface{}))
+
+	return policy
+}
+
+func setClientRegistrationAllowedProtocolMappersPolicyData(data *schema.ResourceData, policy *keycloak.ClientRegistrationPolicy) {
+	setClientRegistrationPolicyData(data, policy)
+	data.Set("allowed_protocol_mapper_types", policy.Config["allowed-protocol-mapper-types"])
+}
@@ -0,0 +1,58 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccKeycloakClientRegistrationAllowedProtocolMappersPolicy_basic(t *testing.T) {
+	t.Parallel()
+
+	policyName := acctest.RandomWithPrefix("tf-acc")
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		PreCheck:          func() { testAccPreCheck(t) },
+		CheckDestroy:      testAccCheckKeycloakClientRegistrationPolicyDestroy("keycloak_client_registration_allowed_protocol_mappers_policy"),
+		Steps: []resource.TestStep{
+			{
+				Config: testKeycloakClientRegistrationAllowedProtocolMappersPolicy_basic(policyName, "oidc-full-name-mapper"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckKeycloakClientRegistrationPolicyExists("keycloak_client_registration_allowed_protocol_mappers_policy.policy", "allowed-protocol-mappers"),
+					resource.TestCheckResourceAttr("keycloak_client_registration_allowed_protocol_mappers_policy.policy", "allowed_protocol_mapper_types.0", "oidc-full-name-mapper"),
+				),
+			},
+			{
+				Config: testKeycloakClientRegistrationAllowedProtocolMappersPolicy_basic(policyName, "oidc-address-mapper"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckKeycloakClientRegistrationPolicyExists("keycloak_client_registration_allowed_protocol_mappers_policy.policy", "allowed-protocol-mappers"),
+					resource.TestCheckResourceAttr("keycloak_client_registration_allowed_protocol_mappers_policy.policy", "allowed_protocol_mapper_types.0", "oidc-address-mapper"),
+				),
+			},
+			{
+				ResourceName:        "keycloak_client_registration_allowed_protocol_mappers_policy.policy",
+				ImportState:         true,
+				ImportStateVerify:   true,
+				ImportStateIdPrefix: testAccRealm.Realm + "/",
+			},
+		},
+	})
+}
+
+func testKeycloakClientRegistrationAllowedProtocolMappersPolicy_basic(name, mapperType string) string {
+	return fmt.Sprintf(`
+data "keycloak_realm" "realm" {
+	realm = "%s"
+}
+
+resource "keycloak_client_registration_allowed_protocol_mappers_policy" "policy" {
+	realm_id                      = data.keycloak_realm.realm.id
+	name                          = "%s"
+	sub_type                      = "anonymous"
+	allowed_protocol_mapper_types = ["%s"]
+}
+	`, testAccRealm.Realm, name, mapperType)
+}
@@ -0,0 +1,18 @@
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/keycloak/terraform-provider-keycloak/keycloak"
+)
+
+func resourceKeycloakClientRegistrationConsentRequiredPolicy() *schema.Resource {
+	policyResource := resourceKeycloakClientRegistrationPolicy()
+	policyResource.CreateContext = resourceKeycloakClientRegistrationPolicyCreate(getClientRegistrationConsentRequiredPolicyFromData, setClientRegistrationPolicyData)
+	policyResource.ReadContext = resourceKeycloakClientRegistrationPolicyRead("consent-required", setClientRegistrationPolicyData)
+	policyResource.UpdateContext = resourceKeycloakClientRegistrationPolicyUpdate(getClientRegistrationConsentRequiredPolicyFromData, setClientRegistrationPolicyData)
+	return policyResource
+}
+
+func getClientRegistrationConsentRequiredPolicyFromData(data *schema.ResourceData) *keycloak.ClientRegistrationPolicy {
+	return getClientRegistrationPolicyFromData(data, "consent-required")
+}
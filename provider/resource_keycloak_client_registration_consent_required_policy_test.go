@@ -0,0 +1,57 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccKeycloakClientRegistrationConsentRequiredPolicy_basic(t *testing.T) {
+	t.Parallel()
+
+	policyName := acctest.RandomWithPrefix("tf-acc")
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		PreCheck:          func() { testAccPreCheck(t) },
+		CheckDestroy:      testAccCheckKeycloakClientRegistrationPolicyDestroy("keycloak_client_registration_consent_required_policy"),
+		Steps: []resource.TestStep{
+			{
+				Config: testKeycloakClientRegistrationConsentRequiredPolicy_basic(policyName, "anonymous"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckKeycloakClientRegistrationPolicyExists("keycloak_client_registration_consent_required_policy.policy", "consent-required"),
+					resource.TestCheckResourceAttr("keycloak_client_registration_consent_required_policy.policy", "sub_type", "anonymous"),
+				),
+			},
+			{
+				Config: testKeycloakClientRegistrationConsentRequiredPolicy_basic(policyName, "authenticated"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckKeycloakClientRegistrationPolicyExists("keycloak_client_registration_consent_required_policy.policy", "consent-required"),
+					resource.TestCheckResourceAttr("keycloak_client_registration_consent_required_policy.policy", "sub_type", "authenticated"),
+				),
+			},
+			{
+				ResourceName:        "keycloak_client_registration_consent_required_policy.policy",
+				ImportState:         true,
+				ImportStateVerify:   true,
+				ImportStateIdPrefix: testAccRealm.Realm + "/",
+			},
+		},
+	})
+}
+
+func testKeycloakClientRegistrationConsentRequiredPolicy_basic(name, subType string) string {
+	return fmt.Sprintf(`
+data "keycloak_realm" "realm" {
+	realm = "%s"
+}
+
+resource "keycloak_client_registration_consent_required_policy" "policy" {
+	realm_id = data.keycloak_realm.realm.id
+	name     = "%s"
+	sub_type = "%s"
+}
+	`, testAccRealm.Realm, name, subType)
+}
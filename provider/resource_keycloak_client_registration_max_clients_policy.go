@@ -0,0 +1,45 @@
+package provider
+
+import (
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/keycloak/terraform-provider-keycloak/keycloak"
+)
+
+func resourceKeycloakClientRegistrationMaxClientsPolicy() *schema.Resource {
+	policySchema := map[string]*schema.Schema{
+		"max_clients": {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			Default:      200,
+			ValidateFunc: validation.IntAtLeast(1),
+			Description:  "Registration requests are rejected once the realm has this many clients.",
+		},
+	}
+	policyResource := resourceKeycloakClientRegistrationPolicy()
+	policyResource.Schema = mergeSchemas(policyResource.Schema, policySchema)
+	policyResource.CreateContext = resourceKeycloakClientRegistrationPolicyCreate(getClientRegistrationMaxClientsPolicyFromData, setClientRegistrationMaxClientsPolicyData)
+	policyResource.ReadContext = resourceKeycloakClientRegistrationPolicyRead("max-clients", setClientRegistrationMaxClientsPolicyData)
+	policyResource.UpdateContext = resourceKeycloakClientRegistrationPolicyUpdate(getClientRegistrationMaxClientsPolicyFromData, setClientRegistrationMaxClientsPolicyData)
+	return policyResource
+}
+
+func getClientRegistrationMaxClientsPolicyFromData(data *schema.ResourceData) *keycloak.ClientRegistrationPolicy {
+	policy := getClientRegistrationPolicyFromData(data, "max-clients")
+
+	policy.Config["max-clients"] = []string{strconv.Itoa(data.Get("max_clients").(int))}
+
+	return policy
+}
+
+func setClientRegistrationMaxClientsPolicyData(data *schema.ResourceData, policy *keycloak.ClientRegistrationPolicy) {
+	setClientRegistrationPolicyData(data, policy)
+	if values := policy.Config["max-clients"]; len(values) != 0 {
+		maxClients, err := strconv.Atoi(values[0])
+		if err == nil {
+			data.Set("max_clients", maxClients)
+		}
+	}
+}
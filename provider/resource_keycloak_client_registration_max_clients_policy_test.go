@@ -0,0 +1,58 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccKeycloakClientRegistrationMaxClientsPolicy_basic(t *testing.T) {
+	t.Parallel()
+
+	policyName := acctest.RandomWithPrefix("tf-acc")
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		PreCheck:          func() { testAccPreCheck(t) },
+		CheckDestroy:      testAccCheckKeycloakClientRegistrationPolicyDestroy("keycloak_client_registration_max_clients_policy"),
+		Steps: []resource.TestStep{
+			{
+				Config: testKeycloakClientRegistrationMaxClientsPolicy_basic(policyName, "10"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckKeycloakClientRegistrationPolicyExists("keycloak_client_registration_max_clients_policy.policy", "max-clients"),
+					resource.TestCheckResourceAttr("keycloak_client_registration_max_clients_policy.policy", "max_clients", "10"),
+				),
+			},
+			{
+				Config: testKeycloakClientRegistrationMaxClientsPolicy_basic(policyName, "20"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckKeycloakClientRegistrationPolicyExists("keycloak_client_registration_max_clients_policy.policy", "max-clients"),
+					resource.TestCheckResourceAttr("keycloak_client_registration_max_clients_policy.policy", "max_clients", "20"),
+				),
+			},
+			{
+				ResourceName:        "keycloak_client_registration_max_clients_policy.policy",
+				ImportState:         true,
+				ImportStateVerify:   true,
+				ImportStateIdPrefix: testAccRealm.Realm + "/",
+			},
+		},
+	})
+}
+
+func testKeycloakClientRegistrationMaxClientsPolicy_basic(name, maxClients string) string {
+	return fmt.Sprintf(`
+data "keycloak_realm" "realm" {
+	realm = "%s"
+}
+
+resource "keycloak_client_registration_max_clients_policy" "policy" {
+	realm_id    = data.keycloak_realm.realm.id
+	name        = "%s"
+	sub_type    = "authenticated"
+	max_clients = %s
+}
+	`, testAccRealm.Realm, name, maxClients)
+}
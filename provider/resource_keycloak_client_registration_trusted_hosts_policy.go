@@ -0,0 +1,54 @@
+package provider
+
+import (
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/keycloak/terraform-provider-keycloak/keycloak"
+)
+
+func resourceKeycloakClientRegistrationTrustedHostsPolicy() *schema.Resource {
+	policySchema := map[string]*schema.Schema{
+		"trusted_hosts": {
+			Type:        schema.TypeList,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+			Optional:    true,
+			Description: "Hosts or domains that are trusted to register clients.",
+		},
+		"host_sending_registration_request_must_match": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     true,
+			Description: "When true, registration requests must come from one of the trusted hosts.",
+		},
+		"client_uris_must_match": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     true,
+			Description: "When true, the redirect URIs and other URIs of the client must point to one of the trusted hosts.",
+		},
+	}
+	policyResource := resourceKeycloakClientRegistrationPolicy()
+	policyResource.Schema = mergeSchemas(policyResource.Schema, policySchema)
+	policyResource.CreateContext = resourceKeycloakClientRegistrationPolicyCreate(getClientRegistrationTrustedHostsPolicyFromData, setClientRegistrationTrustedHostsPolicyData)
+	policyResource.ReadContext = resourceKeycloakClientRegistrationPolicyRead("trusted-hosts", setClientRegistrationTrustedHostsPolicyData)
+	policyResource.UpdateContext = resourceKeycloakClientRegistrationPolicyUpdate(getClientRegistrationTrustedHostsPolicyFromData, setClientRegistrationTrustedHostsPolicyData)
+	return policyResource
+}
+
+func getClientRegistrationTrustedHostsPolicyFromData(data *schema.ResourceData) *keycloak.ClientRegistrationPolicy {
+	policy := getClientRegistrationPolicyFromData(data, "trusted-hosts")
+
+	policy.Config["trusted-hosts"] = interfaceSliceToStringSlice(data.Get("trusted_hosts").([]interface{}))
+	policy.Config["host-sending-registration-request-must-match"] = []string{strconv.FormatBool(data.Get("host_sending_registration_request_must_match").(bool))}
+	policy.Config["client-uris-must-match"] = []string{strconv.FormatBool(data.Get("client_uris_must_match").(bool))}
+
+	return policy
+}
+
+func setClientRegistrationTrustedHostsPolicyData(data *schema.ResourceData, policy *keycloak.ClientRegistrationPolicy) {
+	setClientRegistrationPolicyData(data, policy)
+	data.Set("trusted_hosts", policy.Config["trusted-hosts"])
+	data.Set("host_sending_registration_request_must_match", getClientRegistrationPolicyConfigBool(policy, "host-sending-registration-request-must-match"))
+	data.Set("client_uris_must_match", getClientRegistrationPolicyConfigBool(policy, "client-uris-must-match"))
+}
@@ -0,0 +1,98 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccKeycloakClientRegistrationTrustedHostsPolicy_basic(t *testing.T) {
+	t.Parallel()
+
+	policyName := acctest.RandomWithPrefix("tf-acc")
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		PreCheck:          func() { testAccPreCheck(t) },
+		CheckDestroy:      testAccCheckKeycloakClientRegistrationPolicyDestroy("keycloak_client_registration_trusted_hosts_policy"),
+		Steps: []resource.TestStep{
+			{
+				Config: testKeycloakClientRegistrationTrustedHostsPolicy_basic(policyName, "example.com", true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckKeycloakClientRegistrationPolicyExists("keycloak_client_registration_trusted_hosts_policy.trusted_hosts", "trusted-hosts"),
+					resource.TestCheckResourceAttr("keycloak_client_registration_trusted_hosts_policy.trusted_hosts", "trusted_hosts.0", "example.com"),
+					resource.TestCheckResourceAttr("keycloak_client_registration_trusted_hosts_policy.trusted_hosts", "client_uris_must_match", "true"),
+				),
+			},
+			{
+				Config: testKeycloakClientRegistrationTrustedHostsPolicy_basic(policyName, "example.org", false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckKeycloakClientRegistrationPolicyExists("keycloak_client_registration_trusted_hosts_policy.trusted_hosts", "trusted-hosts"),
+					resource.TestCheckResourceAttr("keycloak_client_registration_trusted_hosts_policy.trusted_hosts", "trusted_hosts.0", "example.org"),
+					resource.TestCheckResourceAttr("keycloak_client_registration_trusted_hosts_policy.trusted_hosts", "client_uris_must_match", "false"),
+				),
+			},
+			{
+				ResourceName:        "keycloak_client_registration_trusted_hosts_policy.trusted_hosts",
+				ImportState:         true,
+				ImportStateVerify:   true,
+				ImportStateIdPrefix: testAccRealm.Realm + "/",
+			},
+		},
+	})
+}
+
+func testAccCheckKeycloakClientRegistrationPolicyExists(resourceName, providerId string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("resource not found: %s", resourceName)
+		}
+
+		policy, err := keycloakClient.GetClientRegistrationPolicy(testCtx, rs.Primary.Attributes["realm_id"], rs.Primary.ID)
+		if err != nil {
+			return fmt.Errorf("error getting client registration policy %s: %s", rs.Primary.ID, err)
+		}
+		if policy.ProviderId != providerId {
+			return fmt.Errorf("expected a %s policy, got a %s policy", providerId, policy.ProviderId)
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckKeycloakClientRegistrationPolicyDestroy(resourceType string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		for _, rs := range s.RootModule().Resources {
+			if rs.Type != resourceType {
+				continue
+			}
+
+			policy, _ := keycloakClient.GetClientRegistrationPolicy(testCtx, rs.Primary.Attributes["realm_id"], rs.Primary.ID)
+			if policy != nil {
+				return fmt.Errorf("client registration policy %s still exists", rs.Primary.ID)
+			}
+		}
+
+		return nil
+	}
+}
+
+func testKeycloakClientRegistrationTrustedHostsPolicy_basic(name, trustedHost string, clientUrisMustMatch bool) string {
+	return fmt.Sprintf(`
+data "keycloak_realm" "realm" {
+	realm = "%s"
+}
+
+resource "keycloak_client_registration_trusted_hosts_policy" "trusted_hosts" {
+	realm_id               = data.keycloak_realm.realm.id
+	name                   = "%s"
+	sub_type               = "anonymous"
+	trusted_hosts          = ["%s"]
+	client_uris_must_match = %t
+}
+	`, testAccRealm.Realm, name, trustedHost, clientUrisMustMatch)
+}
@@ -79,7 +79,7 @@ func resourceKeycloakCustomIdentityProviderMapperCreate(ctx context.Context, dat
 
 	err := keycloakClient.NewCustomIdentityProviderMapper(ctx, customIdentityProvider)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	setCustomIdentityProviderMapperData(data, customIdentityProvider)
@@ -111,7 +111,7 @@ func resourceKeycloakCustomIdentityProviderMapperUpdate(ctx context.Context, dat
 
 	err := keycloakClient.UpdateCustomIdentityProviderMapper(ctx, customIdentityProvider)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	setCustomIdentityProviderMapperData(data, customIdentityProvider)
@@ -159,19 +159,19 @@ func resourceKeycloakCustomUserFederationCreate(ctx context.Context, data *schem
 
 	realm, err := keycloakClient.GetRealm(ctx, realmId)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	custom := getCustomUserFederationFromData(data, realm.Id)
 
 	err = keycloakClient.ValidateCustomUserFederation(ctx, custom)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	err = keycloakClient.NewCustomUserFederation(ctx, realmId, custom)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	setCustomUserFederationData(data, custom, realmId)
@@ -202,19 +202,19 @@ func resourceKeycloakCustomUserFederationUpdate(ctx context.Context, data *schem
 
 	realm, err := keycloakClient.GetRealm(ctx, realmId)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	custom := getCustomUserFederationFromData(data, realm.Id)
 
 	err = keycloakClient.ValidateCustomUserFederation(ctx, custom)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	err = keycloakClient.UpdateCustomUserFederation(ctx, realmId, custom)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	setCustomUserFederationData(data, custom, realmId)
@@ -45,7 +45,7 @@ func resourceKeycloakDefaultGroupsCreate(ctx context.Context, data *schema.Resou
 	for _, groupId := range groupIds {
 		err := keycloakClient.PutDefaultGroup(ctx, realmId, groupId)
 		if err != nil {
-			return diag.FromErr(err)
+			return diagnosticsFromError(err, data)
 		}
 	}
 
@@ -61,7 +61,7 @@ func resourceKeycloakDefaultGroupsRead(ctx context.Context, data *schema.Resourc
 
 	groups, err := keycloakClient.GetDefaultGroups(ctx, realmId)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	var groupIds []string
@@ -83,7 +83,7 @@ func resourceKeycloakDefaultGroupsUpdate(ctx context.Context, data *schema.Resou
 
 	originalGroups, err := keycloakClient.GetDefaultGroups(ctx, realmId)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	for _, originalGroup := range originalGroups {
@@ -92,7 +92,7 @@ func resourceKeycloakDefaultGroupsUpdate(ctx context.Context, data *schema.Resou
 		} else {
 			err := keycloakClient.DeleteDefaultGroup(ctx, realmId, originalGroup.Id)
 			if err != nil {
-				return diag.FromErr(err)
+				return diagnosticsFromError(err, data)
 			}
 		}
 	}
@@ -101,7 +101,7 @@ func resourceKeycloakDefaultGroupsUpdate(ctx context.Context, data *schema.Resou
 	for _, group := range interfaceSliceToStringSlice(newGroupIds.List()) {
 		err := keycloakClient.PutDefaultGroup(ctx, realmId, group)
 		if err != nil {
-			return diag.FromErr(err)
+			return diagnosticsFromError(err, data)
 		}
 	}
 
@@ -119,7 +119,7 @@ func resourceKeycloakDefaultGroupsDelete(ctx context.Context, data *schema.Resou
 	for _, groupId := range groupIds {
 		err := keycloakClient.DeleteDefaultGroup(ctx, realmId, groupId)
 		if err != nil {
-			return diag.FromErr(err)
+			return diagnosticsFromError(err, data)
 		}
 	}
 
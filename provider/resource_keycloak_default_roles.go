@@ -87,33 +87,28 @@ func resourceKeycloakDefaultRolesRead(ctx context.Context, data *schema.Resource
 func resourceKeycloakDefaultRolesReconcile(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	keycloakClient := meta.(*keycloak.KeycloakClient)
 
-	if ok, err := keycloakClient.VersionIsGreaterThanOrEqualTo(ctx, keycloak.Version_13); !ok && err == nil {
-		return diag.Diagnostics{{
-			Severity: diag.Error,
-			Summary:  "this resource requires Keycloak v13 or higher",
-		}}
-	} else if err != nil {
-		return diag.FromErr(err)
+	if diags := requireFeature(ctx, keycloakClient, keycloak.FeatureDefaultRoles); diags.HasError() {
+		return diags
 	}
 
 	defaultRoles := mapFromDataToDefaultRoles(data)
 
 	realm, err := keycloakClient.GetRealm(ctx, defaultRoles.RealmId)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	data.SetId(realm.DefaultRole.Id)
 
 	composites, err := keycloakClient.GetDefaultRoles(ctx, defaultRoles.RealmId, realm.DefaultRole.Id)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	defaultRoleNamesList := getDefaultRoleNames(composites)
 	rolesList, err := keycloakClient.GetRealmRoles(ctx, defaultRoles.RealmId)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	// skip if actual default roles in keycloak same as we want
@@ -126,7 +121,7 @@ func resourceKeycloakDefaultRolesReconcile(ctx context.Context, data *schema.Res
 		if !roleListContains(defaultRoleNamesList, roleName) {
 			defaultRoles, err := getRoleByNameFromList(rolesList, roleName)
 			if err != nil {
-				return diag.FromErr(err)
+				return diagnosticsFromError(err, data)
 			}
 			putList = append(putList, defaultRoles)
 		}
@@ -135,7 +130,7 @@ func resourceKeycloakDefaultRolesReconcile(ctx context.Context, data *schema.Res
 		if !roleListContains(defaultRoles.DefaultRoles, roleName) {
 			defaultRoles, err := getRoleByNameFromList(rolesList, roleName)
 			if err != nil {
-				return diag.FromErr(err)
+				return diagnosticsFromError(err, data)
 			}
 			deleteList = append(deleteList, defaultRoles)
 		}
@@ -149,7 +144,7 @@ func resourceKeycloakDefaultRolesReconcile(ctx context.Context, data *schema.Res
 		}
 		err := keycloakClient.AddCompositesToRole(ctx, role, putList)
 		if err != nil {
-			return diag.FromErr(err)
+			return diagnosticsFromError(err, data)
 		}
 	}
 	if len(deleteList) > 0 {
@@ -159,7 +154,7 @@ func resourceKeycloakDefaultRolesReconcile(ctx context.Context, data *schema.Res
 		}
 		err := keycloakClient.RemoveCompositesFromRole(ctx, role, deleteList)
 		if err != nil {
-			return diag.FromErr(err)
+			return diagnosticsFromError(err, data)
 		}
 	}
 
@@ -178,7 +173,7 @@ func resourceKeycloakDefaultRolesDelete(ctx context.Context, data *schema.Resour
 
 	defaultRoles, err := keycloakClient.GetDefaultRoles(ctx, realmId, realm.DefaultRole.Id)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	if len(defaultRoles) > 0 {
@@ -188,7 +183,7 @@ func resourceKeycloakDefaultRolesDelete(ctx context.Context, data *schema.Resour
 		}
 		err := keycloakClient.RemoveCompositesFromRole(ctx, role, defaultRoles)
 		if err != nil {
-			return diag.FromErr(err)
+			return diagnosticsFromError(err, data)
 		}
 	}
 
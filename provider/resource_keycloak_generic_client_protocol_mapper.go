@@ -112,12 +112,12 @@ func resourceKeycloakGenericClientProtocolMapperCreate(ctx context.Context, data
 
 	err := genericClientProtocolMapper.Validate(ctx, keycloakClient)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	err = keycloakClient.NewGenericProtocolMapper(ctx, genericClientProtocolMapper)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 	mapFromGenericClientProtocolMapperToData(data, genericClientProtocolMapper)
 
@@ -149,7 +149,7 @@ func resourceKeycloakGenericClientProtocolMapperUpdate(ctx context.Context, data
 
 	err := keycloakClient.UpdateGenericProtocolMapper(ctx, resource)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	mapFromGenericClientProtocolMapperToData(data, resource)
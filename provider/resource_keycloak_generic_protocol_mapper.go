@@ -111,12 +111,12 @@ func resourceKeycloakGenericProtocolMapperCreate(ctx context.Context, data *sche
 
 	err := genericProtocolMapper.Validate(ctx, keycloakClient)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	err = keycloakClient.NewGenericProtocolMapper(ctx, genericProtocolMapper)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 	mapFromGenericProtocolMapperToData(data, genericProtocolMapper)
 
@@ -148,7 +148,7 @@ func resourceKeycloakGenericProtocolMapperUpdate(ctx context.Context, data *sche
 
 	err := keycloakClient.UpdateGenericProtocolMapper(ctx, resource)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	mapFromGenericProtocolMapperToData(data, resource)
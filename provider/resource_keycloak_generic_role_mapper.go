@@ -59,12 +59,12 @@ func resourceKeycloakGenericRoleMapperCreate(ctx context.Context, data *schema.R
 
 	role, err := keycloakClient.GetRole(ctx, realmId, roleId)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	err = keycloakClient.CreateRoleScopeMapping(ctx, realmId, clientId, clientScopeId, role)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	if clientId != "" {
@@ -108,7 +108,7 @@ func resourceKeycloakGenericRoleMapperDelete(ctx context.Context, data *schema.R
 
 	role, err := keycloakClient.GetRole(ctx, realmId, roleId)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	return diag.FromErr(keycloakClient.DeleteRoleScopeMapping(ctx, realmId, clientId, clientScopeId, role))
@@ -89,7 +89,7 @@ func resourceKeycloakGroupCreate(ctx context.Context, data *schema.ResourceData,
 
 	err := keycloakClient.NewGroup(ctx, group)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	mapFromGroupToData(data, group)
@@ -120,7 +120,7 @@ func resourceKeycloakGroupUpdate(ctx context.Context, data *schema.ResourceData,
 
 	err := keycloakClient.UpdateGroup(ctx, group)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	mapFromGroupToData(data, group)
@@ -45,12 +45,12 @@ func resourceKeycloakGroupMembershipsCreate(ctx context.Context, data *schema.Re
 
 	err := keycloakClient.ValidateGroupMembers(members)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	err = keycloakClient.AddUsersToGroup(ctx, realmId, groupId, members)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	data.SetId(groupMembershipsId(realmId, groupId))
@@ -89,12 +89,12 @@ func resourceKeycloakGroupMembershipsUpdate(ctx context.Context, data *schema.Re
 
 	err := keycloakClient.ValidateGroupMembers(tfMembers.List())
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	keycloakMembers, err := keycloakClient.GetGroupMembers(ctx, realmId, groupId)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	for _, keycloakMember := range keycloakMembers {
@@ -106,7 +106,7 @@ func resourceKeycloakGroupMembershipsUpdate(ctx context.Context, data *schema.Re
 			// if the user exists in keycloak and not in tf state, they need to be removed from the group
 			err = keycloakClient.RemoveUserFromGroup(ctx, keycloakMember, groupId)
 			if err != nil {
-				return diag.FromErr(err)
+				return diagnosticsFromError(err, data)
 			}
 		}
 	}
@@ -114,7 +114,7 @@ func resourceKeycloakGroupMembershipsUpdate(ctx context.Context, data *schema.Re
 	// at this point, `tfMembers` should only contain users that exist in tf state but not keycloak. these users need to be added
 	err = keycloakClient.AddUsersToGroup(ctx, realmId, groupId, tfMembers.List())
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	data.SetId(groupMembershipsId(realmId, groupId))
@@ -66,48 +66,48 @@ func resourceKeycloakGroupPermissionsUpdate(ctx context.Context, data *schema.Re
 	// the existence of this resource implies that it is enabled.
 	err := keycloakClient.EnableGroupPermissions(ctx, realmId, groupId)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	// setting scope permissions requires us to fetch the users permissions details, as well as the realm management client
 	groupPermissions, err := keycloakClient.GetGroupPermissions(ctx, realmId, groupId)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	realmManagementClient, err := keycloakClient.GetOpenidClientByClientId(ctx, realmId, "realm-management")
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	if viewScope, ok := data.GetOk("view_scope"); ok {
 		err := setOpenidClientScopePermissionPolicy(ctx, keycloakClient, realmId, realmManagementClient.Id, groupPermissions.ScopePermissions["view"].(string), viewScope.(*schema.Set))
 		if err != nil {
-			return diag.FromErr(err)
+			return diagnosticsFromError(err, data)
 		}
 	}
 	if manageScope, ok := data.GetOk("manage_scope"); ok {
 		err := setOpenidClientScopePermissionPolicy(ctx, keycloakClient, realmId, realmManagementClient.Id, groupPermissions.ScopePermissions["manage"].(string), manageScope.(*schema.Set))
 		if err != nil {
-			return diag.FromErr(err)
+			return diagnosticsFromError(err, data)
 		}
 	}
 	if viewMembersScope, ok := data.GetOk("view_members_scope"); ok {
 		err := setOpenidClientScopePermissionPolicy(ctx, keycloakClient, realmId, realmManagementClient.Id, groupPermissions.ScopePermissions["view-members"].(string), viewMembersScope.(*schema.Set))
 		if err != nil {
-			return diag.FromErr(err)
+			return diagnosticsFromError(err, data)
 		}
 	}
 	if manageMembersScope, ok := data.GetOk("manage_members_scope"); ok {
 		err := setOpenidClientScopePermissionPolicy(ctx, keycloakClient, realmId, realmManagementClient.Id, groupPermissions.ScopePermissions["manage-members"].(string), manageMembersScope.(*schema.Set))
 		if err != nil {
-			return diag.FromErr(err)
+			return diagnosticsFromError(err, data)
 		}
 	}
 	if manageMembershipScope, ok := data.GetOk("manage_membership_scope"); ok {
 		err := setOpenidClientScopePermissionPolicy(ctx, keycloakClient, realmId, realmManagementClient.Id, groupPermissions.ScopePermissions["manage-membership"].(string), manageMembershipScope.(*schema.Set))
 		if err != nil {
-			return diag.FromErr(err)
+			return diagnosticsFromError(err, data)
 		}
 	}
 
@@ -121,7 +121,7 @@ func resourceKeycloakGroupPermissionsRead(ctx context.Context, data *schema.Reso
 
 	realmManagementClient, err := keycloakClient.GetOpenidClientByClientId(ctx, realmId, "realm-management")
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	groupPermissions, err := keycloakClient.GetGroupPermissions(ctx, realmId, groupId)
@@ -138,31 +138,31 @@ func resourceKeycloakGroupPermissionsRead(ctx context.Context, data *schema.Reso
 	if viewScope, err := getOpenidClientScopePermissionPolicy(ctx, keycloakClient, realmId, realmManagementClient.Id, groupPermissions.ScopePermissions["view"].(string)); err == nil && viewScope != nil {
 		data.Set("view_scope", []interface{}{viewScope})
 	} else if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	if manageScope, err := getOpenidClientScopePermissionPolicy(ctx, keycloakClient, realmId, realmManagementClient.Id, groupPermissions.ScopePermissions["manage"].(string)); err == nil && manageScope != nil {
 		data.Set("manage_scope", []interface{}{manageScope})
 	} else if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	if viewMembersScope, err := getOpenidClientScopePermissionPolicy(ctx, keycloakClient, realmId, realmManagementClient.Id, groupPermissions.ScopePermissions["view-members"].(string)); err == nil && viewMembersScope != nil {
 		data.Set("view_members_scope", []interface{}{viewMembersScope})
 	} else if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	if manageMembersScope, err := getOpenidClientScopePermissionPolicy(ctx, keycloakClient, realmId, realmManagementClient.Id, groupPermissions.ScopePermissions["manage-members"].(string)); err == nil && manageMembersScope != nil {
 		data.Set("manage_members_scope", []interface{}{manageMembersScope})
 	} else if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	if manageMembershipScope, err := getOpenidClientScopePermissionPolicy(ctx, keycloakClient, realmId, realmManagementClient.Id, groupPermissions.ScopePermissions["manage-membership"].(string)); err == nil && manageMembershipScope != nil {
 		data.Set("manage_membership_scope", []interface{}{manageMembershipScope})
 	} else if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	return nil
@@ -100,7 +100,7 @@ func resourceKeycloakGroupRolesReconcile(ctx context.Context, data *schema.Resou
 
 	group, err := keycloakClient.GetGroup(ctx, realmId, groupId)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	if data.HasChange("role_ids") {
@@ -111,17 +111,17 @@ func resourceKeycloakGroupRolesReconcile(ctx context.Context, data *schema.Resou
 
 		tfRolesToRemove, err := getExtendedRoleMapping(ctx, keycloakClient, realmId, remove)
 		if err != nil {
-			return diag.FromErr(err)
+			return diagnosticsFromError(err, data)
 		}
 
 		if err = removeRolesFromGroup(ctx, keycloakClient, tfRolesToRemove.clientRoles, tfRolesToRemove.realmRoles, group); err != nil {
-			return diag.FromErr(err)
+			return diagnosticsFromError(err, data)
 		}
 	}
 
 	tfRoles, err := getExtendedRoleMapping(ctx, keycloakClient, realmId, roleIds)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	// get the list of currently assigned roles. Due to default realm and client roles
@@ -133,14 +133,14 @@ func resourceKeycloakGroupRolesReconcile(ctx context.Context, data *schema.Resou
 	// add roles
 	err = addRolesToGroup(ctx, keycloakClient, updates.clientRolesToAdd, updates.realmRolesToAdd, group)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	// remove roles if exhaustive (authoritative)
 	if exhaustive {
 		err = removeRolesFromGroup(ctx, keycloakClient, updates.clientRolesToRemove, updates.realmRolesToRemove, group)
 		if err != nil {
-			return diag.FromErr(err)
+			return diagnosticsFromError(err, data)
 		}
 	}
 
@@ -164,7 +164,7 @@ func resourceKeycloakGroupRolesRead(ctx context.Context, data *schema.ResourceDa
 
 	roles, err := keycloakClient.GetGroupRoleMappings(ctx, realmId, groupId)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	var roleIds []string
@@ -200,12 +200,12 @@ func resourceKeycloakGroupRolesDelete(ctx context.Context, data *schema.Resource
 	roleIds := interfaceSliceToStringSlice(data.Get("role_ids").(*schema.Set).List())
 	rolesToRemove, err := getExtendedRoleMapping(ctx, keycloakClient, realmId, roleIds)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	err = removeRolesFromGroup(ctx, keycloakClient, rolesToRemove.clientRoles, rolesToRemove.realmRoles, group)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	return nil
@@ -0,0 +1,88 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/keycloak/terraform-provider-keycloak/keycloak"
+	"github.com/keycloak/terraform-provider-keycloak/keycloak/keycloaktest"
+)
+
+func newFakeKeycloakClient(t *testing.T) *keycloak.KeycloakClient {
+	t.Helper()
+
+	server := keycloaktest.NewServer()
+	t.Cleanup(server.Close)
+
+	return newFakeKeycloakClientForServer(t, server)
+}
+
+// newFakeKeycloakClientForServer logs in to a fake that has been set up by the test, e.g. with features disabled
+func newFakeKeycloakClientForServer(t *testing.T, server *keycloaktest.Server) *keycloak.KeycloakClient {
+	t.Helper()
+
+	keycloakClient, err := keycloak.NewKeycloakClient(context.Background(), keycloak.KeycloakClientConfig{
+		Url:           server.URL,
+		ClientId:      "terraform",
+		ClientSecret:  "secret",
+		Realm:         "master",
+		InitialLogin:  true,
+		ClientTimeout: 5,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return keycloakClient
+}
+
+func TestGroupResourceLifecycle(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	keycloakClient := newFakeKeycloakClient(t)
+
+	if err := keycloakClient.NewRealm(ctx, &keycloak.Realm{Realm: "test", Enabled: true}); err != nil {
+		t.Fatal(err)
+	}
+
+	parent := schema.TestResourceDataRaw(t, resourceKeycloakGroup().Schema, map[string]interface{}{
+		"realm_id": "test",
+		"name":     "parent",
+	})
+	if diags := resourceKeycloakGroupCreate(ctx, parent, keycloakClient); diags.HasError() {
+		t.Fatal(diags)
+	}
+
+	child := schema.TestResourceDataRaw(t, resourceKeycloakGroup().Schema, map[string]interface{}{
+		"realm_id":  "test",
+		"parent_id": parent.Id(),
+		"name":      "child",
+		"attributes": map[string]interface{}{
+			"team": "platform",
+		},
+	})
+	if diags := resourceKeycloakGroupCreate(ctx, child, keycloakClient); diags.HasError() {
+		t.Fatal(diags)
+	}
+
+	if path := child.Get("path").(string); path != "/parent/child" {
+		t.Errorf("expected path /parent/child, got %s", path)
+	}
+	if team := child.Get("attributes.team").(string); team != "platform" {
+		t.Errorf("expected the team attribute to be read back, got %q", team)
+	}
+
+	if diags := resourceKeycloakGroupDelete(ctx, parent, keycloakClient); diags.HasError() {
+		t.Fatal(diags)
+	}
+
+	// the child was deleted along with its parent, so it is removed from state
+	if diags := resourceKeycloakGroupRead(ctx, child, keycloakClient); diags.HasError() {
+		t.Fatal(diags)
+	}
+	if child.Id() != "" {
+		t.Errorf("expected the deleted group to be removed from state, got id %s", child.Id())
+	}
+}
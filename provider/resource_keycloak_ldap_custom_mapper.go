@@ -93,7 +93,7 @@ func resourceKeycloakLdapCustomMapperCreate(ctx context.Context, data *schema.Re
 
 	err := keycloakClient.NewLdapCustomMapper(ctx, ldapCustomMapper)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	setLdapCustomMapperData(data, ldapCustomMapper)
@@ -124,7 +124,7 @@ func resourceKeycloakLdapCustomMapperUpdate(ctx context.Context, data *schema.Re
 
 	err := keycloakClient.UpdateLdapCustomMapper(ctx, ldapCustomMapper)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	setLdapCustomMapperData(data, ldapCustomMapper)
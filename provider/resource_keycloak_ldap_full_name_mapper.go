@@ -87,12 +87,12 @@ func resourceKeycloakLdapFullNameMapperCreate(ctx context.Context, data *schema.
 
 	err := keycloakClient.ValidateLdapFullNameMapper(ctx, ldapFullNameMapper)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	err = keycloakClient.NewLdapFullNameMapper(ctx, ldapFullNameMapper)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	setLdapFullNameMapperData(data, ldapFullNameMapper)
@@ -123,12 +123,12 @@ func resourceKeycloakLdapFullNameMapperUpdate(ctx context.Context, data *schema.
 
 	err := keycloakClient.ValidateLdapFullNameMapper(ctx, ldapFullNameMapper)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	err = keycloakClient.UpdateLdapFullNameMapper(ctx, ldapFullNameMapper)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	setLdapFullNameMapperData(data, ldapFullNameMapper)
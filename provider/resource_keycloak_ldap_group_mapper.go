@@ -156,7 +156,7 @@ func getLdapGroupMapperFromData(ctx context.Context, keycloakClient *keycloak.Ke
 		MappedGroupAttributes:           mappedGroupAttributes,
 		DropNonExistingGroupsDuringSync: data.Get("drop_non_existing_groups_during_sync").(bool),
 	}
-	versionOk, err := keycloakClient.VersionIsGreaterThanOrEqualTo(ctx, keycloak.Version_11)
+	versionOk, err := keycloakClient.FeatureIsEnabled(ctx, keycloak.FeatureLdapGroupsPath)
 	if err != nil {
 		return nil, err
 	}
@@ -190,7 +190,7 @@ func setLdapGroupMapperData(ctx context.Context, keycloakClient *keycloak.Keyclo
 	data.Set("mapped_group_attributes", ldapGroupMapper.MappedGroupAttributes)
 	data.Set("drop_non_existing_groups_during_sync", ldapGroupMapper.DropNonExistingGroupsDuringSync)
 
-	versionOk, err := keycloakClient.VersionIsGreaterThanOrEqualTo(ctx, keycloak.Version_11)
+	versionOk, err := keycloakClient.FeatureIsEnabled(ctx, keycloak.FeatureLdapGroupsPath)
 	if err != nil {
 		return err
 	}
@@ -207,22 +207,22 @@ func resourceKeycloakLdapGroupMapperCreate(ctx context.Context, data *schema.Res
 
 	ldapGroupMapper, err := getLdapGroupMapperFromData(ctx, keycloakClient, data)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	err = keycloakClient.ValidateLdapGroupMapper(ctx, ldapGroupMapper)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	err = keycloakClient.NewLdapGroupMapper(ctx, ldapGroupMapper)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	err = setLdapGroupMapperData(ctx, keycloakClient, data, ldapGroupMapper)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	return resourceKeycloakLdapGroupMapperRead(ctx, data, meta)
@@ -241,7 +241,7 @@ func resourceKeycloakLdapGroupMapperRead(ctx context.Context, data *schema.Resou
 
 	err = setLdapGroupMapperData(ctx, keycloakClient, data, ldapGroupMapper)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	return nil
@@ -252,22 +252,22 @@ func resourceKeycloakLdapGroupMapperUpdate(ctx context.Context, data *schema.Res
 
 	ldapGroupMapper, err := getLdapGroupMapperFromData(ctx, keycloakClient, data)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	err = keycloakClient.ValidateLdapGroupMapper(ctx, ldapGroupMapper)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	err = keycloakClient.UpdateLdapGroupMapper(ctx, ldapGroupMapper)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	err = setLdapGroupMapperData(ctx, keycloakClient, data, ldapGroupMapper)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	return nil
@@ -79,7 +79,7 @@ func resourceKeycloakLdapHardcodedAttributeMapperCreate(ctx context.Context, dat
 
 	err := keycloakClient.NewLdapHardcodedAttributeMapper(ctx, ldapMapper)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	setLdapHardcodedAttributeMapperData(data, ldapMapper)
@@ -110,7 +110,7 @@ func resourceKeycloakLdapHardcodedAttributeMapperUpdate(ctx context.Context, dat
 
 	err := keycloakClient.UpdateLdapHardcodedAttributeMapper(ctx, ldapMapper)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	setLdapHardcodedAttributeMapperData(data, ldapMapper)
@@ -126,5 +126,5 @@ func resourceKeycloakLdapHardcodedAttributeMapperDelete(ctx context.Context, dat
 
 	err := keycloakClient.DeleteLdapHardcodedAttributeMapper(ctx, realmId, id)
 
-	return diag.FromErr(err)
+	return diagnosticsFromError(err, data)
 }
@@ -70,12 +70,12 @@ func resourceKeycloakLdapHardcodedGroupMapperCreate(ctx context.Context, data *s
 
 	err := keycloakClient.ValidateLdapHardcodedGroupMapper(ctx, ldapMapper)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	err = keycloakClient.NewLdapHardcodedGroupMapper(ctx, ldapMapper)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	setLdapHardcodedGroupMapperData(data, ldapMapper)
@@ -106,12 +106,12 @@ func resourceKeycloakLdapHardcodedGroupMapperUpdate(ctx context.Context, data *s
 
 	err := keycloakClient.ValidateLdapHardcodedGroupMapper(ctx, ldapMapper)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	err = keycloakClient.UpdateLdapHardcodedGroupMapper(ctx, ldapMapper)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	setLdapHardcodedGroupMapperData(data, ldapMapper)
@@ -70,12 +70,12 @@ func resourceKeycloakLdapHardcodedRoleMapperCreate(ctx context.Context, data *sc
 
 	err := keycloakClient.ValidateLdapHardcodedRoleMapper(ctx, ldapMapper)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	err = keycloakClient.NewLdapHardcodedRoleMapper(ctx, ldapMapper)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	setLdapHardcodedRoleMapperData(data, ldapMapper)
@@ -106,12 +106,12 @@ func resourceKeycloakLdapHardcodedRoleMapperUpdate(ctx context.Context, data *sc
 
 	err := keycloakClient.ValidateLdapHardcodedRoleMapper(ctx, ldapMapper)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	err = keycloakClient.UpdateLdapHardcodedRoleMapper(ctx, ldapMapper)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	setLdapHardcodedRoleMapperData(data, ldapMapper)
@@ -122,7 +122,7 @@ func resourceKeycloakLdapUserAttributeMapperCreate(ctx context.Context, data *sc
 
 	err := keycloakClient.NewLdapUserAttributeMapper(ctx, ldapUserAttributeMapper)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	setLdapUserAttributeMapperData(data, ldapUserAttributeMapper)
@@ -153,7 +153,7 @@ func resourceKeycloakLdapUserAttributeMapperUpdate(ctx context.Context, data *sc
 
 	err := keycloakClient.UpdateLdapUserAttributeMapper(ctx, ldapUserAttributeMapper)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	setLdapUserAttributeMapperData(data, ldapUserAttributeMapper)
@@ -467,25 +467,25 @@ func resourceKeycloakLdapUserFederationCreate(ctx context.Context, data *schema.
 
 	realm, err := keycloakClient.GetRealm(ctx, realmId)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	ldap := getLdapUserFederationFromData(data, realm.Id)
 
 	err = keycloakClient.ValidateLdapUserFederation(ctx, ldap)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	err = keycloakClient.NewLdapUserFederation(ctx, realmId, ldap)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	if data.Get("delete_default_mappers").(bool) {
 		err = keycloakClient.DeleteLdapUserFederationMappers(ctx, realmId, ldap.Id)
 		if err != nil {
-			return diag.FromErr(err)
+			return diagnosticsFromError(err, data)
 		}
 	}
 
@@ -518,19 +518,19 @@ func resourceKeycloakLdapUserFederationUpdate(ctx context.Context, data *schema.
 
 	realm, err := keycloakClient.GetRealm(ctx, realmId)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	ldap := getLdapUserFederationFromData(data, realm.Id)
 
 	err = keycloakClient.ValidateLdapUserFederation(ctx, ldap)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	err = keycloakClient.UpdateLdapUserFederation(ctx, realmId, ldap)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	setLdapUserFederationData(data, ldap, realmId)
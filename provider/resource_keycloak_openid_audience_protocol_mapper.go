@@ -118,12 +118,12 @@ func resourceKeycloakOpenIdAudienceProtocolMapperCreate(ctx context.Context, dat
 
 	err := keycloakClient.ValidateOpenIdAudienceProtocolMapper(ctx, openIdAudienceMapper)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	err = keycloakClient.NewOpenIdAudienceProtocolMapper(ctx, openIdAudienceMapper)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	mapFromOpenIdAudienceMapperToData(openIdAudienceMapper, data)
@@ -155,12 +155,12 @@ func resourceKeycloakOpenIdAudienceProtocolMapperUpdate(ctx context.Context, dat
 
 	err := keycloakClient.ValidateOpenIdAudienceProtocolMapper(ctx, openIdAudienceMapper)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	err = keycloakClient.UpdateOpenIdAudienceProtocolMapper(ctx, openIdAudienceMapper)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	return resourceKeycloakOpenIdAudienceProtocolMapperRead(ctx, data, meta)
@@ -81,12 +81,12 @@ func resourceKeycloakOpenIdAudienceResolveProtocolMapperCreate(ctx context.Conte
 
 	err := keycloakClient.ValidateOpenIdAudienceResolveProtocolMapper(ctx, openIdAudienceResolveMapper)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	err = keycloakClient.NewOpenIdAudienceResolveProtocolMapper(ctx, openIdAudienceResolveMapper)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	mapFromOpenIdAudienceResolveMapperToData(openIdAudienceResolveMapper, data)
@@ -519,38 +519,38 @@ func resourceKeycloakOpenidClientCreate(ctx context.Context, data *schema.Resour
 
 	client, err := getOpenidClientFromData(data)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	err = keycloakClient.ValidateOpenidClient(ctx, client)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	if data.Get("import").(bool) {
 		existingClient, err := keycloakClient.GetOpenidClientByClientId(ctx, client.RealmId, client.ClientId)
 		if err != nil {
-			return diag.FromErr(err)
+			return diagnosticsFromError(err, data)
 		}
 
 		if err = mergo.Merge(client, existingClient); err != nil {
-			return diag.FromErr(err)
+			return diagnosticsFromError(err, data)
 		}
 
 		err = keycloakClient.UpdateOpenidClient(ctx, client)
 		if err != nil {
-			return diag.FromErr(err)
+			return diagnosticsFromError(err, data)
 		}
 	} else {
 		err = keycloakClient.NewOpenidClient(ctx, client)
 		if err != nil {
-			return diag.FromErr(err)
+			return diagnosticsFromError(err, data)
 		}
 	}
 
 	err = setOpenidClientData(ctx, keycloakClient, data, client)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	return resourceKeycloakOpenidClientRead(ctx, data, meta)
@@ -569,7 +569,7 @@ func resourceKeycloakOpenidClientRead(ctx context.Context, data *schema.Resource
 
 	err = setOpenidClientData(ctx, keycloakClient, data, client)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	return nil
@@ -580,22 +580,22 @@ func resourceKeycloakOpenidClientUpdate(ctx context.Context, data *schema.Resour
 
 	client, err := getOpenidClientFromData(data)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	err = keycloakClient.ValidateOpenidClient(ctx, client)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	err = keycloakClient.UpdateOpenidClient(ctx, client)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	err = setOpenidClientData(ctx, keycloakClient, data, client)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	return nil
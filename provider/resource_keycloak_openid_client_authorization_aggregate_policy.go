@@ -93,7 +93,7 @@ func resourceKeycloakOpenidClientAuthorizationAggregatePolicyCreate(ctx context.
 	resource := getOpenidClientAuthorizationAggregatePolicyResourceFromData(data)
 	err := keycloakClient.NewOpenidClientAuthorizationAggregatePolicy(ctx, resource)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	setOpenidClientAuthorizationAggregatePolicyResourceData(data, resource)
@@ -124,7 +124,7 @@ func resourceKeycloakOpenidClientAuthorizationAggregatePolicyUpdate(ctx context.
 
 	err := keycloakClient.UpdateOpenidClientAuthorizationAggregatePolicy(ctx, resource)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	setOpenidClientAuthorizationAggregatePolicyResourceData(data, resource)
@@ -94,7 +94,7 @@ func resourceKeycloakOpenidClientAuthorizationClientPolicyCreate(ctx context.Con
 
 	err := keycloakClient.NewOpenidClientAuthorizationClientPolicy(ctx, resource)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	setOpenidClientAuthorizationClientAuthorizationClientPolicyResourceData(data, resource)
@@ -126,7 +126,7 @@ func resourceKeycloakOpenidClientAuthorizationClientPolicyUpdate(ctx context.Con
 
 	err := keycloakClient.UpdateOpenidClientAuthorizationClientPolicy(ctx, resource)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	setOpenidClientAuthorizationClientAuthorizationClientPolicyResourceData(data, resource)
@@ -140,12 +140,12 @@ func resourceKeycloakOpenidClientAuthorizationGroupPolicyCreate(ctx context.Cont
 
 	err := keycloakClient.NewOpenidClientAuthorizationGroupPolicy(ctx, resource)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	err = setOpenidClientAuthorizationGroupPolicyResourceData(ctx, keycloakClient, resource, data)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	return resourceKeycloakOpenidClientAuthorizationGroupPolicyRead(ctx, data, meta)
@@ -165,7 +165,7 @@ func resourceKeycloakOpenidClientAuthorizationGroupPolicyRead(ctx context.Contex
 
 	err = setOpenidClientAuthorizationGroupPolicyResourceData(ctx, keycloakClient, resource, data)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	return nil
@@ -178,12 +178,12 @@ func resourceKeycloakOpenidClientAuthorizationGroupPolicyUpdate(ctx context.Cont
 
 	err := keycloakClient.UpdateOpenidClientAuthorizationGroupPolicy(ctx, resource)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	err = setOpenidClientAuthorizationGroupPolicyResourceData(ctx, keycloakClient, resource, data)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	return nil
@@ -90,7 +90,7 @@ func resourceKeycloakOpenidClientAuthorizationJSPolicyCreate(ctx context.Context
 
 	err := keycloakClient.NewOpenidClientAuthorizationJSPolicy(ctx, resource)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	setOpenidClientAuthorizationJSPolicyResourceData(data, resource)
@@ -122,7 +122,7 @@ func resourceKeycloakOpenidClientAuthorizationJSPolicyUpdate(ctx context.Context
 
 	err := keycloakClient.UpdateOpenidClientAuthorizationJSPolicy(ctx, resource)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	setOpenidClientAuthorizationJSPolicyResourceData(data, resource)
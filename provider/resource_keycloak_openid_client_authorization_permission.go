@@ -138,7 +138,7 @@ func resourceKeycloakOpenidClientAuthorizationPermissionCreate(ctx context.Conte
 
 	err := keycloakClient.NewOpenidClientAuthorizationPermission(ctx, permission)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	setOpenidClientAuthorizationPermissionData(data, permission)
@@ -170,7 +170,7 @@ func resourceKeycloakOpenidClientAuthorizationPermissionUpdate(ctx context.Conte
 
 	err := keycloakClient.UpdateOpenidClientAuthorizationPermission(ctx, permission)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	setOpenidClientAuthorizationPermissionData(data, permission)
@@ -130,7 +130,7 @@ func resourceKeycloakOpenidClientAuthorizationResourceCreate(ctx context.Context
 
 	err := keycloakClient.NewOpenidClientAuthorizationResource(ctx, resource)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	setOpenidClientAuthorizationResourceData(data, resource)
@@ -162,7 +162,7 @@ func resourceKeycloakOpenidClientAuthorizationResourceUpdate(ctx context.Context
 
 	err := keycloakClient.UpdateOpenidClientAuthorizationResource(ctx, resource)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	setOpenidClientAuthorizationResourceData(data, resource)
@@ -127,7 +127,7 @@ func resourceKeycloakOpenidClientAuthorizationRolePolicyCreate(ctx context.Conte
 
 	err := keycloakClient.NewOpenidClientAuthorizationRolePolicy(ctx, resource)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	setOpenidClientAuthorizationRolePolicyResourceData(data, resource)
@@ -159,7 +159,7 @@ func resourceKeycloakOpenidClientAuthorizationRolePolicyUpdate(ctx context.Conte
 
 	err := keycloakClient.UpdateOpenidClientAuthorizationRolePolicy(ctx, resource)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	setOpenidClientAuthorizationRolePolicyResourceData(data, resource)
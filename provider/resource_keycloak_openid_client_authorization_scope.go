@@ -73,7 +73,7 @@ func resourceKeycloakOpenidClientAuthorizationScopeCreate(ctx context.Context, d
 
 	err := keycloakClient.NewOpenidClientAuthorizationScope(ctx, scope)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	setOpenidClientAuthorizationScopeData(data, scope)
@@ -105,7 +105,7 @@ func resourceKeycloakOpenidClientAuthorizationScopeUpdate(ctx context.Context, d
 
 	err := keycloakClient.UpdateOpenidClientAuthorizationScope(ctx, scope)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	setOpenidClientAuthorizationScopeData(data, scope)
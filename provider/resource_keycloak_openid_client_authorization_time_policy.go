@@ -152,7 +152,7 @@ func resourceKeycloakOpenidClientAuthorizationTimePolicyCreate(ctx context.Conte
 
 	err := keycloakClient.NewOpenidClientAuthorizationTimePolicy(ctx, resource)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	setOpenidClientAuthorizationTimePolicyResourceData(data, resource)
@@ -184,7 +184,7 @@ func resourceKeycloakOpenidClientAuthorizationTimePolicyUpdate(ctx context.Conte
 
 	err := keycloakClient.UpdateOpenidClientAuthorizationTimePolicy(ctx, resource)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	setOpenidClientAuthorizationTimePolicyResourceData(data, resource)
@@ -74,7 +74,7 @@ func resourceKeycloakOpenidClientDefaultScopesReconcile(ctx context.Context, dat
 		if keycloak.ErrorIs404(err) {
 			return diag.FromErr(fmt.Errorf("validation error: client with id %s does not exist", clientId))
 		}
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	var openidClientDefaultScopesToDetach []string
@@ -92,13 +92,13 @@ func resourceKeycloakOpenidClientDefaultScopesReconcile(ctx context.Context, dat
 	// detach scopes that aren't in tf state
 	err = keycloakClient.DetachOpenidClientDefaultScopes(ctx, realmId, clientId, openidClientDefaultScopesToDetach)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	// attach scopes that exist in tf state but not in keycloak
 	err = keycloakClient.AttachOpenidClientDefaultScopes(ctx, realmId, clientId, interfaceSliceToStringSlice(tfOpenidClientDefaultScopes.List()))
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	data.SetId(openidClientDefaultScopesId(realmId, clientId))
@@ -74,7 +74,7 @@ func resourceKeycloakOpenidClientOptionalScopesReconcile(ctx context.Context, da
 		if keycloak.ErrorIs404(err) {
 			return diag.FromErr(fmt.Errorf("validation error: client with id %s does not exist", clientId))
 		}
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	var openidClientOptionalScopesToDetach []string
@@ -92,13 +92,13 @@ func resourceKeycloakOpenidClientOptionalScopesReconcile(ctx context.Context, da
 	// detach scopes that aren't in tf state
 	err = keycloakClient.DetachOpenidClientOptionalScopes(ctx, realmId, clientId, openidClientOptionalScopesToDetach)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	// attach scopes that exist in tf state but not in keycloak
 	err = keycloakClient.AttachOpenidClientOptionalScopes(ctx, realmId, clientId, interfaceSliceToStringSlice(tfOpenidClientOptionalScopes.List()))
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	data.SetId(openidClientOptionalScopesId(realmId, clientId))
@@ -102,7 +102,7 @@ func resourceKeycloakOpenidClientScopeCreate(ctx context.Context, data *schema.R
 
 	err := keycloakClient.NewOpenidClientScope(ctx, clientScope)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	setOpenidClientScopeData(data, clientScope)
@@ -133,7 +133,7 @@ func resourceKeycloakOpenidClientScopeUpdate(ctx context.Context, data *schema.R
 
 	err := keycloakClient.UpdateOpenidClientScope(ctx, clientScope)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	setOpenidClientScopeData(data, clientScope)
@@ -70,12 +70,12 @@ func resourceKeycloakOpenidClientServiceAccountRealmRoleCreate(ctx context.Conte
 	keycloakClient := meta.(*keycloak.KeycloakClient)
 	serviceAccountRole, err := getOpenidClientServiceAccountRealmRoleFromData(ctx, data, keycloakClient)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	err = keycloakClient.NewOpenidClientServiceAccountRealmRole(ctx, serviceAccountRole)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 	setOpenidClientServiceAccountRealmRoleData(data, serviceAccountRole)
 	return resourceKeycloakOpenidClientServiceAccountRealmRoleRead(ctx, data, meta)
@@ -86,7 +86,7 @@ func resourceKeycloakOpenidClientServiceAccountRealmRoleRead(ctx context.Context
 
 	serviceAccountRole, err := getOpenidClientServiceAccountRealmRoleFromData(ctx, data, keycloakClient)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	serviceAccountRole, err = keycloakClient.GetOpenidClientServiceAccountRealmRole(ctx, serviceAccountRole.RealmId, serviceAccountRole.ServiceAccountUserId, serviceAccountRole.Id)
@@ -104,7 +104,7 @@ func resourceKeycloakOpenidClientServiceAccountRealmRoleDelete(ctx context.Conte
 
 	serviceAccountRole, err := getOpenidClientServiceAccountRealmRoleFromData(ctx, data, keycloakClient)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	err = keycloakClient.DeleteOpenidClientServiceAccountRealmRole(ctx, serviceAccountRole.RealmId, serviceAccountRole.ServiceAccountUserId, serviceAccountRole.Id)
@@ -78,12 +78,12 @@ func resourceKeycloakOpenidClientServiceAccountRoleCreate(ctx context.Context, d
 	keycloakClient := meta.(*keycloak.KeycloakClient)
 	serviceAccountRole, err := getOpenidClientServiceAccountRoleFromData(ctx, data, keycloakClient)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	err = keycloakClient.NewOpenidClientServiceAccountRole(ctx, serviceAccountRole)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 	setOpenidClientServiceAccountRoleData(data, serviceAccountRole)
 	return resourceKeycloakOpenidClientServiceAccountRoleRead(ctx, data, meta)
@@ -94,7 +94,7 @@ func resourceKeycloakOpenidClientServiceAccountRoleRead(ctx context.Context, dat
 
 	serviceAccountRole, err := getOpenidClientServiceAccountRoleFromData(ctx, data, keycloakClient)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	serviceAccountRole, err = keycloakClient.GetOpenidClientServiceAccountRole(ctx, serviceAccountRole.RealmId, serviceAccountRole.ServiceAccountUserId, serviceAccountRole.ContainerId, serviceAccountRole.Id)
@@ -112,7 +112,7 @@ func resourceKeycloakOpenidClientServiceAccountRoleDelete(ctx context.Context, d
 
 	serviceAccountRole, err := getOpenidClientServiceAccountRoleFromData(ctx, data, keycloakClient)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	err = keycloakClient.DeleteOpenidClientServiceAccountRole(ctx, serviceAccountRole.RealmId, serviceAccountRole.ServiceAccountUserId, serviceAccountRole.ContainerId, serviceAccountRole.Id)
@@ -103,12 +103,12 @@ func resourceKeycloakOpenIdFullNameProtocolMapperCreate(ctx context.Context, dat
 
 	err := keycloakClient.ValidateOpenIdFullNameProtocolMapper(ctx, openIdFullNameMapper)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	err = keycloakClient.NewOpenIdFullNameProtocolMapper(ctx, openIdFullNameMapper)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	mapFromOpenIdFullNameMapperToData(openIdFullNameMapper, data)
@@ -140,12 +140,12 @@ func resourceKeycloakOpenIdFullNameProtocolMapperUpdate(ctx context.Context, dat
 
 	err := keycloakClient.ValidateOpenIdFullNameProtocolMapper(ctx, openIdFullNameMapper)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	err = keycloakClient.UpdateOpenIdFullNameProtocolMapper(ctx, openIdFullNameMapper)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	return resourceKeycloakOpenIdFullNameProtocolMapperRead(ctx, data, meta)
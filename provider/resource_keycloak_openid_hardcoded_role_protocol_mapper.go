@@ -88,12 +88,12 @@ func resourceKeycloakOpenIdHardcodedRoleProtocolMapperCreate(ctx context.Context
 
 	err := keycloakClient.ValidateOpenIdHardcodedRoleProtocolMapper(ctx, openIdHardcodedRoleMapper)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	err = keycloakClient.NewOpenIdHardcodedRoleProtocolMapper(ctx, openIdHardcodedRoleMapper)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	mapFromOpenIdHardcodedRoleMapperToData(openIdHardcodedRoleMapper, data)
@@ -125,12 +125,12 @@ func resourceKeycloakOpenIdHardcodedRoleProtocolMapperUpdate(ctx context.Context
 
 	err := keycloakClient.ValidateOpenIdHardcodedRoleProtocolMapper(ctx, openIdHardcodedRoleMapper)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	err = keycloakClient.UpdateOpenIdHardcodedRoleProtocolMapper(ctx, openIdHardcodedRoleMapper)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	return resourceKeycloakOpenIdHardcodedRoleProtocolMapperRead(ctx, data, meta)
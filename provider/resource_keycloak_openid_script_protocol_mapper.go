@@ -136,12 +136,12 @@ func resourceKeycloakOpenIdScriptProtocolMapperCreate(ctx context.Context, data
 
 	err := keycloakClient.ValidateOpenIdScriptProtocolMapper(ctx, openIdScriptMapper)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	err = keycloakClient.NewOpenIdScriptProtocolMapper(ctx, openIdScriptMapper)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	mapFromOpenIdScriptMapperToData(openIdScriptMapper, data)
@@ -173,12 +173,12 @@ func resourceKeycloakOpenIdScriptProtocolMapperUpdate(ctx context.Context, data
 
 	err := keycloakClient.ValidateOpenIdScriptProtocolMapper(ctx, openIdScriptMapper)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	err = keycloakClient.UpdateOpenIdScriptProtocolMapper(ctx, openIdScriptMapper)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	return resourceKeycloakOpenIdScriptProtocolMapperRead(ctx, data, meta)
@@ -143,12 +143,12 @@ func resourceKeycloakOpenIdUserClientRoleProtocolMapperCreate(ctx context.Contex
 
 	err := keycloakClient.ValidateOpenIdUserClientRoleProtocolMapper(ctx, openIdUserClientRoleMapper)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	err = keycloakClient.NewOpenIdUserClientRoleProtocolMapper(ctx, openIdUserClientRoleMapper)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	mapFromOpenIdUserClientRoleMapperToData(openIdUserClientRoleMapper, data)
@@ -179,12 +179,12 @@ func resourceKeycloakOpenIdUserClientRoleProtocolMapperUpdate(ctx context.Contex
 
 	err := keycloakClient.ValidateOpenIdUserClientRoleProtocolMapper(ctx, openIdUserClientRoleMapper)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	err = keycloakClient.UpdateOpenIdUserClientRoleProtocolMapper(ctx, openIdUserClientRoleMapper)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	return resourceKeycloakOpenIdUserClientRoleProtocolMapperRead(ctx, data, meta)
@@ -0,0 +1,212 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/keycloak/terraform-provider-keycloak/keycloak"
+)
+
+func resourceKeycloakOrganization() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceKeycloakOrganizationCreate,
+		ReadContext:   resourceKeycloakOrganizationRead,
+		DeleteContext: resourceKeycloakOrganizationDelete,
+		UpdateContext: resourceKeycloakOrganizationUpdate,
+		// This resource can be imported using {{realm}}/{{organization_id}}
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceKeycloakOrganizationImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"realm_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"alias": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "The alias of the organization. Defaults to the name of the organization and cannot be changed after creation.",
+			},
+			"enabled": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"redirect_url": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The URL members are redirected to after completing their registration or accepting an invitation.",
+			},
+			"domain": {
+				Type:     schema.TypeSet,
+				Required: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"verified": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  false,
+						},
+					},
+				},
+			},
+			"attributes": {
+				Type:     schema.TypeMap,
+				Optional: true,
+			},
+		},
+	}
+}
+
+func mapFromDataToOrganization(data *schema.ResourceData) *keycloak.Organization {
+	attributes := map[string][]string{}
+	if v, ok := data.GetOk("attributes"); ok {
+		for key, value := range v.(map[string]interface{}) {
+			attributes[key] = strings.Split(value.(string), MULTIVALUE_ATTRIBUTE_SEPARATOR)
+		}
+	}
+
+	domains := make([]*keycloak.OrganizationDomain, 0)
+	for _, d := range data.Get("domain").(*schema.Set).List() {
+		domain := d.(map[string]interface{})
+
+		domains = append(domains, &keycloak.OrganizationDomain{
+			Name:     domain["name"].(string),
+			Verified: domain["verified"].(bool),
+		})
+	}
+
+	return &keycloak.Organization{
+		Id:          data.Id(),
+		RealmId:     data.Get("realm_id").(string),
+		Name:        data.Get("name").(string),
+		Alias:       data.Get("alias").(string),
+		Enabled:     data.Get("enabled").(bool),
+		Description: data.Get("description").(string),
+		RedirectUrl: data.Get("redirect_url").(string),
+		Domains:     domains,
+		Attributes:  attributes,
+	}
+}
+
+func mapFromOrganizationToData(data *schema.ResourceData, organization *keycloak.Organization) {
+	attributes := map[string]string{}
+	for k, v := range organization.Attributes {
+		attributes[k] = strings.Join(v, MULTIVALUE_ATTRIBUTE_SEPARATOR)
+	}
+
+	domains := make([]interface{}, 0)
+	for _, domain := range organization.Domains {
+		domains = append(domains, map[string]interface{}{
+			"name":     domain.Name,
+			"verified": domain.Verified,
+		})
+	}
+
+	data.SetId(organization.Id)
+	data.Set("realm_id", organization.RealmId)
+	data.Set("name", organization.Name)
+	data.Set("alias", organization.Alias)
+	data.Set("enabled", organization.Enabled)
+	data.Set("description", organization.Description)
+	data.Set("redirect_url", organization.RedirectUrl)
+	data.Set("domain", domains)
+	data.Set("attributes", attributes)
+}
+
+func resourceKeycloakOrganizationCreate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	err := keycloakClient.CheckOrganizationsSupported(ctx)
+	if err != nil {
+		return diagnosticsFromError(err, data)
+	}
+
+	organization := mapFromDataToOrganization(data)
+
+	err = keycloakClient.NewOrganization(ctx, organization)
+	if err != nil {
+		return diagnosticsFromError(err, data)
+	}
+
+	mapFromOrganizationToData(data, organization)
+
+	return resourceKeycloakOrganizationRead(ctx, data, meta)
+}
+
+func resourceKeycloakOrganizationRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	realmId := data.Get("realm_id").(string)
+	id := data.Id()
+
+	organization, err := keycloakClient.GetOrganization(ctx, realmId, id)
+	if err != nil {
+		return handleNotFoundError(ctx, err, data)
+	}
+
+	mapFromOrganizationToData(data, organization)
+
+	return nil
+}
+
+func resourceKeycloakOrganizationUpdate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	organization := mapFromDataToOrganization(data)
+
+	err := keycloakClient.UpdateOrganization(ctx, organization)
+	if err != nil {
+		return diagnosticsFromError(err, data)
+	}
+
+	mapFromOrganizationToData(data, organization)
+
+	return nil
+}
+
+func resourceKeycloakOrganizationDelete(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	realmId := data.Get("realm_id").(string)
+	id := data.Id()
+
+	return diag.FromErr(keycloakClient.DeleteOrganization(ctx, realmId, id))
+}
+
+func resourceKeycloakOrganizationImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	parts := strings.Split(d.Id(), "/")
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("Invalid import. Supported import formats: {{realmId}}/{{organizationId}}")
+	}
+
+	err := keycloakClient.CheckOrganizationsSupported(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	d.Set("realm_id", parts[0])
+	d.SetId(parts[1])
+
+	return []*schema.ResourceData{d}, nil
+}
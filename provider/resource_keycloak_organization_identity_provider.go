@@ -0,0 +1,152 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/keycloak/terraform-provider-keycloak/keycloak"
+)
+
+func resourceKeycloakOrganizationIdentityProvider() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceKeycloakOrganizationIdentityProviderCreate,
+		ReadContext:   resourceKeycloakOrganizationIdentityProviderRead,
+		DeleteContext: resourceKeycloakOrganizationIdentityProviderDelete,
+		UpdateContext: resourceKeycloakOrganizationIdentityProviderUpdate,
+		// This resource can be imported using {{realm}}/{{organization_id}}/{{identity_provider_alias}}
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceKeycloakOrganizationIdentityProviderImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"realm_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"organization_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"identity_provider_alias": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The alias of an existing identity provider, which will be linked to the organization.",
+			},
+			"domain": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "One of the domains of the organization. Users with an email matching this domain are associated with the identity provider.",
+			},
+			"redirect_email_matches": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "When true, users with an email matching the domain are automatically redirected to the identity provider.",
+			},
+		},
+	}
+}
+
+func mapFromDataToOrganizationIdentityProvider(data *schema.ResourceData) *keycloak.OrganizationIdentityProvider {
+	return &keycloak.OrganizationIdentityProvider{
+		RealmId:              data.Get("realm_id").(string),
+		OrganizationId:       data.Get("organization_id").(string),
+		Alias:                data.Get("identity_provider_alias").(string),
+		Domain:               data.Get("domain").(string),
+		RedirectEmailMatches: data.Get("redirect_email_matches").(bool),
+	}
+}
+
+func mapFromOrganizationIdentityProviderToData(data *schema.ResourceData, organizationIdentityProvider *keycloak.OrganizationIdentityProvider) {
+	data.SetId(organizationIdentityProviderId(organizationIdentityProvider.RealmId, organizationIdentityProvider.OrganizationId, organizationIdentityProvider.Alias))
+	data.Set("realm_id", organizationIdentityProvider.RealmId)
+	data.Set("organization_id", organizationIdentityProvider.OrganizationId)
+	data.Set("identity_provider_alias", organizationIdentityProvider.Alias)
+	data.Set("domain", organizationIdentityProvider.Domain)
+	data.Set("redirect_email_matches", organizationIdentityProvider.RedirectEmailMatches)
+}
+
+func resourceKeycloakOrganizationIdentityProviderCreate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	err := keycloakClient.CheckOrganizationsSupported(ctx)
+	if err != nil {
+		return diagnosticsFromError(err, data)
+	}
+
+	organizationIdentityProvider := mapFromDataToOrganizationIdentityProvider(data)
+
+	err = keycloakClient.NewOrganizationIdentityProvider(ctx, organizationIdentityProvider)
+	if err != nil {
+		return diagnosticsFromError(err, data)
+	}
+
+	mapFromOrganizationIdentityProviderToData(data, organizationIdentityProvider)
+
+	return resourceKeycloakOrganizationIdentityProviderRead(ctx, data, meta)
+}
+
+func resourceKeycloakOrganizationIdentityProviderRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	realmId := data.Get("realm_id").(string)
+	organizationId := data.Get("organization_id").(string)
+	alias := data.Get("identity_provider_alias").(string)
+
+	organizationIdentityProvider, err := keycloakClient.GetOrganizationIdentityProvider(ctx, realmId, organizationId, alias)
+	if err != nil {
+		return handleNotFoundError(ctx, err, data)
+	}
+
+	mapFromOrganizationIdentityProviderToData(data, organizationIdentityProvider)
+
+	return nil
+}
+
+func resourceKeycloakOrganizationIdentityProviderUpdate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	organizationIdentityProvider := mapFromDataToOrganizationIdentityProvider(data)
+
+	err := keycloakClient.UpdateOrganizationIdentityProvider(ctx, organizationIdentityProvider)
+	if err != nil {
+		return diagnosticsFromError(err, data)
+	}
+
+	mapFromOrganizationIdentityProviderToData(data, organizationIdentityProvider)
+
+	return nil
+}
+
+func resourceKeycloakOrganizationIdentityProviderDelete(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	realmId := data.Get("realm_id").(string)
+	organizationId := data.Get("organization_id").(string)
+	alias := data.Get("identity_provider_alias").(string)
+
+	return diag.FromErr(keycloakClient.DeleteOrganizationIdentityProvider(ctx, realmId, organizationId, alias))
+}
+
+func resourceKeycloakOrganizationIdentityProviderImport(_ context.Context, d *schema.ResourceData, _ interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.Split(d.Id(), "/")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("Invalid import. Supported import formats: {{realmId}}/{{organizationId}}/{{identityProviderAlias}}")
+	}
+
+	d.Set("realm_id", parts[0])
+	d.Set("organization_id", parts[1])
+	d.Set("identity_provider_alias", parts[2])
+	d.SetId(organizationIdentityProviderId(parts[0], parts[1], parts[2]))
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func organizationIdentityProviderId(realmId, organizationId, alias string) string {
+	return fmt.Sprintf("%s/%s/%s", realmId, organizationId, alias)
+}
@@ -0,0 +1,123 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/keycloak/terraform-provider-keycloak/keycloak"
+)
+
+func TestAccKeycloakOrganizationIdentityProvider_basic(t *testing.T) {
+	skipIfFeatureIsDisabled(testCtx, t, keycloakClient, keycloak.FeatureOrganization)
+
+	realmName := acctest.RandomWithPrefix("tf-acc")
+	alias := acctest.RandomWithPrefix("tf-acc")
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		PreCheck:          func() { testAccPreCheck(t) },
+		CheckDestroy:      testAccCheckKeycloakOrganizationIdentityProviderDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config: testKeycloakOrganizationIdentityProvider_basic(realmName, alias, false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckKeycloakOrganizationIdentityProviderExists("keycloak_organization_identity_provider.link"),
+					resource.TestCheckResourceAttr("keycloak_organization_identity_provider.link", "domain", "example.com"),
+					resource.TestCheckResourceAttr("keycloak_organization_identity_provider.link", "redirect_email_matches", "false"),
+				),
+			},
+			{
+				Config: testKeycloakOrganizationIdentityProvider_basic(realmName, alias, true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckKeycloakOrganizationIdentityProviderExists("keycloak_organization_identity_provider.link"),
+					resource.TestCheckResourceAttr("keycloak_organization_identity_provider.link", "redirect_email_matches", "true"),
+				),
+			},
+			{
+				ResourceName:      "keycloak_organization_identity_provider.link",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckKeycloakOrganizationIdentityProviderExists(resourceName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("resource not found: %s", resourceName)
+		}
+
+		realmId := rs.Primary.Attributes["realm_id"]
+		organizationId := rs.Primary.Attributes["organization_id"]
+		alias := rs.Primary.Attributes["identity_provider_alias"]
+
+		_, err := keycloakClient.GetOrganizationIdentityProvider(testCtx, realmId, organizationId, alias)
+		if err != nil {
+			return fmt.Errorf("error getting identity provider %s linked to organization %s: %s", alias, organizationId, err)
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckKeycloakOrganizationIdentityProviderDestroy() resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		for _, rs := range s.RootModule().Resources {
+			if rs.Type != "keycloak_organization_identity_provider" {
+				continue
+			}
+
+			realmId := rs.Primary.Attributes["realm_id"]
+			organizationId := rs.Primary.Attributes["organization_id"]
+			alias := rs.Primary.Attributes["identity_provider_alias"]
+
+			organizationIdentityProvider, _ := keycloakClient.GetOrganizationIdentityProvider(testCtx, realmId, organizationId, alias)
+			if organizationIdentityProvider != nil {
+				return fmt.Errorf("identity provider %s is still linked to organization %s", alias, organizationId)
+			}
+		}
+
+		return nil
+	}
+}
+
+func testKeycloakOrganizationIdentityProvider_basic(realm, alias string, redirectEmailMatches bool) string {
+	return fmt.Sprintf(`
+resource "keycloak_realm" "realm" {
+	realm                 = "%s"
+	organizations_enabled = true
+}
+
+resource "keycloak_organization" "organization" {
+	realm_id = keycloak_realm.realm.id
+	name     = "%s"
+
+	domain {
+		name = "example.com"
+	}
+}
+
+resource "keycloak_oidc_identity_provider" "oidc" {
+	realm             = keycloak_realm.realm.id
+	alias             = "%s"
+	authorization_url = "https://example.com/auth"
+	token_url         = "https://example.com/token"
+	client_id         = "example_id"
+	client_secret     = "example_token"
+}
+
+resource "keycloak_organization_identity_provider" "link" {
+	realm_id                = keycloak_realm.realm.id
+	organization_id         = keycloak_organization.organization.id
+	identity_provider_alias = keycloak_oidc_identity_provider.oidc.alias
+
+	domain                 = "example.com"
+	redirect_email_matches = %t
+}
+	`, realm, realm, alias, redirectEmailMatches)
+}
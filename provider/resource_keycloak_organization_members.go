@@ -0,0 +1,149 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/keycloak/terraform-provider-keycloak/keycloak"
+)
+
+func resourceKeycloakOrganizationMembers() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceKeycloakOrganizationMembersCreate,
+		ReadContext:   resourceKeycloakOrganizationMembersRead,
+		DeleteContext: resourceKeycloakOrganizationMembersDelete,
+		UpdateContext: resourceKeycloakOrganizationMembersUpdate,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceKeycloakOrganizationMembersImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"realm_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"organization_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"members": {
+				Type:        schema.TypeSet,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Set:         schema.HashString,
+				Required:    true,
+				Description: "Usernames of the members of the organization.",
+			},
+		},
+	}
+}
+
+func resourceKeycloakOrganizationMembersCreate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	err := keycloakClient.CheckOrganizationsSupported(ctx)
+	if err != nil {
+		return diagnosticsFromError(err, data)
+	}
+
+	realmId := data.Get("realm_id").(string)
+	organizationId := data.Get("organization_id").(string)
+	members := data.Get("members").(*schema.Set).List()
+
+	err = keycloakClient.AddUsersToOrganization(ctx, realmId, organizationId, members)
+	if err != nil {
+		return diagnosticsFromError(err, data)
+	}
+
+	data.SetId(organizationMembersId(realmId, organizationId))
+
+	return resourceKeycloakOrganizationMembersRead(ctx, data, meta)
+}
+
+func resourceKeycloakOrganizationMembersRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	realmId := data.Get("realm_id").(string)
+	organizationId := data.Get("organization_id").(string)
+
+	organizationMembers, err := keycloakClient.GetOrganizationMembers(ctx, realmId, organizationId)
+	if err != nil {
+		return handleNotFoundError(ctx, err, data)
+	}
+
+	var members []string
+	for _, organizationMember := range organizationMembers {
+		members = append(members, organizationMember.Username)
+	}
+
+	data.Set("members", members)
+	data.SetId(organizationMembersId(realmId, organizationId))
+
+	return nil
+}
+
+func resourceKeycloakOrganizationMembersUpdate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	realmId := data.Get("realm_id").(string)
+	organizationId := data.Get("organization_id").(string)
+	tfMembers := data.Get("members").(*schema.Set)
+
+	keycloakMembers, err := keycloakClient.GetOrganizationMembers(ctx, realmId, organizationId)
+	if err != nil {
+		return diagnosticsFromError(err, data)
+	}
+
+	for _, keycloakMember := range keycloakMembers {
+		if tfMembers.Contains(keycloakMember.Username) {
+			// if the user exists in keycloak and tf state, no update is required for this member
+			// remove them from the set so we can look at members that need to be added later
+			tfMembers.Remove(keycloakMember.Username)
+		} else {
+			// if the user exists in keycloak and not in tf state, they need to be removed from the organization
+			err = keycloakClient.RemoveUserFromOrganization(ctx, realmId, organizationId, keycloakMember.Id)
+			if err != nil {
+				return diagnosticsFromError(err, data)
+			}
+		}
+	}
+
+	// at this point, `tfMembers` should only contain users that exist in tf state but not keycloak. these users need to be added
+	err = keycloakClient.AddUsersToOrganization(ctx, realmId, organizationId, tfMembers.List())
+	if err != nil {
+		return diagnosticsFromError(err, data)
+	}
+
+	data.SetId(organizationMembersId(realmId, organizationId))
+
+	return resourceKeycloakOrganizationMembersRead(ctx, data, meta)
+}
+
+func resourceKeycloakOrganizationMembersDelete(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	realmId := data.Get("realm_id").(string)
+	organizationId := data.Get("organization_id").(string)
+
+	return diag.FromErr(keycloakClient.RemoveUsersFromOrganization(ctx, realmId, organizationId, data.Get("members").(*schema.Set).List()))
+}
+
+func resourceKeycloakOrganizationMembersImport(_ context.Context, d *schema.ResourceData, _ interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.Split(d.Id(), "/")
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("Invalid import. Supported import formats: {{realmId}}/{{organizationId}}")
+	}
+
+	d.Set("realm_id", parts[0])
+	d.Set("organization_id", parts[1])
+	d.SetId(organizationMembersId(parts[0], parts[1]))
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func organizationMembersId(realmId, organizationId string) string {
+	return fmt.Sprintf("%s/organization-members/%s", realmId, organizationId)
+}
@@ -0,0 +1,122 @@
+package provider
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/keycloak/terraform-provider-keycloak/keycloak"
+)
+
+func TestAccKeycloakOrganizationMembers_basic(t *testing.T) {
+	skipIfFeatureIsDisabled(testCtx, t, keycloakClient, keycloak.FeatureOrganization)
+
+	realmName := acctest.RandomWithPrefix("tf-acc")
+	usernames := []string{acctest.RandomWithPrefix("tf-acc"), acctest.RandomWithPrefix("tf-acc")}
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		PreCheck:          func() { testAccPreCheck(t) },
+		Steps: []resource.TestStep{
+			{
+				Config: testKeycloakOrganizationMembers_basic(realmName, usernames, usernames),
+				Check:  testAccCheckKeycloakOrganizationMembers("keycloak_organization_members.members", usernames),
+			},
+			{
+				ResourceName:      "keycloak_organization_members.members",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					rs := s.RootModule().Resources["keycloak_organization_members.members"]
+
+					return fmt.Sprintf("%s/%s", rs.Primary.Attributes["realm_id"], rs.Primary.Attributes["organization_id"]), nil
+				},
+			},
+			{
+				Config: testKeycloakOrganizationMembers_basic(realmName, usernames, usernames[:1]),
+				Check:  testAccCheckKeycloakOrganizationMembers("keycloak_organization_members.members", usernames[:1]),
+			},
+		},
+	})
+}
+
+func testAccCheckKeycloakOrganizationMembers(resourceName string, usernames []string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("resource not found: %s", resourceName)
+		}
+
+		realmId := rs.Primary.Attributes["realm_id"]
+		organizationId := rs.Primary.Attributes["organization_id"]
+
+		members, err := keycloakClient.GetOrganizationMembers(testCtx, realmId, organizationId)
+		if err != nil {
+			return err
+		}
+
+		if len(members) != len(usernames) {
+			return fmt.Errorf("expected organization %s to have %d members, but found %d", organizationId, len(usernames), len(members))
+		}
+
+		for _, member := range members {
+			if !stringSliceContains(usernames, member.Username) {
+				return fmt.Errorf("unexpected member %s in organization %s", member.Username, organizationId)
+			}
+		}
+
+		return nil
+	}
+}
+
+func testKeycloakOrganizationMembers_basic(realm string, definedUsers, members []string) string {
+	var users string
+	for i, username := range definedUsers {
+		users += fmt.Sprintf(`
+resource "keycloak_user" "user_%d" {
+	realm_id = keycloak_realm.realm.id
+	username = "%s"
+	email    = "%s@example.com"
+}
+`, i, username, username)
+	}
+
+	return fmt.Sprintf(`
+resource "keycloak_realm" "realm" {
+	realm                 = "%s"
+	organizations_enabled = true
+}
+
+resource "keycloak_organization" "organization" {
+	realm_id = keycloak_realm.realm.id
+	name     = "%s"
+
+	domain {
+		name = "example.com"
+	}
+}
+
+%s
+
+resource "keycloak_organization_members" "members" {
+	realm_id        = keycloak_realm.realm.id
+	organization_id = keycloak_organization.organization.id
+
+	members = %s
+
+	depends_on = [%s]
+}
+	`, realm, realm, users, arrayOfStringsForTerraformResource(members), testKeycloakOrganizationMembers_userReferences(definedUsers))
+}
+
+func testKeycloakOrganizationMembers_userReferences(definedUsers []string) string {
+	var references []string
+	for i := range definedUsers {
+		references = append(references, fmt.Sprintf("keycloak_user.user_%d", i))
+	}
+
+	return strings.Join(references, ", ")
+}
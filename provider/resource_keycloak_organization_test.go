@@ -0,0 +1,186 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/keycloak/terraform-provider-keycloak/keycloak"
+)
+
+func TestAccKeycloakOrganization_basic(t *testing.T) {
+	skipIfFeatureIsDisabled(testCtx, t, keycloakClient, keycloak.FeatureOrganization)
+
+	realmName := acctest.RandomWithPrefix("tf-acc")
+	organizationName := acctest.RandomWithPrefix("tf-acc")
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		PreCheck:          func() { testAccPreCheck(t) },
+		CheckDestroy:      testAccCheckKeycloakOrganizationDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config: testKeycloakOrganization_basic(realmName, organizationName, "initial description"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckKeycloakOrganizationExists("keycloak_organization.organization"),
+					resource.TestCheckResourceAttr("keycloak_organization.organization", "alias", organizationName),
+					resource.TestCheckResourceAttr("keycloak_organization.organization", "domain.#", "2"),
+					resource.TestCheckResourceAttr("keycloak_organization.organization", "attributes.tier", "gold"),
+				),
+			},
+			{
+				ResourceName:        "keycloak_organization.organization",
+				ImportState:         true,
+				ImportStateVerify:   true,
+				ImportStateIdPrefix: realmName + "/",
+			},
+		},
+	})
+}
+
+func TestAccKeycloakOrganization_update(t *testing.T) {
+	skipIfFeatureIsDisabled(testCtx, t, keycloakClient, keycloak.FeatureOrganization)
+
+	realmName := acctest.RandomWithPrefix("tf-acc")
+	organizationName := acctest.RandomWithPrefix("tf-acc")
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		PreCheck:          func() { testAccPreCheck(t) },
+		CheckDestroy:      testAccCheckKeycloakOrganizationDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config: testKeycloakOrganization_basic(realmName, organizationName, "initial description"),
+				Check:  testAccCheckKeycloakOrganizationExists("keycloak_organization.organization"),
+			},
+			{
+				Config: testKeycloakOrganization_basic(realmName, organizationName, "updated description"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckKeycloakOrganizationExists("keycloak_organization.organization"),
+					resource.TestCheckResourceAttr("keycloak_organization.organization", "description", "updated description"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccKeycloakOrganization_createAfterManualDestroy(t *testing.T) {
+	skipIfFeatureIsDisabled(testCtx, t, keycloakClient, keycloak.FeatureOrganization)
+
+	var organization = &keycloak.Organization{}
+
+	realmName := acctest.RandomWithPrefix("tf-acc")
+	organizationName := acctest.RandomWithPrefix("tf-acc")
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		PreCheck:          func() { testAccPreCheck(t) },
+		CheckDestroy:      testAccCheckKeycloakOrganizationDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config: testKeycloakOrganization_basic(realmName, organizationName, "initial description"),
+				Check:  testAccCheckKeycloakOrganizationFetch("keycloak_organization.organization", organization),
+			},
+			{
+				PreConfig: func() {
+					err := keycloakClient.DeleteOrganization(testCtx, organization.RealmId, organization.Id)
+					if err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config: testKeycloakOrganization_basic(realmName, organizationName, "initial description"),
+				Check:  testAccCheckKeycloakOrganizationExists("keycloak_organization.organization"),
+			},
+		},
+	})
+}
+
+func testAccCheckKeycloakOrganizationExists(resourceName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		_, err := getOrganizationFromState(s, resourceName)
+
+		return err
+	}
+}
+
+func testAccCheckKeycloakOrganizationFetch(resourceName string, organization *keycloak.Organization) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		fetchedOrganization, err := getOrganizationFromState(s, resourceName)
+		if err != nil {
+			return err
+		}
+
+		organization.Id = fetchedOrganization.Id
+		organization.RealmId = fetchedOrganization.RealmId
+
+		return nil
+	}
+}
+
+func testAccCheckKeycloakOrganizationDestroy() resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		for _, rs := range s.RootModule().Resources {
+			if rs.Type != "keycloak_organization" {
+				continue
+			}
+
+			id := rs.Primary.ID
+			realm := rs.Primary.Attributes["realm_id"]
+
+			organization, _ := keycloakClient.GetOrganization(testCtx, realm, id)
+			if organization != nil {
+				return fmt.Errorf("organization with id %s still exists", id)
+			}
+		}
+
+		return nil
+	}
+}
+
+func getOrganizationFromState(s *terraform.State, resourceName string) (*keycloak.Organization, error) {
+	rs, ok := s.RootModule().Resources[resourceName]
+	if !ok {
+		return nil, fmt.Errorf("resource not found: %s", resourceName)
+	}
+
+	id := rs.Primary.ID
+	realm := rs.Primary.Attributes["realm_id"]
+
+	organization, err := keycloakClient.GetOrganization(testCtx, realm, id)
+	if err != nil {
+		return nil, fmt.Errorf("error getting organization with id %s: %s", id, err)
+	}
+
+	return organization, nil
+}
+
+func testKeycloakOrganization_basic(realm, organization, description string) string {
+	return fmt.Sprintf(`
+resource "keycloak_realm" "realm" {
+	realm                 = "%s"
+	organizations_enabled = true
+}
+
+resource "keycloak_organization" "organization" {
+	realm_id     = keycloak_realm.realm.id
+	name         = "%s"
+	description  = "%s"
+	redirect_url = "https://example.com/welcome"
+
+	domain {
+		name     = "example.com"
+		verified = true
+	}
+
+	domain {
+		name = "example.org"
+	}
+
+	attributes = {
+		tier = "gold"
+	}
+}
+	`, realm, organization, description)
+}
@@ -2,18 +2,50 @@ package provider
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/keycloak/terraform-provider-keycloak/keycloak"
 	"github.com/keycloak/terraform-provider-keycloak/keycloak/types"
+	"strconv"
 )
 
 var (
-	keycloakRealmValidOTPTypes      = []string{"totp", "hotp"}
-	keycloakRealmValidOTPAlgorithms = []string{"HmacSHA1", "HmacSHA256", "HmacSHA512"}
+	keycloakRealmValidOTPTypes                          = []string{"totp", "hotp"}
+	keycloakRealmValidOTPAlgorithms                     = []string{"HmacSHA1", "HmacSHA256", "HmacSHA512"}
+	keycloakRealmValidCibaBackchannelTokenDeliveryModes = []string{"poll", "ping"}
 )
 
+// keycloakRealmPasswordPolicies are the arguments of the password_policies block and the ids of the password policy
+// providers they are for. Boolean arguments are for policies without config, like notUsername.
+var keycloakRealmPasswordPolicies = []struct {
+	argument    string
+	id          string
+	valueType   schema.ValueType
+	description string
+}{
+	{"length", "length", schema.TypeInt, "Minimum length of passwords"},
+	{"max_length", "maxLength", schema.TypeInt, "Maximum length of passwords"},
+	{"digits", "digits", schema.TypeInt, "Minimum number of digits in passwords"},
+	{"lower_case", "lowerCase", schema.TypeInt, "Minimum number of lower case characters in passwords"},
+	{"upper_case", "upperCase", schema.TypeInt, "Minimum number of upper case characters in passwords"},
+	{"special_chars", "specialChars", schema.TypeInt, "Minimum number of special characters in passwords"},
+	{"not_username", "notUsername", schema.TypeBool, "Whether passwords can't be the same as the username"},
+	{"not_email", "notEmail", schema.TypeBool, "Whether passwords can't be the same as the email address"},
+	{"not_contains_username", "notContainsUsername", schema.TypeBool, "Whether passwords can't contain the username"},
+	{"regex_pattern", "regexPattern", schema.TypeString, "Regular expression that passwords have to match"},
+	{"password_history", "passwordHistory", schema.TypeInt, "Number of previous passwords that can't be used again"},
+	{"password_age", "passwordAge", schema.TypeInt, "Number of days before a previous password can be used again"},
+	{"force_expired_password_change", "forceExpiredPasswordChange", schema.TypeInt, "Number of days after which passwords have to be changed"},
+	{"hash_algorithm", "hashAlgorithm", schema.TypeString, "Algorithm passwords are hashed with, like pbkdf2-sha512"},
+	{"hash_iterations", "hashIterations", schema.TypeInt, "Number of iterations of the hash algorithm"},
+	{"password_blacklist", "passwordBlacklist", schema.TypeString, "Name of the file of passwords that can't be used"},
+	{"max_auth_age", "maxAuthAge", schema.TypeInt, "Number of seconds since the last authentication after which users have to authenticate again to change their password"},
+}
+
 func resourceKeycloakRealm() *schema.Resource {
 
 	otpPolicySchema := map[string]*schema.Schema{
@@ -179,6 +211,12 @@ func resourceKeycloakRealm() *schema.Resource {
 				Optional: true,
 				Default:  false,
 			},
+			"organizations_enabled": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "When true, organizations can be managed within this realm. Requires Keycloak 25 or higher.",
+			},
 
 			// Login Config
 			"registration_allowed": {
@@ -272,9 +310,10 @@ func resourceKeycloakRealm() *schema.Resource {
 							Optional: true,
 						},
 						"auth": {
-							Type:     schema.TypeList,
-							Optional: true,
-							MaxItems: 1,
+							Type:          schema.TypeList,
+							Optional:      true,
+							MaxItems:      1,
+							ConflictsWith: []string{"smtp_server.0.token_auth"},
 							Elem: &schema.Resource{
 								Schema: map[string]*schema.Schema{
 									"username": {
@@ -292,6 +331,48 @@ func resourceKeycloakRealm() *schema.Resource {
 								},
 							},
 						},
+						"token_auth": {
+							Type:          schema.TypeList,
+							Optional:      true,
+							MaxItems:      1,
+							ConflictsWith: []string{"smtp_server.0.auth"},
+							Description:   "Authenticates with the XOAUTH2 mechanism, using a token obtained with the client credentials grant.",
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"username": {
+										Type:     schema.TypeString,
+										Required: true,
+									},
+									"url": {
+										Type:        schema.TypeString,
+										Required:    true,
+										Description: "The token endpoint of the authorization server.",
+									},
+									"client_id": {
+										Type:     schema.TypeString,
+										Required: true,
+									},
+									"client_secret": {
+										Type:      schema.TypeString,
+										Required:  true,
+										Sensitive: true,
+										DiffSuppressFunc: func(_, smtpServerClientSecret, _ string, _ *schema.ResourceData) bool {
+											return smtpServerClientSecret == "**********"
+										},
+									},
+									"scope": {
+										Type:     schema.TypeString,
+										Optional: true,
+									},
+								},
+							},
+						},
+						"verify_connection": {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     false,
+							Description: "When true, a test email is sent whenever the SMTP settings change, and the apply fails if it can't be sent.",
+						},
 					},
 				},
 			},
@@ -563,9 +644,23 @@ func resourceKeycloakRealm() *schema.Resource {
 
 			// authentication password policy
 			"password_policy": {
-				Type:        schema.TypeString,
-				Description: "String that represents the passwordPolicies that are in place. Each policy is separated with \" and \". Supported policies can be found in the server-info providers page. example: \"upperCase(1) and length(8) and forceExpiredPasswordChange(365) and notUsername(undefined)\"",
-				Optional:    true,
+				Type:             schema.TypeString,
+				Description:      "String that represents the passwordPolicies that are in place. Each policy is separated with \" and \". Supported policies can be found in the server-info providers page. example: \"upperCase(1) and length(8) and forceExpiredPasswordChange(365) and notUsername(undefined)\"",
+				Optional:         true,
+				Deprecated:       "use the password_policies block instead",
+				ConflictsWith:    []string{"password_policies"},
+				DiffSuppressFunc: suppressPasswordPolicyOrderDiff,
+			},
+			// the password policy as a block, which is only read when it's configured so the deprecated password_policy
+			// argument keeps working
+			"password_policies": {
+				Type:          schema.TypeList,
+				Optional:      true,
+				MaxItems:      1,
+				ConflictsWith: []string{"password_policy"},
+				Elem: &schema.Resource{
+					Schema: realmPasswordPoliciesSchema(),
+				},
 			},
 
 			// authentication flow bindings
@@ -660,6 +755,62 @@ func resourceKeycloakRealm() *schema.Resource {
 					Schema: webAuthnSchema,
 				},
 			},
+
+			// CIBA and PAR policies, which are kept in the attributes of the realm. They are only read when they're
+			// configured, so the attributes can still be managed through the attributes argument instead.
+			"ciba_policy": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"backchannel_token_delivery_mode": {
+							Type:         schema.TypeString,
+							Description:  "How the client gets the tokens, either poll or ping",
+							Optional:     true,
+							Default:      "poll",
+							ValidateFunc: validation.StringInSlice(keycloakRealmValidCibaBackchannelTokenDeliveryModes, false),
+						},
+						"expires_in": {
+							Type:         schema.TypeInt,
+							Description:  "The number of seconds an authentication request is valid",
+							Optional:     true,
+							Default:      120,
+							ValidateFunc: validation.IntBetween(10, 600),
+						},
+						"interval": {
+							Type:         schema.TypeInt,
+							Description:  "The minimum number of seconds the client has to wait between polling requests",
+							Optional:     true,
+							Default:      5,
+							ValidateFunc: validation.IntBetween(0, 600),
+						},
+						"auth_requested_user_hint": {
+							Type:         schema.TypeString,
+							Description:  "How the user is identified, keycloak only supports login_hint",
+							Optional:     true,
+							Default:      "login_hint",
+							ValidateFunc: validation.StringInSlice([]string{"login_hint"}, false),
+						},
+					},
+				},
+			},
+			"par_policy": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"request_uri_lifespan": {
+							Type:             schema.TypeString,
+							Description:      "How long the request uri of a pushed authorization request is valid",
+							Optional:         true,
+							Default:          "1m0s",
+							DiffSuppressFunc: suppressDurationStringDiff,
+						},
+					},
+				},
+			},
 		},
 	}
 }
@@ -679,6 +830,58 @@ func getRealmSMTPPasswordFromData(data *schema.ResourceData) (string, bool) {
 	return "", false
 }
 
+func getRealmSMTPTokenClientSecretFromData(data *schema.ResourceData) (string, bool) {
+	if v, ok := data.GetOk("smtp_server"); ok {
+		smtpSettings := v.([]interface{})[0].(map[string]interface{})
+		tokenAuthConfig := smtpSettings["token_auth"].([]interface{})
+
+		if len(tokenAuthConfig) == 1 {
+			return tokenAuthConfig[0].(map[string]interface{})["client_secret"].(string), true
+		}
+
+		return "", false
+	}
+
+	return "", false
+}
+
+// verifyRealmSmtpServer sends a test email when verify_connection is set and the smtp settings are new or have changed
+func verifyRealmSmtpServer(ctx context.Context, keycloakClient *keycloak.KeycloakClient, data *schema.ResourceData, realm *keycloak.Realm) diag.Diagnostics {
+	err := testRealmSmtpServer(ctx, keycloakClient, data, realm)
+	if errors.Is(err, keycloak.ErrLoggedInUserHasNoEmail) {
+		return diag.Diagnostics{
+			{
+				Severity:      diag.Error,
+				Summary:       "unable to send a test email: " + err.Error(),
+				Detail:        "Keycloak sends the test email of verify_connection to the email address of the user or service account the provider is logged in as. Give that user an email address, or set verify_connection to false.",
+				AttributePath: cty.GetAttrPath("smtp_server").IndexInt(0).GetAttr("verify_connection"),
+			},
+		}
+	}
+	if err != nil {
+		return diagnosticsFromError(err, data)
+	}
+
+	return nil
+}
+
+func testRealmSmtpServer(ctx context.Context, keycloakClient *keycloak.KeycloakClient, data *schema.ResourceData, realm *keycloak.Realm) error {
+	if !data.Get("smtp_server.0.verify_connection").(bool) {
+		return nil
+	}
+
+	// a realm that doesn't exist yet is tested before it is created, so a failed test doesn't leave a tainted realm behind
+	if data.Id() == "" {
+		return keycloakClient.TestSmtpConnection(ctx, &realm.SmtpServer)
+	}
+
+	if !data.HasChange("smtp_server") {
+		return nil
+	}
+
+	return keycloakClient.TestRealmSmtpConnection(ctx, realm.Realm, &realm.SmtpServer)
+}
+
 func setRealmFlowBindings(data *schema.ResourceData, realm *keycloak.Realm) {
 	if flow, ok := data.GetOk("browser_flow"); ok {
 		realm.BrowserFlow = stringPointer(flow.(string))
@@ -717,6 +920,192 @@ func setRealmFlowBindings(data *schema.ResourceData, realm *keycloak.Realm) {
 	}
 }
 
+// setRealmPoliciesFromData writes the configured CIBA and PAR policies into the attributes of the realm. An attribute
+// can't be set both by a policy and by the attributes argument, as they would overwrite each other.
+func setRealmPoliciesFromData(data *schema.ResourceData, realm *keycloak.Realm) error {
+	if v, ok := data.GetOk("ciba_policy"); ok {
+		if err := checkRealmAttributesNotConfigured(realm, "ciba_policy", keycloak.RealmCibaPolicyAttributes); err != nil {
+			return err
+		}
+
+		cibaPolicy := v.([]interface{})[0].(map[string]interface{})
+
+		realm.SetCibaPolicy(&keycloak.RealmCibaPolicy{
+			BackchannelTokenDeliveryMode: cibaPolicy["backchannel_token_delivery_mode"].(string),
+			ExpiresIn:                    cibaPolicy["expires_in"].(int),
+			Interval:                     cibaPolicy["interval"].(int),
+			AuthRequestedUserHint:        cibaPolicy["auth_requested_user_hint"].(string),
+		})
+	}
+
+	if v, ok := data.GetOk("par_policy"); ok {
+		if err := checkRealmAttributesNotConfigured(realm, "par_policy", keycloak.RealmParPolicyAttributes); err != nil {
+			return err
+		}
+
+		parPolicy := v.([]interface{})[0].(map[string]interface{})
+
+		requestUriLifespan, err := getSecondsFromDurationString(parPolicy["request_uri_lifespan"].(string))
+		if err != nil {
+			return err
+		}
+
+		realm.SetParPolicy(&keycloak.RealmParPolicy{
+			RequestUriLifespan: requestUriLifespan,
+		})
+	}
+
+	return nil
+}
+
+func checkRealmAttributesNotConfigured(realm *keycloak.Realm, block string, keys []string) error {
+	for _, key := range keys {
+		if _, ok := realm.Attributes[key]; ok {
+			return fmt.Errorf("validation error: the %s attribute is managed by %s, it can't also be set in attributes", key, block)
+		}
+	}
+
+	return nil
+}
+
+// requireRealmPolicyFeatures checks that the features of the configured policies are enabled, keycloak would store the
+// attributes of a disabled feature without complaint
+func requireRealmPolicyFeatures(ctx context.Context, keycloakClient *keycloak.KeycloakClient, data *schema.ResourceData) diag.Diagnostics {
+	if _, ok := data.GetOk("ciba_policy"); ok {
+		if diags := requireFeature(ctx, keycloakClient, keycloak.FeatureCiba); diags.HasError() {
+			return diags
+		}
+	}
+
+	if _, ok := data.GetOk("par_policy"); ok {
+		if diags := requireFeature(ctx, keycloakClient, keycloak.FeaturePar); diags.HasError() {
+			return diags
+		}
+	}
+
+	return nil
+}
+
+// setRealmFeatureDependentFields leaves out the fields of features that the server doesn't have, since keycloak only
+// applies the fields that are present. Enabling such a feature is rejected.
+func setRealmFeatureDependentFields(ctx context.Context, keycloakClient *keycloak.KeycloakClient, realm *keycloak.Realm) diag.Diagnostics {
+	if realm.OrganizationsEnabled != nil && *realm.OrganizationsEnabled {
+		return requireFeature(ctx, keycloakClient, keycloak.FeatureOrganization)
+	}
+
+	organizationsAvailable, err := keycloakClient.FeatureIsEnabled(ctx, keycloak.FeatureOrganization)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if !organizationsAvailable {
+		realm.OrganizationsEnabled = nil
+	}
+
+	return nil
+}
+
+func realmPasswordPoliciesSchema() map[string]*schema.Schema {
+	passwordPoliciesSchema := map[string]*schema.Schema{
+		"extra_policies": {
+			Type:        schema.TypeMap,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+			Optional:    true,
+			Description: "Policies of password policy providers that don't have an argument, like custom ones, by provider id. The value is the config of the policy, or an empty string for a policy without config.",
+		},
+	}
+
+	for _, passwordPolicy := range keycloakRealmPasswordPolicies {
+		passwordPolicySchema := &schema.Schema{
+			Type:        passwordPolicy.valueType,
+			Optional:    true,
+			Description: passwordPolicy.description,
+		}
+		if passwordPolicy.valueType == schema.TypeInt {
+			passwordPolicySchema.ValidateFunc = validation.IntAtLeast(1)
+		}
+
+		passwordPoliciesSchema[passwordPolicy.argument] = passwordPolicySchema
+	}
+
+	return passwordPoliciesSchema
+}
+
+// getRealmPasswordPolicyFromData turns the password_policies block into a password policy. Arguments that aren't set
+// leave out their policy.
+func getRealmPasswordPolicyFromData(passwordPolicies map[string]interface{}) (string, error) {
+	var policies []keycloak.RealmPasswordPolicy
+
+	for _, passwordPolicy := range keycloakRealmPasswordPolicies {
+		switch value := passwordPolicies[passwordPolicy.argument].(type) {
+		case int:
+			if value != 0 {
+				policies = append(policies, keycloak.RealmPasswordPolicy{Id: passwordPolicy.id, Value: strconv.Itoa(value)})
+			}
+		case bool:
+			if value {
+				policies = append(policies, keycloak.RealmPasswordPolicy{Id: passwordPolicy.id})
+			}
+		case string:
+			if value != "" {
+				policies = append(policies, keycloak.RealmPasswordPolicy{Id: passwordPolicy.id, Value: value})
+			}
+		}
+	}
+
+	if extraPolicies, ok := passwordPolicies["extra_policies"].(map[string]interface{}); ok {
+		for id, value := range extraPolicies {
+			// the policy would be read back into its argument, which would show up as a diff
+			for _, passwordPolicy := range keycloakRealmPasswordPolicies {
+				if passwordPolicy.id == id {
+					return "", fmt.Errorf("validation error: the %s password policy can't be set in extra_policies, use the %s argument instead", id, passwordPolicy.argument)
+				}
+			}
+
+			policies = append(policies, keycloak.RealmPasswordPolicy{Id: id, Value: value.(string)})
+		}
+	}
+
+	return keycloak.FormatRealmPasswordPolicy(policies), nil
+}
+
+// getRealmPasswordPoliciesData turns a password policy into the password_policies block, policies without an argument
+// end up in extra_policies
+func getRealmPasswordPoliciesData(passwordPolicy string) map[string]interface{} {
+	passwordPolicies := map[string]interface{}{}
+	extraPolicies := map[string]interface{}{}
+
+	for _, policy := range keycloak.ParseRealmPasswordPolicy(passwordPolicy) {
+		argument := ""
+		valueType := schema.TypeString
+		for _, passwordPolicy := range keycloakRealmPasswordPolicies {
+			if passwordPolicy.id == policy.Id {
+				argument = passwordPolicy.argument
+				valueType = passwordPolicy.valueType
+			}
+		}
+
+		switch {
+		case argument == "":
+			extraPolicies[policy.Id] = policy.Value
+		case valueType == schema.TypeInt:
+			passwordPolicies[argument], _ = strconv.Atoi(policy.Value)
+		case valueType == schema.TypeBool:
+			passwordPolicies[argument] = true
+		default:
+			passwordPolicies[argument] = policy.Value
+		}
+	}
+
+	passwordPolicies["extra_policies"] = extraPolicies
+
+	return passwordPolicies
+}
+
+// suppressPasswordPolicyOrderDiff ignores the order of the policies in a password policy
+func suppressPasswordPolicyOrderDiff(_, old, new string, _ *schema.ResourceData) bool {
+	return keycloak.FormatRealmPasswordPolicy(keycloak.ParseRealmPasswordPolicy(old)) == keycloak.FormatRealmPasswordPolicy(keycloak.ParseRealmPasswordPolicy(new))
+}
+
 func getRealmFromData(data *schema.ResourceData) (*keycloak.Realm, error) {
 	internationalizationEnabled := false
 	supportLocales := make([]string, 0)
@@ -746,6 +1135,8 @@ func getRealmFromData(data *schema.ResourceData) (*keycloak.Realm, error) {
 		DisplayNameHtml:   data.Get("display_name_html").(string),
 		UserManagedAccess: data.Get("user_managed_access").(bool),
 
+		OrganizationsEnabled: boolPointer(data.Get("organizations_enabled").(bool)),
+
 		// Login Config
 		RegistrationAllowed:         data.Get("registration_allowed").(bool),
 		RegistrationEmailAsUsername: data.Get("registration_email_as_username").(bool),
@@ -780,12 +1171,23 @@ func getRealmFromData(data *schema.ResourceData) (*keycloak.Realm, error) {
 		}
 
 		authConfig := smtpSettings["auth"].([]interface{})
+		tokenAuthConfig := smtpSettings["token_auth"].([]interface{})
 		if len(authConfig) == 1 {
 			auth := authConfig[0].(map[string]interface{})
 
 			smtpServer.Auth = true
 			smtpServer.User = auth["username"].(string)
 			smtpServer.Password = auth["password"].(string)
+		} else if len(tokenAuthConfig) == 1 {
+			tokenAuth := tokenAuthConfig[0].(map[string]interface{})
+
+			smtpServer.Auth = true
+			smtpServer.AuthType = "token"
+			smtpServer.User = tokenAuth["username"].(string)
+			smtpServer.AuthTokenUrl = tokenAuth["url"].(string)
+			smtpServer.AuthTokenClientId = tokenAuth["client_id"].(string)
+			smtpServer.AuthTokenClientSecret = tokenAuth["client_secret"].(string)
+			smtpServer.AuthTokenScope = tokenAuth["scope"].(string)
 		} else {
 			smtpServer.Auth = false
 		}
@@ -1002,7 +1404,14 @@ func getRealmFromData(data *schema.ResourceData) (*keycloak.Realm, error) {
 		setDefaultSecuritySettingsBruteForceDetection(realm)
 	}
 
-	if passwordPolicy, ok := data.GetOk("password_policy"); ok {
+	if v, ok := data.GetOk("password_policies"); ok {
+		passwordPolicies, _ := v.([]interface{})[0].(map[string]interface{})
+		passwordPolicy, err := getRealmPasswordPolicyFromData(passwordPolicies)
+		if err != nil {
+			return nil, err
+		}
+		realm.PasswordPolicy = passwordPolicy
+	} else if passwordPolicy, ok := data.GetOk("password_policy"); ok {
 		realm.PasswordPolicy = passwordPolicy.(string)
 	}
 
@@ -1016,6 +1425,11 @@ func getRealmFromData(data *schema.ResourceData) (*keycloak.Realm, error) {
 	}
 	realm.Attributes = attributes
 
+	err := setRealmPoliciesFromData(data, realm)
+	if err != nil {
+		return nil, err
+	}
+
 	defaultDefaultClientScopes := make([]string, 0)
 	if v, ok := data.GetOk("default_default_client_scopes"); ok {
 		for _, defaultDefaultClientScope := range v.(*schema.Set).List() {
@@ -1179,6 +1593,7 @@ func setRealmData(data *schema.ResourceData, realm *keycloak.Realm) {
 	data.Set("display_name", realm.DisplayName)
 	data.Set("display_name_html", realm.DisplayNameHtml)
 	data.Set("user_managed_access", realm.UserManagedAccess)
+	data.Set("organizations_enabled", realm.OrganizationsEnabled != nil && *realm.OrganizationsEnabled)
 
 	// Login Config
 	data.Set("registration_allowed", realm.RegistrationAllowed)
@@ -1208,7 +1623,17 @@ func setRealmData(data *schema.ResourceData, realm *keycloak.Realm) {
 		smtpSettings["envelope_from"] = realm.SmtpServer.EnvelopeFrom
 		smtpSettings["ssl"] = realm.SmtpServer.Ssl
 
-		if realm.SmtpServer.Auth {
+		if realm.SmtpServer.Auth && realm.SmtpServer.AuthType == "token" {
+			tokenAuth := make(map[string]interface{})
+
+			tokenAuth["username"] = realm.SmtpServer.User
+			tokenAuth["url"] = realm.SmtpServer.AuthTokenUrl
+			tokenAuth["client_id"] = realm.SmtpServer.AuthTokenClientId
+			tokenAuth["client_secret"] = realm.SmtpServer.AuthTokenClientSecret
+			tokenAuth["scope"] = realm.SmtpServer.AuthTokenScope
+
+			smtpSettings["token_auth"] = []interface{}{tokenAuth}
+		} else if realm.SmtpServer.Auth {
 			auth := make(map[string]interface{})
 
 			auth["username"] = realm.SmtpServer.User
@@ -1217,6 +1642,11 @@ func setRealmData(data *schema.ResourceData, realm *keycloak.Realm) {
 			smtpSettings["auth"] = []interface{}{auth}
 		}
 
+		// verify_connection only exists in the configuration of the resource
+		if verifyConnection, ok := data.GetOk("smtp_server.0.verify_connection"); ok {
+			smtpSettings["verify_connection"] = verifyConnection
+		}
+
 		data.Set("smtp_server", []interface{}{smtpSettings})
 	}
 
@@ -1279,7 +1709,11 @@ func setRealmData(data *schema.ResourceData, realm *keycloak.Realm) {
 		}
 	}
 
-	data.Set("password_policy", realm.PasswordPolicy)
+	if _, ok := data.GetOk("password_policies"); ok {
+		data.Set("password_policies", []interface{}{getRealmPasswordPoliciesData(realm.PasswordPolicy)})
+	} else {
+		data.Set("password_policy", realm.PasswordPolicy)
+	}
 
 	//Flow Bindings
 	data.Set("browser_flow", realm.BrowserFlow)
@@ -1336,6 +1770,27 @@ func setRealmData(data *schema.ResourceData, realm *keycloak.Realm) {
 	}
 	data.Set("attributes", attributes)
 
+	if _, ok := data.GetOk("ciba_policy"); ok {
+		cibaPolicy := realm.GetCibaPolicy()
+		data.Set("ciba_policy", []interface{}{
+			map[string]interface{}{
+				"backchannel_token_delivery_mode": cibaPolicy.BackchannelTokenDeliveryMode,
+				"expires_in":                      cibaPolicy.ExpiresIn,
+				"interval":                        cibaPolicy.Interval,
+				"auth_requested_user_hint":        cibaPolicy.AuthRequestedUserHint,
+			},
+		})
+	}
+
+	if _, ok := data.GetOk("par_policy"); ok {
+		parPolicy := realm.GetParPolicy()
+		data.Set("par_policy", []interface{}{
+			map[string]interface{}{
+				"request_uri_lifespan": getDurationStringFromSeconds(parPolicy.RequestUriLifespan),
+			},
+		})
+	}
+
 	// default and optional client scope mappings
 	data.Set("default_default_client_scopes", realm.DefaultDefaultClientScopes)
 	data.Set("default_optional_client_scopes", realm.DefaultOptionalClientScopes)
@@ -1369,19 +1824,31 @@ func getHeaderSettings(realm *keycloak.Realm) map[string]interface{} {
 func resourceKeycloakRealmCreate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	keycloakClient := meta.(*keycloak.KeycloakClient)
 
+	if diags := requireRealmPolicyFeatures(ctx, keycloakClient, data); diags.HasError() {
+		return diags
+	}
+
 	realm, err := getRealmFromData(data)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
+	}
+
+	if diags := setRealmFeatureDependentFields(ctx, keycloakClient, realm); diags.HasError() {
+		return diags
 	}
 
 	err = keycloakClient.ValidateRealm(ctx, realm)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
+	}
+
+	if diags := verifyRealmSmtpServer(ctx, keycloakClient, data, realm); diags.HasError() {
+		return diags
 	}
 
 	err = keycloakClient.NewRealm(ctx, realm)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	setRealmData(data, realm)
@@ -1401,6 +1868,9 @@ func resourceKeycloakRealmRead(ctx context.Context, data *schema.ResourceData, m
 	if smtpPassword, ok := getRealmSMTPPasswordFromData(data); ok {
 		realm.SmtpServer.Password = smtpPassword
 	}
+	if smtpClientSecret, ok := getRealmSMTPTokenClientSecretFromData(data); ok {
+		realm.SmtpServer.AuthTokenClientSecret = smtpClientSecret
+	}
 
 	setRealmData(data, realm)
 
@@ -1410,19 +1880,32 @@ func resourceKeycloakRealmRead(ctx context.Context, data *schema.ResourceData, m
 func resourceKeycloakRealmUpdate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	keycloakClient := meta.(*keycloak.KeycloakClient)
 
+	if diags := requireRealmPolicyFeatures(ctx, keycloakClient, data); diags.HasError() {
+		return diags
+	}
+
 	realm, err := getRealmFromData(data)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
+	}
+
+	if diags := setRealmFeatureDependentFields(ctx, keycloakClient, realm); diags.HasError() {
+		return diags
 	}
 
 	err = keycloakClient.ValidateRealm(ctx, realm)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
+	}
+
+	// the new settings are tested before they are saved, so the realm keeps working settings when they fail
+	if diags := verifyRealmSmtpServer(ctx, keycloakClient, data, realm); diags.HasError() {
+		return diags
 	}
 
 	err = keycloakClient.UpdateRealm(ctx, realm)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	setRealmData(data, realm)
@@ -0,0 +1,219 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/keycloak/terraform-provider-keycloak/keycloak"
+)
+
+func resourceKeycloakRealmClientPolicies() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceKeycloakRealmClientPoliciesCreate,
+		ReadContext:   resourceKeycloakRealmClientPoliciesRead,
+		UpdateContext: resourceKeycloakRealmClientPoliciesUpdate,
+		DeleteContext: resourceKeycloakRealmClientPoliciesDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceKeycloakRealmClientPoliciesImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"realm_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"policy": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"description": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"enabled": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  true,
+						},
+						"condition": {
+							Type:     schema.TypeList,
+							Optional: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"name": {
+										Type:        schema.TypeString,
+										Required:    true,
+										Description: "The provider id of the condition, such as client-roles or client-updater-source-host.",
+									},
+									"configuration": {
+										Type:             schema.TypeString,
+										Optional:         true,
+										Default:          "{}",
+										ValidateFunc:     validation.StringIsJSON,
+										DiffSuppressFunc: suppressJsonDiff,
+										Description:      "JSON encoded configuration of the condition.",
+									},
+								},
+							},
+						},
+						"profiles": {
+							Type:        schema.TypeList,
+							Optional:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: "Names of the client profiles that are applied when all conditions of this policy are met.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func getRealmClientPoliciesFromData(data *schema.ResourceData) (*keycloak.RealmClientPolicies, error) {
+	policies := make([]*keycloak.RealmClientPolicy, 0)
+
+	for _, p := range data.Get("policy").([]interface{}) {
+		policyData := p.(map[string]interface{})
+
+		conditions := make([]*keycloak.RealmClientPolicyCondition, 0)
+		for _, c := range policyData["condition"].([]interface{}) {
+			conditionData := c.(map[string]interface{})
+
+			configuration, err := getJsonObjectFromString(conditionData["configuration"].(string))
+			if err != nil {
+				return nil, err
+			}
+
+			conditions = append(conditions, &keycloak.RealmClientPolicyCondition{
+				Condition:     conditionData["name"].(string),
+				Configuration: configuration,
+			})
+		}
+
+		profiles := make([]string, 0)
+		for _, profile := range policyData["profiles"].([]interface{}) {
+			profiles = append(profiles, profile.(string))
+		}
+
+		policies = append(policies, &keycloak.RealmClientPolicy{
+			Name:        policyData["name"].(string),
+			Description: policyData["description"].(string),
+			Enabled:     policyData["enabled"].(bool),
+			Conditions:  conditions,
+			Profiles:    profiles,
+		})
+	}
+
+	return &keycloak.RealmClientPolicies{
+		Policies: policies,
+	}, nil
+}
+
+func setRealmClientPoliciesData(data *schema.ResourceData, realmClientPolicies *keycloak.RealmClientPolicies) error {
+	policies := make([]interface{}, 0)
+
+	for _, policy := range realmClientPolicies.Policies {
+		conditions := make([]interface{}, 0)
+		for _, condition := range policy.Conditions {
+			configuration, err := getJsonStringFromObject(condition.Configuration)
+			if err != nil {
+				return err
+			}
+
+			conditions = append(conditions, map[string]interface{}{
+				"name":          condition.Condition,
+				"configuration": configuration,
+			})
+		}
+
+		policies = append(policies, map[string]interface{}{
+			"name":        policy.Name,
+			"description": policy.Description,
+			"enabled":     policy.Enabled,
+			"condition":   conditions,
+			"profiles":    policy.Profiles,
+		})
+	}
+
+	return data.Set("policy", policies)
+}
+
+func resourceKeycloakRealmClientPoliciesCreate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	if diags := requireFeature(ctx, keycloakClient, keycloak.FeatureClientPolicies); diags.HasError() {
+		return diags
+	}
+
+	realmId := data.Get("realm_id").(string)
+	data.SetId(realmId)
+
+	diagnostics := resourceKeycloakRealmClientPoliciesUpdate(ctx, data, meta)
+	if diagnostics.HasError() {
+		return diagnostics
+	}
+
+	return resourceKeycloakRealmClientPoliciesRead(ctx, data, meta)
+}
+
+func resourceKeycloakRealmClientPoliciesRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	realmId := data.Get("realm_id").(string)
+
+	realmClientPolicies, err := keycloakClient.GetRealmClientPolicies(ctx, realmId)
+	if err != nil {
+		return handleNotFoundError(ctx, err, data)
+	}
+
+	err = setRealmClientPoliciesData(data, realmClientPolicies)
+	if err != nil {
+		return diagnosticsFromError(err, data)
+	}
+
+	return nil
+}
+
+func resourceKeycloakRealmClientPoliciesUpdate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	realmId := data.Get("realm_id").(string)
+
+	realmClientPolicies, err := getRealmClientPoliciesFromData(data)
+	if err != nil {
+		return diagnosticsFromError(err, data)
+	}
+
+	err = keycloakClient.UpdateRealmClientPolicies(ctx, realmId, realmClientPolicies)
+	if err != nil {
+		return diagnosticsFromError(err, data)
+	}
+
+	return nil
+}
+
+func resourceKeycloakRealmClientPoliciesDelete(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	realmId := data.Get("realm_id").(string)
+
+	// Client policies cannot be deleted, so instead we remove every realm level policy.
+	realmClientPolicies := &keycloak.RealmClientPolicies{
+		Policies: []*keycloak.RealmClientPolicy{},
+	}
+
+	return diag.FromErr(keycloakClient.UpdateRealmClientPolicies(ctx, realmId, realmClientPolicies))
+}
+
+func resourceKeycloakRealmClientPoliciesImport(_ context.Context, d *schema.ResourceData, _ interface{}) ([]*schema.ResourceData, error) {
+	d.Set("realm_id", d.Id())
+
+	return []*schema.ResourceData{d}, nil
+}
@@ -0,0 +1,171 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/keycloak/terraform-provider-keycloak/keycloak"
+)
+
+func TestAccKeycloakRealmClientPolicies_basic(t *testing.T) {
+	skipIfVersionIsLessThanOrEqualTo(testCtx, t, keycloakClient, keycloak.Version_14)
+
+	realmName := acctest.RandomWithPrefix("tf-acc")
+	policyName := acctest.RandomWithPrefix("tf-acc")
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		PreCheck:          func() { testAccPreCheck(t) },
+		Steps: []resource.TestStep{
+			{
+				Config: testKeycloakRealmClientPolicies_basic(realmName, policyName, true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckKeycloakRealmClientPoliciesExists("keycloak_realm_client_policies.policies", policyName),
+					resource.TestCheckResourceAttr("keycloak_realm_client_policies.policies", "policy.0.enabled", "true"),
+					resource.TestCheckResourceAttr("keycloak_realm_client_policies.policies", "policy.0.profiles.#", "1"),
+				),
+			},
+			{
+				ResourceName:      "keycloak_realm_client_policies.policies",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateId:     realmName,
+			},
+		},
+	})
+}
+
+func TestAccKeycloakRealmClientPolicies_update(t *testing.T) {
+	skipIfVersionIsLessThanOrEqualTo(testCtx, t, keycloakClient, keycloak.Version_14)
+
+	realmName := acctest.RandomWithPrefix("tf-acc")
+	policyName := acctest.RandomWithPrefix("tf-acc")
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		PreCheck:          func() { testAccPreCheck(t) },
+		Steps: []resource.TestStep{
+			{
+				Config: testKeycloakRealmClientPolicies_basic(realmName, policyName, true),
+				Check:  testAccCheckKeycloakRealmClientPoliciesExists("keycloak_realm_client_policies.policies", policyName),
+			},
+			{
+				Config: testKeycloakRealmClientPolicies_basic(realmName, policyName, false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckKeycloakRealmClientPoliciesExists("keycloak_realm_client_policies.policies", policyName),
+					resource.TestCheckResourceAttr("keycloak_realm_client_policies.policies", "policy.0.enabled", "false"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccKeycloakRealmClientPolicies_destroy(t *testing.T) {
+	skipIfVersionIsLessThanOrEqualTo(testCtx, t, keycloakClient, keycloak.Version_14)
+
+	realmName := acctest.RandomWithPrefix("tf-acc")
+	policyName := acctest.RandomWithPrefix("tf-acc")
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		PreCheck:          func() { testAccPreCheck(t) },
+		Steps: []resource.TestStep{
+			{
+				Config: testKeycloakRealmClientPolicies_basic(realmName, policyName, true),
+				Check:  testAccCheckKeycloakRealmClientPoliciesExists("keycloak_realm_client_policies.policies", policyName),
+			},
+			{
+				Config: testKeycloakRealmClientPolicies_realmOnly(realmName),
+				Check: func(state *terraform.State) error {
+					realmClientPolicies, err := keycloakClient.GetRealmClientPolicies(testCtx, realmName)
+					if err != nil {
+						return err
+					}
+
+					if len(realmClientPolicies.Policies) != 0 {
+						return fmt.Errorf("expected realm %s to have no client policies after destroy, but found %d", realmName, len(realmClientPolicies.Policies))
+					}
+
+					return nil
+				},
+			},
+		},
+	})
+}
+
+func testAccCheckKeycloakRealmClientPoliciesExists(resourceName, policyName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("resource not found: %s", resourceName)
+		}
+
+		realmId := rs.Primary.Attributes["realm_id"]
+
+		realmClientPolicies, err := keycloakClient.GetRealmClientPolicies(testCtx, realmId)
+		if err != nil {
+			return fmt.Errorf("error getting client policies for realm %s: %s", realmId, err)
+		}
+
+		for _, policy := range realmClientPolicies.Policies {
+			if policy.Name == policyName {
+				return nil
+			}
+		}
+
+		return fmt.Errorf("client policy %s does not exist in realm %s", policyName, realmId)
+	}
+}
+
+func testKeycloakRealmClientPolicies_basic(realm, policyName string, enabled bool) string {
+	return fmt.Sprintf(`
+resource "keycloak_realm" "realm" {
+	realm = "%s"
+}
+
+resource "keycloak_realm_client_profiles" "profiles" {
+	realm_id = keycloak_realm.realm.id
+
+	profile {
+		name = "enforce-pkce"
+
+		executor {
+			name          = "pkce-enforcer"
+			configuration = jsonencode({
+				"auto-configure" = "true"
+			})
+		}
+	}
+}
+
+resource "keycloak_realm_client_policies" "policies" {
+	realm_id = keycloak_realm.realm.id
+
+	policy {
+		name        = "%s"
+		description = "terraform acceptance test policy"
+		enabled     = %t
+
+		condition {
+			name          = "client-access-type"
+			configuration = jsonencode({
+				"type" = ["public"]
+			})
+		}
+
+		profiles = keycloak_realm_client_profiles.profiles.profile[*].name
+	}
+}
+	`, realm, policyName, enabled)
+}
+
+func testKeycloakRealmClientPolicies_realmOnly(realm string) string {
+	return fmt.Sprintf(`
+resource "keycloak_realm" "realm" {
+	realm = "%s"
+}
+	`, realm)
+}
@@ -0,0 +1,199 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/keycloak/terraform-provider-keycloak/keycloak"
+)
+
+func resourceKeycloakRealmClientProfiles() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceKeycloakRealmClientProfilesCreate,
+		ReadContext:   resourceKeycloakRealmClientProfilesRead,
+		UpdateContext: resourceKeycloakRealmClientProfilesUpdate,
+		DeleteContext: resourceKeycloakRealmClientProfilesDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceKeycloakRealmClientProfilesImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"realm_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"profile": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"description": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"executor": {
+							Type:     schema.TypeList,
+							Optional: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"name": {
+										Type:        schema.TypeString,
+										Required:    true,
+										Description: "The provider id of the executor, such as pkce-enforcer or secure-client-authenticator.",
+									},
+									"configuration": {
+										Type:             schema.TypeString,
+										Optional:         true,
+										Default:          "{}",
+										ValidateFunc:     validation.StringIsJSON,
+										DiffSuppressFunc: suppressJsonDiff,
+										Description:      "JSON encoded configuration of the executor.",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func getRealmClientProfilesFromData(data *schema.ResourceData) (*keycloak.RealmClientProfiles, error) {
+	profiles := make([]*keycloak.RealmClientProfile, 0)
+
+	for _, p := range data.Get("profile").([]interface{}) {
+		profileData := p.(map[string]interface{})
+
+		executors := make([]*keycloak.RealmClientProfileExecutor, 0)
+		for _, e := range profileData["executor"].([]interface{}) {
+			executorData := e.(map[string]interface{})
+
+			configuration, err := getJsonObjectFromString(executorData["configuration"].(string))
+			if err != nil {
+				return nil, err
+			}
+
+			executors = append(executors, &keycloak.RealmClientProfileExecutor{
+				Executor:      executorData["name"].(string),
+				Configuration: configuration,
+			})
+		}
+
+		profiles = append(profiles, &keycloak.RealmClientProfile{
+			Name:        profileData["name"].(string),
+			Description: profileData["description"].(string),
+			Executors:   executors,
+		})
+	}
+
+	return &keycloak.RealmClientProfiles{
+		Profiles: profiles,
+	}, nil
+}
+
+func setRealmClientProfilesData(data *schema.ResourceData, realmClientProfiles *keycloak.RealmClientProfiles) error {
+	profiles := make([]interface{}, 0)
+
+	for _, profile := range realmClientProfiles.Profiles {
+		executors := make([]interface{}, 0)
+		for _, executor := range profile.Executors {
+			configuration, err := getJsonStringFromObject(executor.Configuration)
+			if err != nil {
+				return err
+			}
+
+			executors = append(executors, map[string]interface{}{
+				"name":          executor.Executor,
+				"configuration": configuration,
+			})
+		}
+
+		profiles = append(profiles, map[string]interface{}{
+			"name":        profile.Name,
+			"description": profile.Description,
+			"executor":    executors,
+		})
+	}
+
+	return data.Set("profile", profiles)
+}
+
+func resourceKeycloakRealmClientProfilesCreate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	if diags := requireFeature(ctx, keycloakClient, keycloak.FeatureClientPolicies); diags.HasError() {
+		return diags
+	}
+
+	realmId := data.Get("realm_id").(string)
+	data.SetId(realmId)
+
+	diagnostics := resourceKeycloakRealmClientProfilesUpdate(ctx, data, meta)
+	if diagnostics.HasError() {
+		return diagnostics
+	}
+
+	return resourceKeycloakRealmClientProfilesRead(ctx, data, meta)
+}
+
+func resourceKeycloakRealmClientProfilesRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	realmId := data.Get("realm_id").(string)
+
+	realmClientProfiles, err := keycloakClient.GetRealmClientProfiles(ctx, realmId)
+	if err != nil {
+		return handleNotFoundError(ctx, err, data)
+	}
+
+	err = setRealmClientProfilesData(data, realmClientProfiles)
+	if err != nil {
+		return diagnosticsFromError(err, data)
+	}
+
+	return nil
+}
+
+func resourceKeycloakRealmClientProfilesUpdate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	realmId := data.Get("realm_id").(string)
+
+	realmClientProfiles, err := getRealmClientProfilesFromData(data)
+	if err != nil {
+		return diagnosticsFromError(err, data)
+	}
+
+	err = keycloakClient.UpdateRealmClientProfiles(ctx, realmId, realmClientProfiles)
+	if err != nil {
+		return diagnosticsFromError(err, data)
+	}
+
+	return nil
+}
+
+func resourceKeycloakRealmClientProfilesDelete(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	realmId := data.Get("realm_id").(string)
+
+	// Client profiles cannot be deleted, so instead we remove every realm level profile.
+	realmClientProfiles := &keycloak.RealmClientProfiles{
+		Profiles: []*keycloak.RealmClientProfile{},
+	}
+
+	return diag.FromErr(keycloakClient.UpdateRealmClientProfiles(ctx, realmId, realmClientProfiles))
+}
+
+func resourceKeycloakRealmClientProfilesImport(_ context.Context, d *schema.ResourceData, _ interface{}) ([]*schema.ResourceData, error) {
+	d.Set("realm_id", d.Id())
+
+	return []*schema.ResourceData{d}, nil
+}
@@ -0,0 +1,195 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/keycloak/terraform-provider-keycloak/keycloak"
+)
+
+func TestAccKeycloakRealmClientProfiles_basic(t *testing.T) {
+	skipIfVersionIsLessThanOrEqualTo(testCtx, t, keycloakClient, keycloak.Version_14)
+
+	profileName := acctest.RandomWithPrefix("tf-acc")
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		PreCheck:          func() { testAccPreCheck(t) },
+		CheckDestroy:      testAccCheckKeycloakRealmClientProfilesDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config: testKeycloakRealmClientProfiles_basic(profileName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckKeycloakRealmClientProfilesExists("keycloak_realm_client_profiles.profiles", profileName),
+					resource.TestCheckResourceAttr("keycloak_realm_client_profiles.profiles", "profile.0.executor.#", "2"),
+				),
+			},
+			{
+				ResourceName:      "keycloak_realm_client_profiles.profiles",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateId:     testAccRealm.Realm,
+			},
+		},
+	})
+}
+
+func TestAccKeycloakRealmClientProfiles_updateExecutorConfiguration(t *testing.T) {
+	skipIfVersionIsLessThanOrEqualTo(testCtx, t, keycloakClient, keycloak.Version_14)
+
+	profileName := acctest.RandomWithPrefix("tf-acc")
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		PreCheck:          func() { testAccPreCheck(t) },
+		CheckDestroy:      testAccCheckKeycloakRealmClientProfilesDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config: testKeycloakRealmClientProfiles_basic(profileName),
+				Check:  testAccCheckKeycloakRealmClientProfilesExists("keycloak_realm_client_profiles.profiles", profileName),
+			},
+			{
+				Config: testKeycloakRealmClientProfiles_pkceOnly(profileName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckKeycloakRealmClientProfilesExists("keycloak_realm_client_profiles.profiles", profileName),
+					resource.TestCheckResourceAttr("keycloak_realm_client_profiles.profiles", "profile.0.executor.#", "1"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccKeycloakRealmClientProfiles_createAfterManualDestroy(t *testing.T) {
+	skipIfVersionIsLessThanOrEqualTo(testCtx, t, keycloakClient, keycloak.Version_14)
+
+	profileName := acctest.RandomWithPrefix("tf-acc")
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		PreCheck:          func() { testAccPreCheck(t) },
+		CheckDestroy:      testAccCheckKeycloakRealmClientProfilesDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config: testKeycloakRealmClientProfiles_basic(profileName),
+				Check:  testAccCheckKeycloakRealmClientProfilesExists("keycloak_realm_client_profiles.profiles", profileName),
+			},
+			{
+				PreConfig: func() {
+					err := keycloakClient.UpdateRealmClientProfiles(testCtx, testAccRealm.Realm, &keycloak.RealmClientProfiles{
+						Profiles: []*keycloak.RealmClientProfile{},
+					})
+					if err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config: testKeycloakRealmClientProfiles_basic(profileName),
+				Check:  testAccCheckKeycloakRealmClientProfilesExists("keycloak_realm_client_profiles.profiles", profileName),
+			},
+		},
+	})
+}
+
+func testAccCheckKeycloakRealmClientProfilesExists(resourceName, profileName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("resource not found: %s", resourceName)
+		}
+
+		realmId := rs.Primary.Attributes["realm_id"]
+
+		realmClientProfiles, err := keycloakClient.GetRealmClientProfiles(testCtx, realmId)
+		if err != nil {
+			return fmt.Errorf("error getting client profiles for realm %s: %s", realmId, err)
+		}
+
+		for _, profile := range realmClientProfiles.Profiles {
+			if profile.Name == profileName {
+				return nil
+			}
+		}
+
+		return fmt.Errorf("client profile %s does not exist in realm %s", profileName, realmId)
+	}
+}
+
+func testAccCheckKeycloakRealmClientProfilesDestroy() resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		for _, rs := range s.RootModule().Resources {
+			if rs.Type != "keycloak_realm_client_profiles" {
+				continue
+			}
+
+			realmId := rs.Primary.Attributes["realm_id"]
+
+			realmClientProfiles, err := keycloakClient.GetRealmClientProfiles(testCtx, realmId)
+			if err != nil {
+				return err
+			}
+
+			if len(realmClientProfiles.Profiles) != 0 {
+				return fmt.Errorf("expected realm %s to have no client profiles, but found %d", realmId, len(realmClientProfiles.Profiles))
+			}
+		}
+
+		return nil
+	}
+}
+
+func testKeycloakRealmClientProfiles_basic(profileName string) string {
+	return fmt.Sprintf(`
+data "keycloak_realm" "realm" {
+	realm = "%s"
+}
+
+resource "keycloak_realm_client_profiles" "profiles" {
+	realm_id = data.keycloak_realm.realm.id
+
+	profile {
+		name        = "%s"
+		description = "terraform acceptance test profile"
+
+		executor {
+			name          = "pkce-enforcer"
+			configuration = jsonencode({
+				"auto-configure" = "false"
+			})
+		}
+
+		executor {
+			name          = "secure-client-authenticator"
+			configuration = jsonencode({
+				"allowed-client-authenticators" = ["client-jwt", "client-x509"]
+				"default-client-authenticator"  = "client-jwt"
+			})
+		}
+	}
+}
+	`, testAccRealm.Realm, profileName)
+}
+
+func testKeycloakRealmClientProfiles_pkceOnly(profileName string) string {
+	return fmt.Sprintf(`
+data "keycloak_realm" "realm" {
+	realm = "%s"
+}
+
+resource "keycloak_realm_client_profiles" "profiles" {
+	realm_id = data.keycloak_realm.realm.id
+
+	profile {
+		name = "%s"
+
+		executor {
+			name          = "pkce-enforcer"
+			configuration = jsonencode({
+				"auto-configure" = "true"
+			})
+		}
+	}
+}
+	`, testAccRealm.Realm, profileName)
+}
@@ -0,0 +1,113 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/keycloak/terraform-provider-keycloak/keycloak"
+)
+
+func resourceKeycloakRealmDefaultClientScopes() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceKeycloakRealmDefaultClientScopesReconcile,
+		ReadContext:   resourceKeycloakRealmDefaultClientScopesRead,
+		DeleteContext: resourceKeycloakRealmDefaultClientScopesDelete,
+		UpdateContext: resourceKeycloakRealmDefaultClientScopesReconcile,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceKeycloakRealmClientScopesImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"realm_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"default_scopes": {
+				Type:     schema.TypeSet,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Required: true,
+				Set:      schema.HashString,
+			},
+		},
+	}
+}
+
+func resourceKeycloakRealmDefaultClientScopesRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	realmId := data.Get("realm_id").(string)
+
+	clientScopes, err := keycloakClient.GetRealmDefaultClientScopes(ctx, realmId)
+	if err != nil {
+		return handleNotFoundError(ctx, err, data)
+	}
+
+	var defaultScopes []string
+	for _, clientScope := range clientScopes {
+		defaultScopes = append(defaultScopes, clientScope.Name)
+	}
+
+	data.Set("default_scopes", defaultScopes)
+	data.SetId(realmId)
+
+	return nil
+}
+
+func resourceKeycloakRealmDefaultClientScopesReconcile(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	realmId := data.Get("realm_id").(string)
+	tfRealmDefaultClientScopes := data.Get("default_scopes").(*schema.Set)
+
+	keycloakRealmDefaultClientScopes, err := keycloakClient.GetRealmDefaultClientScopes(ctx, realmId)
+	if err != nil {
+		return diagnosticsFromError(err, data)
+	}
+
+	var realmDefaultClientScopesToDetach []string
+	for _, keycloakRealmDefaultClientScope := range keycloakRealmDefaultClientScopes {
+		// scopes that are attached in keycloak and tf state don't need to be updated
+		if tfRealmDefaultClientScopes.Contains(keycloakRealmDefaultClientScope.Name) {
+			tfRealmDefaultClientScopes.Remove(keycloakRealmDefaultClientScope.Name)
+		} else {
+			realmDefaultClientScopesToDetach = append(realmDefaultClientScopesToDetach, keycloakRealmDefaultClientScope.Name)
+		}
+	}
+
+	// detach scopes that aren't in tf state
+	err = keycloakClient.DetachRealmDefaultClientScopes(ctx, realmId, realmDefaultClientScopesToDetach)
+	if err != nil {
+		return diagnosticsFromError(err, data)
+	}
+
+	// attach scopes that exist in tf state but not in keycloak
+	err = keycloakClient.AttachRealmDefaultClientScopes(ctx, realmId, interfaceSliceToStringSlice(tfRealmDefaultClientScopes.List()))
+	if err != nil {
+		return diagnosticsFromError(err, data)
+	}
+
+	data.SetId(realmId)
+
+	return resourceKeycloakRealmDefaultClientScopesRead(ctx, data, meta)
+}
+
+func resourceKeycloakRealmDefaultClientScopesDelete(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	realmId := data.Get("realm_id").(string)
+	defaultScopes := data.Get("default_scopes").(*schema.Set)
+
+	err := keycloakClient.DetachRealmDefaultClientScopes(ctx, realmId, interfaceSliceToStringSlice(defaultScopes.List()))
+	if err != nil && !keycloak.ErrorIs404(err) {
+		return diagnosticsFromError(err, data)
+	}
+
+	return nil
+}
+
+func resourceKeycloakRealmClientScopesImport(_ context.Context, d *schema.ResourceData, _ interface{}) ([]*schema.ResourceData, error) {
+	d.Set("realm_id", d.Id())
+
+	return []*schema.ResourceData{d}, nil
+}
@@ -0,0 +1,231 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/keycloak/terraform-provider-keycloak/keycloak"
+)
+
+func TestAccKeycloakRealmDefaultClientScopes_basic(t *testing.T) {
+	t.Parallel()
+	realmName := acctest.RandomWithPrefix("tf-acc")
+	clientScope := acctest.RandomWithPrefix("tf-acc")
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		PreCheck:          func() { testAccPreCheck(t) },
+		Steps: []resource.TestStep{
+			{
+				Config: testKeycloakRealmDefaultClientScopes_listOfScopes(realmName, clientScope, []string{"profile", "email", clientScope, clientScope + "-saml"}),
+				Check:  testAccCheckKeycloakRealmHasDefaultClientScopes(realmName, []string{"profile", "email", clientScope, clientScope + "-saml"}),
+			},
+			{
+				ResourceName:      "keycloak_realm_default_client_scopes.default_scopes",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// scopes removed from the list are detached from the realm
+			{
+				Config: testKeycloakRealmDefaultClientScopes_listOfScopes(realmName, clientScope, []string{"profile", clientScope + "-saml"}),
+				Check:  testAccCheckKeycloakRealmHasDefaultClientScopes(realmName, []string{"profile", clientScope + "-saml"}),
+			},
+			{
+				Config: testKeycloakRealmDefaultClientScopes_listOfScopes(realmName, clientScope, []string{}),
+				Check:  testAccCheckKeycloakRealmHasDefaultClientScopes(realmName, []string{}),
+			},
+		},
+	})
+}
+
+// scopes that are attached to the realm outside of terraform are detached again
+func TestAccKeycloakRealmDefaultClientScopes_authoritativeAdd(t *testing.T) {
+	t.Parallel()
+	realmName := acctest.RandomWithPrefix("tf-acc")
+	clientScope := acctest.RandomWithPrefix("tf-acc")
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		PreCheck:          func() { testAccPreCheck(t) },
+		Steps: []resource.TestStep{
+			{
+				Config: testKeycloakRealmDefaultClientScopes_listOfScopes(realmName, clientScope, []string{"profile"}),
+				Check:  testAccCheckKeycloakRealmHasDefaultClientScopes(realmName, []string{"profile"}),
+			},
+			{
+				PreConfig: func() {
+					err := keycloakClient.AttachRealmDefaultClientScopes(testCtx, realmName, []string{clientScope})
+					if err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config: testKeycloakRealmDefaultClientScopes_listOfScopes(realmName, clientScope, []string{"profile"}),
+				Check:  testAccCheckKeycloakRealmHasDefaultClientScopes(realmName, []string{"profile"}),
+			},
+		},
+	})
+}
+
+func TestAccKeycloakRealmDefaultClientScopes_validateScopeDoesNotExist(t *testing.T) {
+	t.Parallel()
+	realmName := acctest.RandomWithPrefix("tf-acc")
+	clientScope := acctest.RandomWithPrefix("tf-acc")
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		PreCheck:          func() { testAccPreCheck(t) },
+		Steps: []resource.TestStep{
+			{
+				Config:      testKeycloakRealmDefaultClientScopes_listOfScopes(realmName, clientScope, []string{"does-not-exist"}),
+				ExpectError: regexp.MustCompile("validation error: client scope does-not-exist does not exist in realm"),
+			},
+		},
+	})
+}
+
+func TestAccKeycloakRealmOptionalClientScopes_basic(t *testing.T) {
+	t.Parallel()
+	realmName := acctest.RandomWithPrefix("tf-acc")
+	clientScope := acctest.RandomWithPrefix("tf-acc")
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		PreCheck:          func() { testAccPreCheck(t) },
+		Steps: []resource.TestStep{
+			{
+				Config: testKeycloakRealmOptionalClientScopes_listOfScopes(realmName, clientScope, []string{"offline_access", clientScope}),
+				Check:  testAccCheckKeycloakRealmHasOptionalClientScopes(realmName, []string{"offline_access", clientScope}),
+			},
+			{
+				ResourceName:      "keycloak_realm_optional_client_scopes.optional_scopes",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: testKeycloakRealmOptionalClientScopes_listOfScopes(realmName, clientScope, []string{clientScope + "-saml"}),
+				Check:  testAccCheckKeycloakRealmHasOptionalClientScopes(realmName, []string{clientScope + "-saml"}),
+			},
+		},
+	})
+}
+
+func TestAccKeycloakRealmOptionalClientScopes_validateDuplicateScopeAssignment(t *testing.T) {
+	t.Parallel()
+	realmName := acctest.RandomWithPrefix("tf-acc")
+	clientScope := acctest.RandomWithPrefix("tf-acc")
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		PreCheck:          func() { testAccPreCheck(t) },
+		Steps: []resource.TestStep{
+			{
+				Config:      testKeycloakRealmOptionalClientScopes_duplicateScopeAssignment(realmName, clientScope),
+				ExpectError: regexp.MustCompile("validation error: scope .+ is already a default client scope of realm .+"),
+			},
+		},
+	})
+}
+
+func testAccCheckKeycloakRealmHasClientScopes(realmName, t string, expectedScopes []string, getClientScopes func() ([]*keycloak.OpenidClientScope, error)) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		clientScopes, err := getClientScopes()
+		if err != nil {
+			return err
+		}
+
+		var scopeNames []string
+		for _, clientScope := range clientScopes {
+			scopeNames = append(scopeNames, clientScope.Name)
+		}
+
+		expected := append([]string{}, expectedScopes...)
+		sort.Strings(expected)
+		sort.Strings(scopeNames)
+
+		if strings.Join(expected, ",") != strings.Join(scopeNames, ",") {
+			return fmt.Errorf("expected realm %s to have %s client scopes %v, got %v", realmName, t, expected, scopeNames)
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckKeycloakRealmHasDefaultClientScopes(realmName string, expectedScopes []string) resource.TestCheckFunc {
+	return testAccCheckKeycloakRealmHasClientScopes(realmName, "default", expectedScopes, func() ([]*keycloak.OpenidClientScope, error) {
+		return keycloakClient.GetRealmDefaultClientScopes(testCtx, realmName)
+	})
+}
+
+func testAccCheckKeycloakRealmHasOptionalClientScopes(realmName string, expectedScopes []string) resource.TestCheckFunc {
+	return testAccCheckKeycloakRealmHasClientScopes(realmName, "optional", expectedScopes, func() ([]*keycloak.OpenidClientScope, error) {
+		return keycloakClient.GetRealmOptionalClientScopes(testCtx, realmName)
+	})
+}
+
+func testKeycloakRealmClientScopes_realm(realmName, clientScope string) string {
+	return fmt.Sprintf(`
+resource "keycloak_realm" "realm" {
+	realm = "%s"
+}
+
+resource "keycloak_openid_client_scope" "client_scope" {
+	realm_id = keycloak_realm.realm.id
+	name     = "%s"
+}
+
+resource "keycloak_saml_client_scope" "client_scope" {
+	realm_id = keycloak_realm.realm.id
+	name     = "%s-saml"
+}
+	`, realmName, clientScope, clientScope)
+}
+
+func testKeycloakRealmDefaultClientScopes_listOfScopes(realmName, clientScope string, scopes []string) string {
+	return testKeycloakRealmClientScopes_realm(realmName, clientScope) + fmt.Sprintf(`
+resource "keycloak_realm_default_client_scopes" "default_scopes" {
+	realm_id       = keycloak_realm.realm.id
+	default_scopes = %s
+
+	depends_on = [
+		keycloak_openid_client_scope.client_scope,
+		keycloak_saml_client_scope.client_scope,
+	]
+}
+	`, arrayOfStringsForTerraformResource(scopes))
+}
+
+func testKeycloakRealmOptionalClientScopes_listOfScopes(realmName, clientScope string, scopes []string) string {
+	return testKeycloakRealmClientScopes_realm(realmName, clientScope) + fmt.Sprintf(`
+resource "keycloak_realm_optional_client_scopes" "optional_scopes" {
+	realm_id        = keycloak_realm.realm.id
+	optional_scopes = %s
+
+	depends_on = [
+		keycloak_openid_client_scope.client_scope,
+		keycloak_saml_client_scope.client_scope,
+	]
+}
+	`, arrayOfStringsForTerraformResource(scopes))
+}
+
+func testKeycloakRealmOptionalClientScopes_duplicateScopeAssignment(realmName, clientScope string) string {
+	return testKeycloakRealmClientScopes_realm(realmName, clientScope) + `
+resource "keycloak_realm_default_client_scopes" "default_scopes" {
+	realm_id       = keycloak_realm.realm.id
+	default_scopes = [keycloak_openid_client_scope.client_scope.name]
+}
+
+resource "keycloak_realm_optional_client_scopes" "optional_scopes" {
+	realm_id        = keycloak_realm.realm.id
+	optional_scopes = [keycloak_openid_client_scope.client_scope.name]
+
+	depends_on = [keycloak_realm_default_client_scopes.default_scopes]
+}
+	`
+}
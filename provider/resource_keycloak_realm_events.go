@@ -131,7 +131,7 @@ func resourceKeycloakRealmEventsDelete(ctx context.Context, data *schema.Resourc
 
 	err := keycloakClient.UpdateRealmEventsConfig(ctx, realmId, realmEventsConfig)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	return nil
@@ -145,7 +145,7 @@ func resourceKeycloakRealmEventsUpdate(ctx context.Context, data *schema.Resourc
 
 	err := keycloakClient.UpdateRealmEventsConfig(ctx, realmId, realmEventsConfig)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	setRealmEventsConfigData(data, realmEventsConfig)
@@ -0,0 +1,427 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/keycloak/terraform-provider-keycloak/keycloak"
+)
+
+// the keys of a rotation are named after the rotation and the time they were created at, so the schedule can be
+// derived from keycloak alone
+const realmKeyRotationTimeFormat = "20060102T150405Z"
+
+var (
+	keycloakRealmKeyRotationEcdsaAlgorithms = map[string]string{
+		"ES256": "P-256",
+		"ES384": "P-384",
+		"ES512": "P-521",
+	}
+	keycloakRealmKeyRotationAlgorithms = append(append(append([]string{},
+		keycloakRealmKeystoreRsaGeneratedAlgorithm...),
+		keycloakRealmKeystoreHmacGeneratedAlgorithm...),
+		keys(keycloakRealmKeyRotationEcdsaAlgorithms)...)
+)
+
+func resourceKeycloakRealmKeyRotation() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceKeycloakRealmKeyRotationReconcile,
+		ReadContext:   resourceKeycloakRealmKeyRotationRead,
+		UpdateContext: resourceKeycloakRealmKeyRotationReconcile,
+		DeleteContext: resourceKeycloakRealmKeyRotationDelete,
+		CustomizeDiff: resourceKeycloakRealmKeyRotationCustomizeDiff,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceKeycloakRealmKeyRotationImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"realm_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Prefix of the names of the keys that are created by this rotation.",
+			},
+			"algorithm": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "RS256",
+				ValidateFunc: validation.StringInSlice(keycloakRealmKeyRotationAlgorithms, false),
+				Description:  "Intended algorithm for the keys. Changing it rotates the keys.",
+			},
+			"key_size": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      2048,
+				ValidateFunc: validation.IntInSlice(keycloakRealmKeystoreRsaGeneratedSize),
+				Description:  "Size of the generated RSA keys, used for new keys only",
+			},
+			"priority": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Default:     100,
+				Description: "Priority of the first key, every new key has a higher priority than the previous one",
+			},
+			"rotation_period": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateFunc:     validateRealmKeyRotationPeriod,
+				DiffSuppressFunc: suppressDurationStringDiff,
+				Description:      "How long a key is used for signing before a new key replaces it, e.g. 720h",
+			},
+			"retirement_period": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateFunc:     validateRealmKeyRotationPeriod,
+				DiffSuppressFunc: suppressDurationStringDiff,
+				Description:      "How long a key is kept as a passive key after it was replaced, to verify tokens that were signed with it",
+			},
+			"current_key_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"current_kid": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"passive_kids": {
+				Type:     schema.TypeList,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Computed: true,
+			},
+			"next_rotation": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"next_retirement": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func validateRealmKeyRotationPeriod(i interface{}, k string) (s []string, errs []error) {
+	duration, err := time.ParseDuration(i.(string))
+	if err != nil {
+		return nil, []error{fmt.Errorf("expected %s to be a duration, got %s: %v", k, i, err)}
+	}
+
+	if duration <= 0 {
+		errs = append(errs, fmt.Errorf("expected %s to be a positive duration, got %s", k, i))
+	}
+
+	return nil, errs
+}
+
+func realmKeyRotationId(realmId, name string) string {
+	return fmt.Sprintf("%s/%s", realmId, name)
+}
+
+// rotatedKey is a key provider that was created by a rotation
+type rotatedKey struct {
+	*keycloak.RealmKeyProvider
+	createdAt time.Time
+}
+
+// getRotatedKeys returns the key providers that belong to a rotation, newest first
+func getRotatedKeys(keyProviders []*keycloak.RealmKeyProvider, name string) []*rotatedKey {
+	var rotatedKeys []*rotatedKey
+	for _, keyProvider := range keyProviders {
+		if !strings.HasPrefix(keyProvider.Name, name+"-") {
+			continue
+		}
+
+		createdAt, err := time.Parse(realmKeyRotationTimeFormat, strings.TrimPrefix(keyProvider.Name, name+"-"))
+		if err != nil {
+			continue
+		}
+
+		rotatedKeys = append(rotatedKeys, &rotatedKey{RealmKeyProvider: keyProvider, createdAt: createdAt})
+	}
+
+	sort.Slice(rotatedKeys, func(i, j int) bool {
+		return rotatedKeys[i].createdAt.After(rotatedKeys[j].createdAt)
+	})
+
+	return rotatedKeys
+}
+
+type realmKeyRotationPlan struct {
+	// create is set when a new key has to replace the current one
+	create     bool
+	priority   int
+	deactivate []*rotatedKey
+	remove     []*rotatedKey
+}
+
+// planRealmKeyRotation decides which keys of a rotation to create, make passive and delete at a point in time
+func planRealmKeyRotation(rotatedKeys []*rotatedKey, now time.Time, algorithm string, priority int, rotationPeriod, retirementPeriod time.Duration) *realmKeyRotationPlan {
+	plan := &realmKeyRotationPlan{
+		create:   len(rotatedKeys) == 0,
+		priority: priority,
+	}
+
+	if len(rotatedKeys) != 0 {
+		current := rotatedKeys[0]
+		plan.create = !now.Before(current.createdAt.Add(rotationPeriod)) || current.Algorithm != algorithm || !current.Enabled
+	}
+
+	for _, rotatedKey := range rotatedKeys {
+		if rotatedKey.Priority >= plan.priority {
+			plan.priority = rotatedKey.Priority + 1
+		}
+	}
+
+	for i, rotatedKey := range rotatedKeys {
+		if i == 0 && !plan.create {
+			continue
+		}
+
+		// a key retires once it has been replaced for longer than the retirement period
+		replacedAt := now
+		if i != 0 {
+			replacedAt = rotatedKeys[i-1].createdAt
+		}
+
+		if !now.Before(replacedAt.Add(retirementPeriod)) {
+			plan.remove = append(plan.remove, rotatedKey)
+		} else if rotatedKey.Active {
+			plan.deactivate = append(plan.deactivate, rotatedKey)
+		}
+	}
+
+	return plan
+}
+
+// realmKeyRotationSchedule returns when the current key has to be replaced, and when the next passive key retires
+func realmKeyRotationSchedule(rotatedKeys []*rotatedKey, rotationPeriod, retirementPeriod time.Duration) (time.Time, time.Time) {
+	var nextRotation, nextRetirement time.Time
+	for i, rotatedKey := range rotatedKeys {
+		if i == 0 {
+			nextRotation = rotatedKey.createdAt.Add(rotationPeriod)
+			continue
+		}
+
+		retirement := rotatedKeys[i-1].createdAt.Add(retirementPeriod)
+		if nextRetirement.IsZero() || retirement.Before(nextRetirement) {
+			nextRetirement = retirement
+		}
+	}
+
+	return nextRotation, nextRetirement
+}
+
+func formatRealmKeyRotationTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+
+	return t.UTC().Format(time.RFC3339)
+}
+
+func newRotatedKey(ctx context.Context, keycloakClient *keycloak.KeycloakClient, data *schema.ResourceData, name string, priority int) error {
+	realmId := data.Get("realm_id").(string)
+	algorithm := data.Get("algorithm").(string)
+
+	if curve, ok := keycloakRealmKeyRotationEcdsaAlgorithms[algorithm]; ok {
+		return keycloakClient.NewRealmKeystoreEcdsaGenerated(ctx, &keycloak.RealmKeystoreEcdsaGenerated{
+			Name:          name,
+			RealmId:       realmId,
+			Active:        true,
+			Enabled:       true,
+			Priority:      priority,
+			EllipticCurve: curve,
+		})
+	}
+
+	if strings.HasPrefix(algorithm, "HS") {
+		return keycloakClient.NewRealmKeystoreHmacGenerated(ctx, &keycloak.RealmKeystoreHmacGenerated{
+			Name:       name,
+			RealmId:    realmId,
+			Active:     true,
+			Enabled:    true,
+			Priority:   priority,
+			SecretSize: 64,
+			Algorithm:  algorithm,
+		})
+	}
+
+	return keycloakClient.NewRealmKeystoreRsaGenerated(ctx, &keycloak.RealmKeystoreRsaGenerated{
+		Name:      name,
+		RealmId:   realmId,
+		Active:    true,
+		Enabled:   true,
+		Priority:  priority,
+		Algorithm: algorithm,
+		KeySize:   data.Get("key_size").(int),
+	})
+}
+
+func resourceKeycloakRealmKeyRotationReconcile(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	realmId := data.Get("realm_id").(string)
+	name := data.Get("name").(string)
+	rotationPeriod, _ := time.ParseDuration(data.Get("rotation_period").(string))
+	retirementPeriod, _ := time.ParseDuration(data.Get("retirement_period").(string))
+
+	keyProviders, err := keycloakClient.GetRealmKeyProviders(ctx, realmId)
+	if err != nil {
+		return diagnosticsFromError(err, data)
+	}
+
+	now := time.Now().UTC().Truncate(time.Second)
+	rotatedKeys := getRotatedKeys(keyProviders, name)
+	plan := planRealmKeyRotation(rotatedKeys, now, data.Get("algorithm").(string), data.Get("priority").(int), rotationPeriod, retirementPeriod)
+
+	// the new key is created first, so there is always an active key to sign with
+	if plan.create {
+		if len(rotatedKeys) != 0 && !rotatedKeys[0].createdAt.Before(now) {
+			return diag.Errorf("the keys of %s were already rotated at %s", name, now.Format(time.RFC3339))
+		}
+
+		err = newRotatedKey(ctx, keycloakClient, data, fmt.Sprintf("%s-%s", name, now.Format(realmKeyRotationTimeFormat)), plan.priority)
+		if err != nil {
+			return diagnosticsFromError(err, data)
+		}
+	}
+
+	for _, rotatedKey := range plan.deactivate {
+		err = keycloakClient.SetRealmKeyProviderActive(ctx, realmId, rotatedKey.Id, false)
+		if err != nil {
+			return diagnosticsFromError(err, data)
+		}
+	}
+
+	for _, rotatedKey := range plan.remove {
+		err = keycloakClient.DeleteComponent(ctx, realmId, rotatedKey.Id)
+		if err != nil && !keycloak.ErrorIs404(err) {
+			return diagnosticsFromError(err, data)
+		}
+	}
+
+	data.SetId(realmKeyRotationId(realmId, name))
+
+	return resourceKeycloakRealmKeyRotationRead(ctx, data, meta)
+}
+
+func resourceKeycloakRealmKeyRotationRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	realmId := data.Get("realm_id").(string)
+	name := data.Get("name").(string)
+	rotationPeriod, _ := time.ParseDuration(data.Get("rotation_period").(string))
+	retirementPeriod, _ := time.ParseDuration(data.Get("retirement_period").(string))
+
+	keyProviders, err := keycloakClient.GetRealmKeyProviders(ctx, realmId)
+	if err != nil {
+		return handleNotFoundError(ctx, err, data)
+	}
+
+	rotatedKeys := getRotatedKeys(keyProviders, name)
+	if len(rotatedKeys) == 0 {
+		data.SetId("")
+		return nil
+	}
+
+	keys, err := keycloakClient.GetRealmKeys(ctx, realmId)
+	if err != nil {
+		return handleNotFoundError(ctx, err, data)
+	}
+
+	kids := map[string]string{}
+	for _, key := range keys.Keys {
+		if key.ProviderId != nil && key.Kid != nil {
+			kids[*key.ProviderId] = *key.Kid
+		}
+	}
+
+	passiveKids := []string{}
+	for _, rotatedKey := range rotatedKeys[1:] {
+		if kid, ok := kids[rotatedKey.Id]; ok {
+			passiveKids = append(passiveKids, kid)
+		}
+	}
+
+	nextRotation, nextRetirement := realmKeyRotationSchedule(rotatedKeys, rotationPeriod, retirementPeriod)
+
+	data.Set("current_key_id", rotatedKeys[0].Id)
+	data.Set("current_kid", kids[rotatedKeys[0].Id])
+	data.Set("passive_kids", passiveKids)
+	data.Set("next_rotation", formatRealmKeyRotationTime(nextRotation))
+	data.Set("next_retirement", formatRealmKeyRotationTime(nextRetirement))
+
+	return nil
+}
+
+func resourceKeycloakRealmKeyRotationDelete(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	realmId := data.Get("realm_id").(string)
+	name := data.Get("name").(string)
+
+	keyProviders, err := keycloakClient.GetRealmKeyProviders(ctx, realmId)
+	if err != nil {
+		if keycloak.ErrorIs404(err) {
+			return nil
+		}
+		return diagnosticsFromError(err, data)
+	}
+
+	for _, rotatedKey := range getRotatedKeys(keyProviders, name) {
+		err = keycloakClient.DeleteComponent(ctx, realmId, rotatedKey.Id)
+		if err != nil && !keycloak.ErrorIs404(err) {
+			return diagnosticsFromError(err, data)
+		}
+	}
+
+	return nil
+}
+
+// the keys are rotated and retired during apply, so once either is due the computed attributes are going to change
+func resourceKeycloakRealmKeyRotationCustomizeDiff(_ context.Context, diff *schema.ResourceDiff, _ interface{}) error {
+	if diff.Id() == "" {
+		return nil
+	}
+
+	now := time.Now()
+	for _, attribute := range []string{"next_rotation", "next_retirement"} {
+		due, err := time.Parse(time.RFC3339, diff.Get(attribute).(string))
+		if err != nil || now.Before(due) {
+			continue
+		}
+
+		for _, computed := range []string{"current_key_id", "current_kid", "passive_kids", "next_rotation", "next_retirement"} {
+			if err := diff.SetNewComputed(computed); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	return nil
+}
+
+func resourceKeycloakRealmKeyRotationImport(_ context.Context, d *schema.ResourceData, _ interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.Split(d.Id(), "/")
+
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("Invalid import. Supported import formats: {{realmId}}/{{name}}")
+	}
+
+	d.Set("realm_id", parts[0])
+	d.Set("name", parts[1])
+
+	return []*schema.ResourceData{d}, nil
+}
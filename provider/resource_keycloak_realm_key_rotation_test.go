@@ -0,0 +1,107 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccKeycloakRealmKeyRotation_basic(t *testing.T) {
+	t.Parallel()
+
+	realmName := acctest.RandomWithPrefix("tf-acc")
+	resourceName := "keycloak_realm_key_rotation.rotation"
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		PreCheck:          func() { testAccPreCheck(t) },
+		CheckDestroy:      testAccCheckRealmKeyRotationDestroy(realmName),
+		Steps: []resource.TestStep{
+			{
+				Config: testKeycloakRealmKeyRotation_basic(realmName, "RS256"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(resourceName, "current_key_id"),
+					resource.TestCheckResourceAttrSet(resourceName, "current_kid"),
+					resource.TestCheckResourceAttrSet(resourceName, "next_rotation"),
+					resource.TestCheckResourceAttr(resourceName, "passive_kids.#", "0"),
+					resource.TestCheckResourceAttr(resourceName, "next_retirement", ""),
+					testAccCheckRealmKeyRotationKeys(realmName, 1),
+				),
+			},
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateId:           realmName + "/signing",
+				ImportStateVerifyIgnore: []string{"algorithm", "key_size", "priority", "rotation_period", "retirement_period", "next_rotation", "next_retirement"},
+			},
+			// changing the algorithm rotates the keys, the previous key stays as a passive key
+			{
+				Config: testKeycloakRealmKeyRotation_basic(realmName, "PS256"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(resourceName, "current_kid"),
+					resource.TestCheckResourceAttr(resourceName, "passive_kids.#", "1"),
+					resource.TestCheckResourceAttrSet(resourceName, "next_retirement"),
+					testAccCheckRealmKeyRotationKeys(realmName, 2),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckRealmKeyRotationKeys(realmName string, expected int) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		keyProviders, err := keycloakClient.GetRealmKeyProviders(testCtx, realmName)
+		if err != nil {
+			return err
+		}
+
+		rotatedKeys := getRotatedKeys(keyProviders, "signing")
+		if len(rotatedKeys) != expected {
+			return fmt.Errorf("expected %d keys to be rotated, got %d", expected, len(rotatedKeys))
+		}
+
+		for i, rotatedKey := range rotatedKeys {
+			if rotatedKey.Active != (i == 0) {
+				return fmt.Errorf("expected only the newest key to be active, key %s has active set to %t", rotatedKey.Name, rotatedKey.Active)
+			}
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckRealmKeyRotationDestroy(realmName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		keyProviders, err := keycloakClient.GetRealmKeyProviders(testCtx, realmName)
+		if err != nil {
+			// the realm is destroyed along with the rotation
+			return nil
+		}
+
+		if rotatedKeys := getRotatedKeys(keyProviders, "signing"); len(rotatedKeys) != 0 {
+			return fmt.Errorf("expected the rotated keys to be deleted, found %d", len(rotatedKeys))
+		}
+
+		return nil
+	}
+}
+
+func testKeycloakRealmKeyRotation_basic(realmName, algorithm string) string {
+	return fmt.Sprintf(`
+resource "keycloak_realm" "realm" {
+	realm = "%s"
+}
+
+resource "keycloak_realm_key_rotation" "rotation" {
+	realm_id          = keycloak_realm.realm.id
+	name              = "signing"
+	algorithm         = "%s"
+	rotation_period   = "720h"
+	retirement_period = "168h"
+}
+	`, realmName, algorithm)
+}
@@ -0,0 +1,115 @@
+package provider
+
+import (
+	"testing"
+	"time"
+
+	"github.com/keycloak/terraform-provider-keycloak/keycloak"
+)
+
+func newTestRotatedKey(id string, createdAt time.Time, active bool, priority int) *rotatedKey {
+	return &rotatedKey{
+		RealmKeyProvider: &keycloak.RealmKeyProvider{
+			Id:        id,
+			Name:      "signing-" + createdAt.Format(realmKeyRotationTimeFormat),
+			Active:    active,
+			Enabled:   true,
+			Priority:  priority,
+			Algorithm: "RS256",
+		},
+		createdAt: createdAt,
+	}
+}
+
+func rotatedKeyIds(rotatedKeys []*rotatedKey) []string {
+	var ids []string
+	for _, rotatedKey := range rotatedKeys {
+		ids = append(ids, rotatedKey.Id)
+	}
+
+	return ids
+}
+
+func TestGetRotatedKeysOnlyReturnsKeysOfTheRotation(t *testing.T) {
+	t.Parallel()
+
+	rotatedKeys := getRotatedKeys([]*keycloak.RealmKeyProvider{
+		{Id: "older", Name: "signing-20260101T000000Z"},
+		{Id: "other", Name: "rsa-generated"},
+		{Id: "newer", Name: "signing-20260201T000000Z"},
+		{Id: "other-rotation", Name: "signing-enc-20260301T000000Z"},
+	}, "signing")
+
+	if ids := rotatedKeyIds(rotatedKeys); len(ids) != 2 || ids[0] != "newer" || ids[1] != "older" {
+		t.Errorf("expected the keys of the rotation newest first, got %v", ids)
+	}
+}
+
+func TestPlanRealmKeyRotation(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+	day := 24 * time.Hour
+
+	// no keys yet
+	plan := planRealmKeyRotation(nil, now, "RS256", 100, 30*day, 7*day)
+	if !plan.create || plan.priority != 100 || len(plan.deactivate) != 0 || len(plan.remove) != 0 {
+		t.Errorf("expected the first key to be created, got %+v", plan)
+	}
+
+	// the current key is still fresh, and the passive key is kept until a week after it was replaced
+	current := newTestRotatedKey("current", now.Add(-10*day), true, 101)
+	passive := newTestRotatedKey("passive", now.Add(-40*day), false, 100)
+	plan = planRealmKeyRotation([]*rotatedKey{current, passive}, now, "RS256", 100, 30*day, 14*day)
+	if plan.create || len(plan.deactivate) != 0 || len(plan.remove) != 0 {
+		t.Errorf("expected nothing to change, got %+v", plan)
+	}
+
+	// the passive key retires
+	plan = planRealmKeyRotation([]*rotatedKey{current, passive}, now, "RS256", 100, 30*day, 7*day)
+	if plan.create || len(plan.remove) != 1 || plan.remove[0].Id != "passive" {
+		t.Errorf("expected the passive key to be deleted, got %+v", plan)
+	}
+
+	// the current key is replaced by a key with a higher priority, and becomes passive
+	current = newTestRotatedKey("current", now.Add(-31*day), true, 101)
+	plan = planRealmKeyRotation([]*rotatedKey{current, passive}, now, "RS256", 100, 30*day, 7*day)
+	if !plan.create || plan.priority != 102 {
+		t.Errorf("expected a new key with a higher priority, got %+v", plan)
+	}
+	if ids := rotatedKeyIds(plan.deactivate); len(ids) != 1 || ids[0] != "current" {
+		t.Errorf("expected the current key to become passive, got %v", ids)
+	}
+	if ids := rotatedKeyIds(plan.remove); len(ids) != 1 || ids[0] != "passive" {
+		t.Errorf("expected the passive key to be deleted, got %v", ids)
+	}
+
+	// changing the algorithm rotates the keys right away
+	current = newTestRotatedKey("current", now.Add(-day), true, 101)
+	plan = planRealmKeyRotation([]*rotatedKey{current}, now, "PS256", 100, 30*day, 7*day)
+	if !plan.create || len(plan.deactivate) != 1 {
+		t.Errorf("expected the keys to be rotated for a new algorithm, got %+v", plan)
+	}
+}
+
+func TestRealmKeyRotationSchedule(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+	day := 24 * time.Hour
+
+	rotatedKeys := []*rotatedKey{
+		newTestRotatedKey("current", now.Add(-10*day), true, 102),
+		newTestRotatedKey("passive", now.Add(-40*day), false, 101),
+		newTestRotatedKey("oldest", now.Add(-70*day), false, 100),
+	}
+
+	nextRotation, nextRetirement := realmKeyRotationSchedule(rotatedKeys, 30*day, 45*day)
+	if !nextRotation.Equal(now.Add(20 * day)) {
+		t.Errorf("expected the next rotation in 20 days, got %s", nextRotation)
+	}
+	// the oldest key was replaced 40 days ago
+	if !nextRetirement.Equal(now.Add(5 * day)) {
+		t.Errorf("expected the next retirement in 5 days, got %s", nextRetirement)
+	}
+}
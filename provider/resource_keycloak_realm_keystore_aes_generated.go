@@ -95,17 +95,17 @@ func resourceKeycloakRealmKeystoreAesGeneratedCreate(ctx context.Context, data *
 
 	realmKey, err := getRealmKeystoreAesGeneratedFromData(data)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	err = keycloakClient.NewRealmKeystoreAesGenerated(ctx, realmKey)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	err = setRealmKeystoreAesGeneratedData(data, realmKey)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	return resourceKeycloakRealmKeystoreAesGeneratedRead(ctx, data, meta)
@@ -124,7 +124,7 @@ func resourceKeycloakRealmKeystoreAesGeneratedRead(ctx context.Context, data *sc
 
 	err = setRealmKeystoreAesGeneratedData(data, realmKey)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	return nil
@@ -135,17 +135,17 @@ func resourceKeycloakRealmKeystoreAesGeneratedUpdate(ctx context.Context, data *
 
 	realmKey, err := getRealmKeystoreAesGeneratedFromData(data)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	err = keycloakClient.UpdateRealmKeystoreAesGenerated(ctx, realmKey)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	err = setRealmKeystoreAesGeneratedData(data, realmKey)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	return nil
@@ -0,0 +1,245 @@
+package provider
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/keycloak/terraform-provider-keycloak/keycloak"
+	"regexp"
+	"strconv"
+	"testing"
+)
+
+func TestAccKeycloakRealmKeystoreEcdhGenerated_basic(t *testing.T) {
+	t.Parallel()
+	skipIfVersionIsLessThanOrEqualTo(testCtx, t, keycloakClient, keycloak.Version_24)
+
+	ecdhName := acctest.RandomWithPrefix("tf-acc")
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		PreCheck:          func() { testAccPreCheck(t) },
+		CheckDestroy:      testAccCheckRealmKeystoreEcdhGeneratedDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config: testKeycloakRealmKeystoreEcdhGenerated_basic(ecdhName),
+				Check:  testAccCheckRealmKeystoreEcdhGeneratedExists("keycloak_realm_keystore_ecdh_generated.realm_ecdh"),
+			},
+			{
+				ResourceName:      "keycloak_realm_keystore_ecdh_generated.realm_ecdh",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: getRealmKeystoreGenericImportId("keycloak_realm_keystore_ecdh_generated.realm_ecdh"),
+			},
+		},
+	})
+}
+
+func TestAccKeycloakRealmKeystoreEcdhGenerated_createAfterManualDestroy(t *testing.T) {
+	t.Parallel()
+	skipIfVersionIsLessThanOrEqualTo(testCtx, t, keycloakClient, keycloak.Version_24)
+
+	var ecdh = &keycloak.RealmKeystoreEcdhGenerated{}
+
+	fullNameKeystoreName := acctest.RandomWithPrefix("tf-acc")
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		PreCheck:          func() { testAccPreCheck(t) },
+		CheckDestroy:      testAccCheckRealmKeystoreEcdhGeneratedDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config: testKeycloakRealmKeystoreEcdhGenerated_basic(fullNameKeystoreName),
+				Check:  testAccCheckRealmKeystoreEcdhGeneratedFetch("keycloak_realm_keystore_ecdh_generated.realm_ecdh", ecdh),
+			},
+			{
+				PreConfig: func() {
+					err := keycloakClient.DeleteRealmKeystoreEcdhGenerated(testCtx, ecdh.RealmId, ecdh.Id)
+					if err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config: testKeycloakRealmKeystoreEcdhGenerated_basic(fullNameKeystoreName),
+				Check:  testAccCheckRealmKeystoreEcdhGeneratedFetch("keycloak_realm_keystore_ecdh_generated.realm_ecdh", ecdh),
+			},
+		},
+	})
+}
+
+func TestAccKeycloakRealmKeystoreEcdhGenerated_ellipticCurveValidation(t *testing.T) {
+	t.Parallel()
+	skipIfVersionIsLessThanOrEqualTo(testCtx, t, keycloakClient, keycloak.Version_24)
+
+	ecdhName := acctest.RandomWithPrefix("tf-acc")
+	ellipticCurve := randomStringInSlice(keycloakRealmKeystoreEcdhGeneratedEllipticCurve)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		PreCheck:          func() { testAccPreCheck(t) },
+		CheckDestroy:      testAccCheckRealmKeystoreEcdhGeneratedDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config:      testKeycloakRealmKeystoreEcdhGenerated_basicWithAttrValidation(ecdhName, "elliptic_curve_key", acctest.RandString(10)),
+				ExpectError: regexp.MustCompile("expected elliptic_curve_key to be one of .+ got .+"),
+			},
+			{
+				Config: testKeycloakRealmKeystoreEcdhGenerated_basicWithAttrValidation(ecdhName, "elliptic_curve_key", ellipticCurve),
+				Check:  testAccCheckRealmKeystoreEcdhGeneratedExists("keycloak_realm_keystore_ecdh_generated.realm_ecdh"),
+			},
+		},
+	})
+}
+
+func TestAccKeycloakRealmKeystoreEcdhGenerated_updateRealmKeystoreEcdhGenerated(t *testing.T) {
+	t.Parallel()
+	skipIfVersionIsLessThanOrEqualTo(testCtx, t, keycloakClient, keycloak.Version_24)
+
+	enabled := randomBool()
+	active := randomBool()
+
+	groupKeystoreOne := &keycloak.RealmKeystoreEcdhGenerated{
+		Name:          acctest.RandString(10),
+		RealmId:       testAccRealmUserFederation.Realm,
+		Enabled:       enabled,
+		Active:        active,
+		Priority:      acctest.RandIntRange(0, 100),
+		EllipticCurve: randomStringInSlice(keycloakRealmKeystoreEcdhGeneratedEllipticCurve),
+	}
+
+	groupKeystoreTwo := &keycloak.RealmKeystoreEcdhGenerated{
+		Name:          acctest.RandString(10),
+		RealmId:       testAccRealmUserFederation.Realm,
+		Enabled:       enabled,
+		Active:        active,
+		Priority:      acctest.RandIntRange(0, 100),
+		EllipticCurve: randomStringInSlice(keycloakRealmKeystoreEcdhGeneratedEllipticCurve),
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		PreCheck:          func() { testAccPreCheck(t) },
+		CheckDestroy:      testAccCheckRealmKeystoreEcdhGeneratedDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config: testKeycloakRealmKeystoreEcdhGenerated_basicFromInterface(groupKeystoreOne),
+				Check:  testAccCheckRealmKeystoreEcdhGeneratedExists("keycloak_realm_keystore_ecdh_generated.realm_ecdh"),
+			},
+			{
+				Config: testKeycloakRealmKeystoreEcdhGenerated_basicFromInterface(groupKeystoreTwo),
+				Check:  testAccCheckRealmKeystoreEcdhGeneratedExists("keycloak_realm_keystore_ecdh_generated.realm_ecdh"),
+			},
+		},
+	})
+}
+
+func testAccCheckRealmKeystoreEcdhGeneratedExists(resourceName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		_, err := getKeycloakRealmKeystoreEcdhGeneratedFromState(s, resourceName)
+		if err != nil {
+			return err
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckRealmKeystoreEcdhGeneratedFetch(resourceName string, keystore *keycloak.RealmKeystoreEcdhGenerated) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		fetchedKeystore, err := getKeycloakRealmKeystoreEcdhGeneratedFromState(s, resourceName)
+		if err != nil {
+			return err
+		}
+
+		keystore.Id = fetchedKeystore.Id
+		keystore.RealmId = fetchedKeystore.RealmId
+
+		return nil
+	}
+}
+
+func testAccCheckRealmKeystoreEcdhGeneratedDestroy() resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		for _, rs := range s.RootModule().Resources {
+			if rs.Type != "keycloak_realm_keystore_ecdh_generated" {
+				continue
+			}
+
+			id := rs.Primary.ID
+			realm := rs.Primary.Attributes["realm_id"]
+
+			ecdh, _ := keycloakClient.GetRealmKeystoreEcdhGenerated(testCtx, realm, id)
+			if ecdh != nil {
+				return fmt.Errorf("ecdh keystore with id %s still exists", id)
+			}
+		}
+
+		return nil
+	}
+}
+
+func getKeycloakRealmKeystoreEcdhGeneratedFromState(s *terraform.State,
+	resourceName string) (*keycloak.RealmKeystoreEcdhGenerated,
+	error) {
+	rs, ok := s.RootModule().Resources[resourceName]
+	if !ok {
+		return nil, fmt.Errorf("resource not found: %s", resourceName)
+	}
+
+	id := rs.Primary.ID
+	realm := rs.Primary.Attributes["realm_id"]
+
+	realmKeystore, err := keycloakClient.GetRealmKeystoreEcdhGenerated(testCtx, realm, id)
+	if err != nil {
+		return nil, fmt.Errorf("error getting ecdh keystore with id %s: %s", id, err)
+	}
+
+	return realmKeystore, nil
+}
+
+func testKeycloakRealmKeystoreEcdhGenerated_basic(ecdhName string) string {
+	return fmt.Sprintf(`
+data "keycloak_realm" "realm" {
+	realm = "%s"
+}
+
+resource "keycloak_realm_keystore_ecdh_generated" "realm_ecdh" {
+	name      = "%s"
+	realm_id  = data.keycloak_realm.realm.id
+
+    priority           = 100
+    elliptic_curve_key = "P-384"
+}
+	`, testAccRealmUserFederation.Realm, ecdhName)
+}
+
+func testKeycloakRealmKeystoreEcdhGenerated_basicWithAttrValidation(ecdhName, attr, val string) string {
+	return fmt.Sprintf(`
+data "keycloak_realm" "realm" {
+	realm = "%s"
+}
+
+resource "keycloak_realm_keystore_ecdh_generated" "realm_ecdh" {
+	name      = "%s"
+	realm_id  = data.keycloak_realm.realm.id
+
+	%s         = "%s"
+}
+	`, testAccRealmUserFederation.Realm, ecdhName, attr, val)
+}
+
+func testKeycloakRealmKeystoreEcdhGenerated_basicFromInterface(keystore *keycloak.RealmKeystoreEcdhGenerated) string {
+	return fmt.Sprintf(`
+data "keycloak_realm" "realm" {
+	realm = "%s"
+}
+
+resource "keycloak_realm_keystore_ecdh_generated" "realm_ecdh" {
+	name      = "%s"
+	realm_id  = data.keycloak_realm.realm.id
+
+    priority           = "%s"
+    elliptic_curve_key = "%s"
+}
+	`, testAccRealmUserFederation.Realm, keystore.Name, strconv.Itoa(keystore.Priority), keystore.EllipticCurve)
+}
@@ -95,17 +95,17 @@ func resourceKeycloakRealmKeystoreEcdsaGeneratedCreate(ctx context.Context, data
 
 	realmKey, err := getRealmKeystoreEcdsaGeneratedFromData(data)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	err = keycloakClient.NewRealmKeystoreEcdsaGenerated(ctx, realmKey)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	err = setRealmKeystoreEcdsaGeneratedData(data, realmKey)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	return resourceKeycloakRealmKeystoreEcdsaGeneratedRead(ctx, data, meta)
@@ -124,7 +124,7 @@ func resourceKeycloakRealmKeystoreEcdsaGeneratedRead(ctx context.Context, data *
 
 	err = setRealmKeystoreEcdsaGeneratedData(data, realmKey)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	return nil
@@ -135,17 +135,17 @@ func resourceKeycloakRealmKeystoreEcdsaGeneratedUpdate(ctx context.Context, data
 
 	realmKey, err := getRealmKeystoreEcdsaGeneratedFromData(data)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	err = keycloakClient.UpdateRealmKeystoreEcdsaGenerated(ctx, realmKey)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	err = setRealmKeystoreEcdsaGeneratedData(data, realmKey)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	return nil
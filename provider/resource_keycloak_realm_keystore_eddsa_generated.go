@@ -0,0 +1,165 @@
+package provider
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/keycloak/terraform-provider-keycloak/keycloak"
+)
+
+var (
+	keycloakRealmKeystoreEddsaGeneratedEllipticCurve = []string{"Ed25519", "Ed448"}
+)
+
+func resourceKeycloakRealmKeystoreEddsaGenerated() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceKeycloakRealmKeystoreEddsaGeneratedCreate,
+		ReadContext:   resourceKeycloakRealmKeystoreEddsaGeneratedRead,
+		UpdateContext: resourceKeycloakRealmKeystoreEddsaGeneratedUpdate,
+		DeleteContext: resourceKeycloakRealmKeystoreEddsaGeneratedDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceKeycloakRealmKeystoreGenericImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Display name of provider when linked in admin console.",
+			},
+			"realm_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"active": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Set if the keys can be used for signing",
+			},
+			"enabled": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Set if the keys are enabled",
+			},
+			"priority": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Default:     0,
+				Description: "Priority for the provider",
+			},
+			"elliptic_curve_key": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice(keycloakRealmKeystoreEddsaGeneratedEllipticCurve, false),
+				Default:      "Ed25519",
+				Description:  "Edwards curve used in EdDSA",
+			},
+		},
+	}
+}
+
+func getRealmKeystoreEddsaGeneratedFromData(data *schema.ResourceData) (*keycloak.RealmKeystoreEddsaGenerated, error) {
+	keystore := &keycloak.RealmKeystoreEddsaGenerated{
+		Id:      data.Id(),
+		Name:    data.Get("name").(string),
+		RealmId: data.Get("realm_id").(string),
+
+		Active:        data.Get("active").(bool),
+		Enabled:       data.Get("enabled").(bool),
+		Priority:      data.Get("priority").(int),
+		EllipticCurve: data.Get("elliptic_curve_key").(string),
+	}
+
+	return keystore, nil
+}
+
+func setRealmKeystoreEddsaGeneratedData(data *schema.ResourceData, realmKey *keycloak.RealmKeystoreEddsaGenerated) error {
+	data.SetId(realmKey.Id)
+
+	data.Set("name", realmKey.Name)
+	data.Set("realm_id", realmKey.RealmId)
+
+	data.Set("active", realmKey.Active)
+	data.Set("enabled", realmKey.Enabled)
+	data.Set("priority", realmKey.Priority)
+	data.Set("elliptic_curve_key", realmKey.EllipticCurve)
+
+	return nil
+}
+
+func resourceKeycloakRealmKeystoreEddsaGeneratedCreate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	if diags := requireFeature(ctx, keycloakClient, keycloak.FeatureEddsaKeys); diags.HasError() {
+		return diags
+	}
+
+	realmKey, err := getRealmKeystoreEddsaGeneratedFromData(data)
+	if err != nil {
+		return diagnosticsFromError(err, data)
+	}
+
+	err = keycloakClient.NewRealmKeystoreEddsaGenerated(ctx, realmKey)
+	if err != nil {
+		return diagnosticsFromError(err, data)
+	}
+
+	err = setRealmKeystoreEddsaGeneratedData(data, realmKey)
+	if err != nil {
+		return diagnosticsFromError(err, data)
+	}
+
+	return resourceKeycloakRealmKeystoreEddsaGeneratedRead(ctx, data, meta)
+}
+
+func resourceKeycloakRealmKeystoreEddsaGeneratedRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	realmId := data.Get("realm_id").(string)
+	id := data.Id()
+
+	realmKey, err := keycloakClient.GetRealmKeystoreEddsaGenerated(ctx, realmId, id)
+	if err != nil {
+		return handleNotFoundError(ctx, err, data)
+	}
+
+	err = setRealmKeystoreEddsaGeneratedData(data, realmKey)
+	if err != nil {
+		return diagnosticsFromError(err, data)
+	}
+
+	return nil
+}
+
+func resourceKeycloakRealmKeystoreEddsaGeneratedUpdate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	realmKey, err := getRealmKeystoreEddsaGeneratedFromData(data)
+	if err != nil {
+		return diagnosticsFromError(err, data)
+	}
+
+	err = keycloakClient.UpdateRealmKeystoreEddsaGenerated(ctx, realmKey)
+	if err != nil {
+		return diagnosticsFromError(err, data)
+	}
+
+	err = setRealmKeystoreEddsaGeneratedData(data, realmKey)
+	if err != nil {
+		return diagnosticsFromError(err, data)
+	}
+
+	return nil
+}
+
+func resourceKeycloakRealmKeystoreEddsaGeneratedDelete(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	realmId := data.Get("realm_id").(string)
+	id := data.Id()
+
+	return diag.FromErr(keycloakClient.DeleteRealmKeystoreEddsaGenerated(ctx, realmId, id))
+}
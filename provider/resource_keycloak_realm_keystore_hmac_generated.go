@@ -105,17 +105,17 @@ func resourceKeycloakRealmKeystoreHmacGeneratedCreate(ctx context.Context, data
 
 	realmKey, err := getRealmKeystoreHmacGeneratedFromData(data)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	err = keycloakClient.NewRealmKeystoreHmacGenerated(ctx, realmKey)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	err = setRealmKeystoreHmacGeneratedData(data, realmKey)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	return resourceKeycloakRealmKeystoreHmacGeneratedRead(ctx, data, meta)
@@ -134,7 +134,7 @@ func resourceKeycloakRealmKeystoreHmacGeneratedRead(ctx context.Context, data *s
 
 	err = setRealmKeystoreHmacGeneratedData(data, realmKey)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	return nil
@@ -145,17 +145,17 @@ func resourceKeycloakRealmKeystoreHmacGeneratedUpdate(ctx context.Context, data
 
 	realmKey, err := getRealmKeystoreHmacGeneratedFromData(data)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	err = keycloakClient.UpdateRealmKeystoreHmacGenerated(ctx, realmKey)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	err = setRealmKeystoreHmacGeneratedData(data, realmKey)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	return nil
@@ -124,17 +124,17 @@ func resourceKeycloakRealmKeystoreJavaKeystoreCreate(ctx context.Context, data *
 
 	realmKey, err := getRealmKeystoreJavaKeystoreFromData(data)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	err = keycloakClient.NewRealmKeystoreJavaKeystore(ctx, realmKey)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	err = setRealmKeystoreJavaKeystoreData(data, realmKey)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	return resourceKeycloakRealmKeystoreJavaKeystoreRead(ctx, data, meta)
@@ -153,7 +153,7 @@ func resourceKeycloakRealmKeystoreJavaKeystoreRead(ctx context.Context, data *sc
 
 	err = setRealmKeystoreJavaKeystoreData(data, realmKey)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	return nil
@@ -164,17 +164,17 @@ func resourceKeycloakRealmKeystoreJavaKeystoreUpdate(ctx context.Context, data *
 
 	realmKey, err := getRealmKeystoreJavaKeystoreFromData(data)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	err = keycloakClient.UpdateRealmKeystoreJavaKeystore(ctx, realmKey)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	err = setRealmKeystoreJavaKeystoreData(data, realmKey)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	return nil
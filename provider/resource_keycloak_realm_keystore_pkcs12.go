@@ -0,0 +1,258 @@
+package provider
+
+import (
+	"context"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/keycloak/terraform-provider-keycloak/keycloak"
+	"software.sslmate.com/src/go-pkcs12"
+)
+
+var (
+	keycloakRealmKeystorePkcs12Algorithm  = []string{"RS256", "RS384", "RS512", "PS256", "PS384", "PS512", "RSA-OAEP", "RSA1_5", "RSA-OAEP-256"}
+	keycloakRealmKeystorePkcs12ProviderId = []string{"rsa", "rsa-enc"}
+
+	// keycloakRealmKeystorePkcs12ProviderAlgorithms maps each key provider to the algorithms it accepts, signature
+	// algorithms for rsa and encryption algorithms for rsa-enc
+	keycloakRealmKeystorePkcs12ProviderAlgorithms = map[string][]string{
+		"rsa":     {"RS256", "RS384", "RS512", "PS256", "PS384", "PS512"},
+		"rsa-enc": {"RSA-OAEP", "RSA1_5", "RSA-OAEP-256"},
+	}
+)
+
+// resourceKeycloakRealmKeystorePkcs12 imports the key pair of a PKCS#12 keystore, such as one exported from a hardware
+// security module or a certificate authority. Keycloak can't read PKCS#12 content over the admin API, so the keystore
+// is decoded by the provider and its key and certificate are sent as PEM to an rsa or rsa-enc key provider.
+func resourceKeycloakRealmKeystorePkcs12() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceKeycloakRealmKeystorePkcs12Create,
+		ReadContext:   resourceKeycloakRealmKeystorePkcs12Read,
+		UpdateContext: resourceKeycloakRealmKeystorePkcs12Update,
+		DeleteContext: resourceKeycloakRealmKeystorePkcs12Delete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceKeycloakRealmKeystoreGenericImport,
+		},
+		CustomizeDiff: resourceKeycloakRealmKeystorePkcs12CustomizeDiff,
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Display name of provider when linked in admin console.",
+			},
+			"realm_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"active": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Set if the keys can be used for signing",
+			},
+			"enabled": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Set if the keys are enabled",
+			},
+			"priority": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Default:     0,
+				Description: "Priority for the provider",
+			},
+			"algorithm": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice(keycloakRealmKeystorePkcs12Algorithm, false),
+				Default:      "RS256",
+				Description:  "Intended algorithm for the key",
+			},
+			"keystore": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Sensitive:    true,
+				ValidateFunc: validation.StringIsBase64,
+				Description:  "Base64 encoded content of the PKCS#12 keystore",
+			},
+			"keystore_password": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+				Description: "Password of the PKCS#12 keystore",
+			},
+			"provider_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "rsa",
+				ValidateFunc: validation.StringInSlice(keycloakRealmKeystorePkcs12ProviderId, false),
+				Description:  "Key provider the keys are imported into, rsa for signing keys or rsa-enc for encryption keys",
+				ForceNew:     true,
+			},
+			"certificate": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "X509 Certificate of the keystore encoded in PEM format",
+			},
+		},
+	}
+}
+
+// decodePkcs12Keystore returns the PEM encoded private key and certificate of a base64 encoded PKCS#12 keystore
+func decodePkcs12Keystore(keystore, password string) (string, string, error) {
+	pfxData, err := base64.StdEncoding.DecodeString(keystore)
+	if err != nil {
+		return "", "", fmt.Errorf("keystore is not base64 encoded: %s", err)
+	}
+
+	// DecodeChain also accepts keystores that contain the certificates of the issuing authorities, only the key and its
+	// own certificate are imported
+	privateKey, certificate, _, err := pkcs12.DecodeChain(pfxData, password)
+	if err != nil {
+		return "", "", fmt.Errorf("unable to decode PKCS#12 keystore: %s", err)
+	}
+
+	rsaPrivateKey, ok := privateKey.(*rsa.PrivateKey)
+	if !ok {
+		return "", "", fmt.Errorf("PKCS#12 keystore contains a %T, only RSA keys are supported", privateKey)
+	}
+
+	privateKeyPem := pem.EncodeToMemory(&pem.Block{
+		Type:  "RSA PRIVATE KEY",
+		Bytes: x509.MarshalPKCS1PrivateKey(rsaPrivateKey),
+	})
+
+	certificatePem := pem.EncodeToMemory(&pem.Block{
+		Type:  "CERTIFICATE",
+		Bytes: certificate.Raw,
+	})
+
+	return string(privateKeyPem), string(certificatePem), nil
+}
+
+// validateRealmKeystorePkcs12Algorithm rejects algorithms that keycloak doesn't accept for the key provider, such as an
+// encryption algorithm for the rsa provider, which only signs
+func validateRealmKeystorePkcs12Algorithm(providerId, algorithm string) error {
+	algorithms := keycloakRealmKeystorePkcs12ProviderAlgorithms[providerId]
+	for _, a := range algorithms {
+		if a == algorithm {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("algorithm %s can't be used with provider_id %s, expected one of %v", algorithm, providerId, algorithms)
+}
+
+func resourceKeycloakRealmKeystorePkcs12CustomizeDiff(_ context.Context, diff *schema.ResourceDiff, _ interface{}) error {
+	if !diff.NewValueKnown("provider_id") || !diff.NewValueKnown("algorithm") {
+		return nil
+	}
+
+	return validateRealmKeystorePkcs12Algorithm(diff.Get("provider_id").(string), diff.Get("algorithm").(string))
+}
+
+func getRealmKeystorePkcs12FromData(data *schema.ResourceData) (*keycloak.RealmKeystoreRsa, error) {
+	privateKey, certificate, err := decodePkcs12Keystore(data.Get("keystore").(string), data.Get("keystore_password").(string))
+	if err != nil {
+		return nil, err
+	}
+
+	keystore := &keycloak.RealmKeystoreRsa{
+		Id:      data.Id(),
+		Name:    data.Get("name").(string),
+		RealmId: data.Get("realm_id").(string),
+
+		Active:      data.Get("active").(bool),
+		Enabled:     data.Get("enabled").(bool),
+		Priority:    data.Get("priority").(int),
+		Algorithm:   data.Get("algorithm").(string),
+		PrivateKey:  privateKey,
+		Certificate: certificate,
+		ProviderId:  data.Get("provider_id").(string),
+	}
+
+	return keystore, nil
+}
+
+// the keystore and its password aren't known to keycloak, so they are kept as configured
+func setRealmKeystorePkcs12Data(data *schema.ResourceData, realmKey *keycloak.RealmKeystoreRsa) {
+	data.SetId(realmKey.Id)
+
+	data.Set("name", realmKey.Name)
+	data.Set("realm_id", realmKey.RealmId)
+
+	data.Set("active", realmKey.Active)
+	data.Set("enabled", realmKey.Enabled)
+	data.Set("priority", realmKey.Priority)
+	data.Set("algorithm", realmKey.Algorithm)
+	data.Set("provider_id", realmKey.ProviderId)
+	data.Set("certificate", realmKey.Certificate)
+}
+
+func resourceKeycloakRealmKeystorePkcs12Create(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	realmKey, err := getRealmKeystorePkcs12FromData(data)
+	if err != nil {
+		return diagnosticsFromError(err, data)
+	}
+
+	err = keycloakClient.NewRealmKeystoreRsa(ctx, realmKey)
+	if err != nil {
+		return diagnosticsFromError(err, data)
+	}
+
+	setRealmKeystorePkcs12Data(data, realmKey)
+
+	return resourceKeycloakRealmKeystorePkcs12Read(ctx, data, meta)
+}
+
+func resourceKeycloakRealmKeystorePkcs12Read(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	realmId := data.Get("realm_id").(string)
+	id := data.Id()
+
+	realmKey, err := keycloakClient.GetRealmKeystoreRsa(ctx, realmId, id)
+	if err != nil {
+		return handleNotFoundError(ctx, err, data)
+	}
+
+	setRealmKeystorePkcs12Data(data, realmKey)
+
+	return nil
+}
+
+func resourceKeycloakRealmKeystorePkcs12Update(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	realmKey, err := getRealmKeystorePkcs12FromData(data)
+	if err != nil {
+		return diagnosticsFromError(err, data)
+	}
+
+	err = keycloakClient.UpdateRealmKeystoreRsa(ctx, realmKey)
+	if err != nil {
+		return diagnosticsFromError(err, data)
+	}
+
+	// keycloak stores the certificate in its own encoding
+	return resourceKeycloakRealmKeystorePkcs12Read(ctx, data, meta)
+}
+
+func resourceKeycloakRealmKeystorePkcs12Delete(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	realmId := data.Get("realm_id").(string)
+	id := data.Id()
+
+	return diag.FromErr(keycloakClient.DeleteRealmKeystoreRsa(ctx, realmId, id))
+}
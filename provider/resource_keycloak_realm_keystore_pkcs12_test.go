@@ -0,0 +1,204 @@
+package provider
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/keycloak/terraform-provider-keycloak/keycloak"
+	"regexp"
+	"testing"
+)
+
+func TestAccKeycloakRealmKeystorePkcs12_basic(t *testing.T) {
+	t.Parallel()
+
+	pkcs12Name := acctest.RandomWithPrefix("tf-acc")
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		PreCheck:          func() { testAccPreCheck(t) },
+		CheckDestroy:      testAccCheckRealmKeystorePkcs12Destroy(),
+		Steps: []resource.TestStep{
+			{
+				Config: testKeycloakRealmKeystorePkcs12_basic(pkcs12Name, "rsa", "RS384"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRealmKeystorePkcs12Exists("keycloak_realm_keystore_pkcs12.realm_pkcs12"),
+					resource.TestCheckResourceAttrSet("keycloak_realm_keystore_pkcs12.realm_pkcs12", "certificate"),
+				),
+			},
+			// the keystore can't be verified on import because keycloak only knows its private key and certificate
+			{
+				ResourceName:      "keycloak_realm_keystore_pkcs12.realm_pkcs12",
+				ImportState:       true,
+				ImportStateIdFunc: getRealmKeystoreGenericImportId("keycloak_realm_keystore_pkcs12.realm_pkcs12"),
+			},
+		},
+	})
+}
+
+func TestAccKeycloakRealmKeystorePkcs12_encryptionKey(t *testing.T) {
+	t.Parallel()
+
+	pkcs12Name := acctest.RandomWithPrefix("tf-acc")
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		PreCheck:          func() { testAccPreCheck(t) },
+		CheckDestroy:      testAccCheckRealmKeystorePkcs12Destroy(),
+		Steps: []resource.TestStep{
+			{
+				Config: testKeycloakRealmKeystorePkcs12_basic(pkcs12Name, "rsa-enc", "RSA-OAEP"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRealmKeystorePkcs12Exists("keycloak_realm_keystore_pkcs12.realm_pkcs12"),
+					resource.TestCheckResourceAttr("keycloak_realm_keystore_pkcs12.realm_pkcs12", "provider_id", "rsa-enc"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccKeycloakRealmKeystorePkcs12_createAfterManualDestroy(t *testing.T) {
+	t.Parallel()
+
+	var keystore = &keycloak.RealmKeystoreRsa{}
+
+	pkcs12Name := acctest.RandomWithPrefix("tf-acc")
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		PreCheck:          func() { testAccPreCheck(t) },
+		CheckDestroy:      testAccCheckRealmKeystorePkcs12Destroy(),
+		Steps: []resource.TestStep{
+			{
+				Config: testKeycloakRealmKeystorePkcs12_basic(pkcs12Name, "rsa", "RS256"),
+				Check:  testAccCheckRealmKeystorePkcs12Fetch("keycloak_realm_keystore_pkcs12.realm_pkcs12", keystore),
+			},
+			{
+				PreConfig: func() {
+					err := keycloakClient.DeleteRealmKeystoreRsa(testCtx, keystore.RealmId, keystore.Id)
+					if err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config: testKeycloakRealmKeystorePkcs12_basic(pkcs12Name, "rsa", "RS256"),
+				Check:  testAccCheckRealmKeystorePkcs12Fetch("keycloak_realm_keystore_pkcs12.realm_pkcs12", keystore),
+			},
+		},
+	})
+}
+
+func TestAccKeycloakRealmKeystorePkcs12_wrongPassword(t *testing.T) {
+	t.Parallel()
+
+	pkcs12Name := acctest.RandomWithPrefix("tf-acc")
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		PreCheck:          func() { testAccPreCheck(t) },
+		CheckDestroy:      testAccCheckRealmKeystorePkcs12Destroy(),
+		Steps: []resource.TestStep{
+			{
+				Config:      testKeycloakRealmKeystorePkcs12_withPassword(pkcs12Name, acctest.RandString(10)),
+				ExpectError: regexp.MustCompile("unable to decode PKCS#12 keystore"),
+			},
+		},
+	})
+}
+
+func testAccCheckRealmKeystorePkcs12Exists(resourceName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		_, err := getKeycloakRealmKeystorePkcs12FromState(s, resourceName)
+		if err != nil {
+			return err
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckRealmKeystorePkcs12Fetch(resourceName string, keystore *keycloak.RealmKeystoreRsa) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		fetchedKeystore, err := getKeycloakRealmKeystorePkcs12FromState(s, resourceName)
+		if err != nil {
+			return err
+		}
+
+		keystore.Id = fetchedKeystore.Id
+		keystore.RealmId = fetchedKeystore.RealmId
+
+		return nil
+	}
+}
+
+func testAccCheckRealmKeystorePkcs12Destroy() resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		for _, rs := range s.RootModule().Resources {
+			if rs.Type != "keycloak_realm_keystore_pkcs12" {
+				continue
+			}
+
+			id := rs.Primary.ID
+			realm := rs.Primary.Attributes["realm_id"]
+
+			keystore, _ := keycloakClient.GetRealmKeystoreRsa(testCtx, realm, id)
+			if keystore != nil {
+				return fmt.Errorf("pkcs12 keystore with id %s still exists", id)
+			}
+		}
+
+		return nil
+	}
+}
+
+func getKeycloakRealmKeystorePkcs12FromState(s *terraform.State, resourceName string) (*keycloak.RealmKeystoreRsa, error) {
+	rs, ok := s.RootModule().Resources[resourceName]
+	if !ok {
+		return nil, fmt.Errorf("resource not found: %s", resourceName)
+	}
+
+	id := rs.Primary.ID
+	realm := rs.Primary.Attributes["realm_id"]
+
+	realmKeystore, err := keycloakClient.GetRealmKeystoreRsa(testCtx, realm, id)
+	if err != nil {
+		return nil, fmt.Errorf("error getting pkcs12 keystore with id %s: %s", id, err)
+	}
+
+	return realmKeystore, nil
+}
+
+func testKeycloakRealmKeystorePkcs12_basic(pkcs12Name, providerId, algorithm string) string {
+	return fmt.Sprintf(`
+data "keycloak_realm" "realm" {
+	realm = "%s"
+}
+
+resource "keycloak_realm_keystore_pkcs12" "realm_pkcs12" {
+	name      = "%s"
+	realm_id  = data.keycloak_realm.realm.id
+
+    priority          = 100
+    provider_id       = "%s"
+    algorithm         = "%s"
+    keystore          = "%s"
+    keystore_password = "%s"
+}
+	`, testAccRealmUserFederation.Realm, pkcs12Name, providerId, algorithm, testPkcs12Keystore, testPkcs12KeystorePassword)
+}
+
+func testKeycloakRealmKeystorePkcs12_withPassword(pkcs12Name, password string) string {
+	return fmt.Sprintf(`
+data "keycloak_realm" "realm" {
+	realm = "%s"
+}
+
+resource "keycloak_realm_keystore_pkcs12" "realm_pkcs12" {
+	name      = "%s"
+	realm_id  = data.keycloak_realm.realm.id
+
+    keystore          = "%s"
+    keystore_password = "%s"
+}
+	`, testAccRealmUserFederation.Realm, pkcs12Name, testPkcs12Keystore, password)
+}
@@ -0,0 +1,146 @@
+package provider
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"strings"
+	"testing"
+)
+
+// a keystore with a 2048 bit RSA key and a self-signed certificate, created with the defaults of OpenSSL 3, which encrypt
+// it with PBES2 and AES-256-CBC:
+// openssl pkcs12 -export -passout pass:changeit
+const testPkcs12Keystore = "" +
+	"MIIJ/wIBAzCCCbUGCSqGSIb3DQEHAaCCCaYEggmiMIIJnjCCBBIGCSqGSIb3DQEHBqCCBAMwggP/AgEAMIID+AYJKoZIhvcNAQcB" +
+	"MFcGCSqGSIb3DQEFDTBKMCkGCSqGSIb3DQEFDDAcBAiaxAWc+GewuwICCAAwDAYIKoZIhvcNAgkFADAdBglghkgBZQMEASoEELRz" +
+	"Hr99iFHcj8LaSNCxnjmAggOQ6YQxIEEiGYVTVT6IaIs/FWhavzb9aQWDi7G9sCuQNcIvObE7rMswxIsJ1cfdtlcVx1HTzrzqxK1v" +
+	"DnTUedrbzcSxnd38D0DqMAkttxhrX9LyPQTvZFanK1PRyiE/KdGmyLCtco/PV+2lPk3fl5CBF4B81j6v5cK0usMXz8arSKRdHxXj" +
+	"lo5Yvrm2mMcUR38eXRul0e2zrjX+tZtpqozRSUCcTOwkc++5BdFgd2yYJPCshxqQBL4PAICkmRPJ/egQJr3DehbT6i92NXoP8b87" +
+	"oEqEFgffPBYu1CSG3MySsd6Z4LpatkFXvTVTf3USM491W5DHvV1R1OqpyEGQ7aKOrt6H8VCofEDAy9/SNgunj33VOBmQw2k5xJIs" +
+	"njC2v5fCQYUb1POCOxrWG60ubb+ayxiUDu61PtqjQJ5pAgI30wmTjo+o2TB8XKJvaNS1MVx9LAoWUrhIAcWOwdFDUog4eyaB2Fly" +
+	"RbymIWpbyBw1qYfD1BSbs4QnsV+oaehAMY2NDC65IXD45xQwclF9lVJkscuGuznUWRl+UVOmRlW1NYSPKCROMVdr9lRNt1x7RoBp" +
+	"/3t6hdsxr1Z0j4IF8xZk3nnZ1l6FWodh0kypkSjIGFAz6Ed1I0U5ELHliDR9DTroQGBbPEWoVVKpq66J/rqzLBDONeW7iUsOMuml" +
+	"LhXZp5jvzOxG1cwaE8LlPXVO9XM7dfhw5kPxdd/NYBOIs/cBlcl0cQcHSh+dT0e+raNQBzz5zwMA4VholTkJ8ITUV6rsxwyhUOb+" +
+	"ANzwfcNVw94loXmxQXkP/fRohTr3/r+mBnhZClSpoYxI+EOO/DEt7WhWis/n8rcVVmJtmayS88RB9Ydt2J827CBJbqz5nQqjVtAe" +
+	"dnmmheKGdxB0yypAgX/Z87rBm+ZAvDw5/AwTdkyRyqsJJsTRHUdH0IHZ1QgG4vK7qLUGHOJ5HmDqsiA76jaRnvexna+zHj1/WVvY" +
+	"853g2WC5JP6gzl2xrwHKHKyyUhh27jc4OzEiW3CCjIu+jINm6xxg4MRv7WTXpfhM7BhPfxnQUjS9C5IMzcvzIhIc5JoBSHFauVTX" +
+	"cU+aJ0M4mEMQ7nSIXI7MyX1Nl+YcHfgZsR/KlIRIi5UOrM3x2N2iVYqyRJQlv7I3GUApDTvAi63bEazx9Vjj/IIk7F3PSJPcRr+m" +
+	"Fwceas0m2/viSSG5kI0tJ6a+4oOEj81MUZKN181WMIIFhAYJKoZIhvcNAQcBoIIFdQSCBXEwggVtMIIFaQYLKoZIhvcNAQwKAQKg" +
+	"ggUxMIIFLTBXBgkqhkiG9w0BBQ0wSjApBgkqhkiG9w0BBQwwHAQIzlHdXNJd3AICAggAMAwGCCqGSIb3DQIJBQAwHQYJYIZIAWUD" +
+	"BAEqBBBi41lHDtMH0p7x2REHGSxuBIIE0G2iEBULUoJxfZ6nkdP6LamVzlMxFQ1JjM4z2/YQW00+cPGHrBaIMVUMuobMxQOcjKYr" +
+	"AIpnZjn2e2c3fnawwlP+bXS0xez2kHc2iyGnrclnSVQHkgN1y7s4l1im2ZPDA8AErF1Egfmldic76gsjFE35wz+X/SwZ6194T3KQ" +
+	"UO/JwQ3uVaVw+USEuGNLnJCpOHhTHoaTiIHCbhKL/dt3pzJqm+nSzxbakml/qKRp6VXDM/XsH49GICfEygKzksnfx0k+H4fvLjP4" +
+	"yXWv7YhobSC40sFK9v+sTxa5yrQZHMuXGAICNzY0cylG2zftb72NnezoLJCcV6g2xvnLeTor+PnZ3jtL9JtoKgmgYxOLVD0sfW5L" +
+	"5YGULPPp45SxAhyn3yFjS8WHh5PyyT4Q1Xckul5bBsN44Fb/bR9rkmmRlksUROxLekPzbcO6Mcymz/ZVO/NGTUmkYkKlIDODxxZi" +
+	"AgizsQsVaFWdI1V9RlhFXHklx4BEo3USi1AlOCQl0oPnyUywzjgkUhb10LRDfTJm6l86eGNIyRT8QB/EfKMNmwwp1xzJVq/oS/wK" +
+	"JtMZDEBvyF1+CmZuz1l/NNzfrXOH8hRnTVamiZKAJxG4TobkFAQmXuLUfkAyTvRj3p3mWGZin8K17xt5ak3j448usXvUirvPxQfq" +
+	"zn5NRocKm7WufXAfB+00srq6Nb2qOVh3D/PuBBZSDUezpwNiyLmfHhzJscIc8zTVr7e90JhJJcR4VoHlPJaSOCWOUjLTG12FtPkr" +
+	"g2d6IqBPV/lylwwdjHsdT9ndFyLInS/vh75XH9SA9jKSRMNfoDUmfpyxYnmRLGMYET0HPjcPsxhLu07lwGelq2V3+VQsQhiRd2XK" +
+	"kk5LNsW7F2tR1dj0UMnJ70tiEvFRA9lYB8fLxI4ipNJziuTNbzqAOQwVP7i9oFSriKmkvJDEawkf03EvbHmFphibMokm9tBv0PqV" +
+	"spJdpiLobK81Vz6coEnWHKsBShK9Szb2/PfAFnQo9//NRsuw+EcrQf1Du70djbozDzmBInWh4X3CEcoNEBv2iwoiW2CYiokCJmDD" +
+	"mTy1MDqDJpuVohtlG72mTP20rqp6yXOJyCLFNUUz/n3U9jvTfrCzvCRXAwoaXPaHVSveal6F8EwcFkf47pCTsUeDFMrA8aqg7d24" +
+	"X/1pMKVbHoSoccQUG0SmkfhkmFkmKIgC5IuaRQdW3I7vinI6UJGG5SB2zJNhDQ+moVCZ/76OHkfek8yKhd/et0dKpKqOdJSf7sbU" +
+	"Lk8G2/CS/5u5HzRTp32d1ARCObKYsMcQlH9l5xZFDsLyk6Cnql49HuDIf9TacTOAPxizSQcRdLJ6urVensA4ht7PGoK5zWtWlKDe" +
+	"G8NH6m5qWQpsWNYM+ZVzKqFtLM3OqMF0I/DH4+xKyCuVhJF4F8zcJDkQVo1458sz+IZzQQEkqeoVI+b/1awehOLhyieRs/xQdEju" +
+	"lqtgk1OtnKcPyBaJ8oOiSGYW1LKVz8ZY6sUKZgnD5a9MzUdzdmtpVygN1hcEQ8678pEb67AsgdTJ8UziPqMOQyA+xcXDQ5u/JtCs" +
+	"EtIgUiVAoRfR7E9MiNNAYa6Unxx9yLoIqsL26FVMg+u0vmk3I9SOh/ieVP4pZaaZR34BFQxCoXm9MSUwIwYJKoZIhvcNAQkVMRYE" +
+	"FOlPFpTEoGUUGm4ZV4AsJeMGxcT6MEEwMTANBglghkgBZQMEAgEFAAQghnLouBklRHPUY3sFbuX+2E4Z/HsajmCIBBEQs2VjkQwE" +
+	"CACadiWMFZSEAgIIAA=="
+
+// the same kind of keystore, encrypted with the legacy algorithms of older versions of OpenSSL and keytool:
+// openssl pkcs12 -export -keypbe PBE-SHA1-3DES -certpbe PBE-SHA1-3DES -macalg sha1 -passout pass:changeit
+const testPkcs12KeystoreLegacy = "" +
+	"MIIJcQIBAzCCCTcGCSqGSIb3DQEHAaCCCSgEggkkMIIJIDCCA9cGCSqGSIb3DQEHBqCCA8gwggPEAgEAMIIDvQYJKoZIhvcNAQcB" +
+	"MBwGCiqGSIb3DQEMAQMwDgQIGXkrirt6gk4CAggAgIIDkB20gtngi9pdUfBIKaBS2K3Jizwm9JqGx0Ah6JEfPjzsANqnp5I5VgkT" +
+	"F5jm4lrrXDKpg9ZwlipYe5boeWoRL+DassgadkcjtsKa+q8UNxrlxRLBFvq6tM6V2sSl/FH9aEjKtqqDeolT1Qp6/bZ+Xo7Rtzzy" +
+	"PlnE5+dEaQfpQpEb5sV5kpw6SAiORRbelAE87TGtiFX8Mx+DLU6qnQjINESqgzbwiNLqX8lKRaJHLnW57kR4PoK8/nMOWZlXnix/" +
+	"Q976/983jIDnTedDzO8IzchjXltIKCKaRsKN21+eLnfW4rQ2UbpdTPggfnap8MtunCfluGx7RKoGjB3KJ79it5ciwBjbeo+Y4pkt" +
+	"PrFVR8bgQPsZNQPjShNrqgTE/90zQu93F64ArHifZ3/88aHndXY0tXhwhcT+TjTTM8NCQFrZ+3uFv0xuAGVlH8PraVIIZyJj0djE" +
+	"qMP+c0Xt9AN+aZC2mvUDvyllKcpixoz02JWDiScBuS0qLHmGEDDSFIr3GrdVZ4vpNIFaT8bMJAdLSLLPY85ZjVsKQfmwUfjb30fi" +
+	"PJ0iYY2GAEOYCxL+2EliBXEbw9AyMfLnboeM4hD9MxBEaSMqkg989W/LNB4pfJvfKu25DZ4CWvh9peZEGK6le/By0klv8Rg4kHP5" +
+	"IOXf+tBQOBUs7W1x0LdHQX5iiFokeljTtpuInBFkYB5WMavP4HvTapFjk7KQPhOidOYVtD5eNRVBavKau6yUC/4MiqPp3R7patjW" +
+	"oGOMKn81D2QBPLMW4V+I3J7kX/3V21uKGt4p/mvZ4R5WCdR/Dd1lBasD4XVRJSViPMSP8pPGsMqoYLluN1Zxd2F84p1IuTbBPvJf" +
+	"Xyl874j67npmxNeT/lovLFa7Vp09Qe7B7LHOhmsaylhPccJMPF9bGi0KpJFtrI439FJ5c8yErELRbHyfju0293mxmZZ0+SPyCZu6" +
+	"FcOR6482ZIAkmx5qxGldYYHKtjCa3uKZ1WbQU0rKpqgnX/dfdq5tvI2VNKDHUxVlFBPztkcI2vCGsU8r9yg0wrFQrz6rG4JN2rb9" +
+	"/71ApmedDM+solBlnCE12EWRKSLycAeukzmaW9B/ggcsZR/3/K31agwisRu/8a3ly8PmW7/aGoFtG5wRQAXyaOLyXmVLQZVl4ME6" +
+	"WcDwksFuygtLzTZn0T0x2+xtHdKFiecs3q+nxPgPR0xfuvdypbyHfOslB/xxNzCCBUEGCSqGSIb3DQEHAaCCBTIEggUuMIIFKjCC" +
+	"BSYGCyqGSIb3DQEMCgECoIIE7jCCBOowHAYKKoZIhvcNAQwBAzAOBAhiR+5kW5MDlAICCAAEggTIdyvCsxKA0YcUSf7yR7Nrr9gh" +
+	"LqBbysxgwGMPuHU/IRRKvyG2icnGHAO6avNZW28hQjZZvrAh1F2ew4UJTWPZ+IG5+z0kAi6zsReaeyETIG9YwHegCNDMNPMrLjAf" +
+	"+CzrlDo91wF7PIHi7AdC5V2NtIOXhVPmWZVMrk4AFR6EBgWl0ctiAxVI443RvXrlD58aP7U0pKjMOUYYZo6Ze0VyyzhJJd67qYDC" +
+	"aZMiIQ9/HF9348G6MEyBGvLpy8X4MnItUZwNH0dpabean8NcLzsAjcAQ8Yi+BlQ1ByGCfgUom/6FODjX+JcHO4uPv+5PKejYTmo2" +
+	"6y5JLMTJpHNdLmq0N+Rs9FcT0G0zhQ9RbTyRRQNKZdpxcQUIn+rEcQBgXxrCreFYi2nDa1sws6BGw1HytWVj6NpSB5eQWXnoPILD" +
+	"1YNpZLe6aDCgmOJ4ltDG17dUEI2+Lo+9tJvxeWZNRdrCz23r187W+hidI7WoDHuwn8AFUmYpX6IHhbuDCso/LR0a7A57x/O116cb" +
+	"T/4bKLzlzWZpAymCe5VRkS3nRNKYJjM3Spy6bym11mHiohCHkBuPElJpSNMHh+LvIdPERU7vWqqLiOYYRqwKyyGeuJurXt6Hkw6D" +
+	"vb/veQkSJ89orkzpo+Lkm0EtmGj9Y2yZcS2ONA9KlLLhe5whZ476Zg38of/mC2nsVEwXGgBG5vaF7tCd5ZLixhgQBiWV4huZykIe" +
+	"EKv+ylRKj+Xkpu1FVRB/xhKAWQBA7S4q2SrB09PGEFXUHRYnJ42rQ+98DmuZd6z/XWK5O3rmi63KNxSgWpKTHkQAZlCoQvguI6f/" +
+	"+fBVl3Sz1nlvB6FGNgtwSjXbxb+lOpwX+nzwM2GM1CYR/KAXGVw+rftHRuhVPl2mB3C5Qhhj/EhyW3pfARBo03b9ay+qOp9yhnRG" +
+	"sFB9ZUHaM9Ew7B8FOBsMZntbbKfZKSLYywu/R+/ZjjBthVo0DlYcSkq71b0yeS5JEfPgCooqZ1hd1T12UL94X2g+KdIx27FIJnul" +
+	"wAtnDJeKhbjnRjgbvWcyNEQO+DKSyPjPFhn87ltcRVG/YBL3qgFjsKoGBonSwLLUWBcCy/qO8NbF3o5V8qm7MRS0csB4ccwp/D4o" +
+	"ED1e+TVk5LW0w9M1ItcOJ5As9ptpef2Xy3M6v07hrUo2hVt1iSfGEM6ctqzjPf5WRGKqaJorisnpkjtI4sCw1jRNmsSK/yg212iT" +
+	"Q3QbnUFGyhkxbqTUU4Jd3vrURILI1m6vshG6aTbhEROZqLhPJPNJ1reKfjjhaTWxTRC2bdyWj8JvbSgFR9XYlFcGtLz8IyeQ8IAU" +
+	"nvseE1hOQB5F4ohy2OQt0D190nktIaxWcg85kZAj/Pa8W8NC+DouUIBrWt0obKY4Y/ab2CbtS1olNbUK9fAQeM2pf2MSOYs+N3Eq" +
+	"Slc/QVzlV6Ugsr8hGpT+bPRLKwjBRk9nYA6Thy2tysVC1kl78MaCPE0hB+kV+Kx8kvonXqVOk/34DnIzU7vpY3mJhdkK8v0lFdoX" +
+	"7dcbet7hiFHxBmwwlBt6ylceB460BdVZ4RyCaTHjvCsQNnZeZ165JD5sxYbHKWvcxphpiNke9HQQB7QZPZnPiIxLTys2J4bGT7V4" +
+	"9bWvah+RMSUwIwYJKoZIhvcNAQkVMRYEFA9tdZqZANJwTD12HrdupcgEWTMhMDEwITAJBgUrDgMCGgUABBSiUBoyCqubWcpWC6LX" +
+	"4+kW7n991gQIoEKt2zS4IDYCAggA"
+
+const testPkcs12KeystorePassword = "changeit"
+
+func TestDecodePkcs12Keystore(t *testing.T) {
+	t.Parallel()
+
+	for name, keystore := range map[string]string{"aes": testPkcs12Keystore, "legacy": testPkcs12KeystoreLegacy} {
+		privateKey, certificate, err := decodePkcs12Keystore(keystore, testPkcs12KeystorePassword)
+		if err != nil {
+			t.Fatalf("expected the %s keystore to be decoded, got %s", name, err)
+		}
+
+		privateKeyBlock, _ := pem.Decode([]byte(privateKey))
+		if privateKeyBlock == nil || privateKeyBlock.Type != "RSA PRIVATE KEY" {
+			t.Fatalf("expected a PEM encoded RSA private key in the %s keystore, got %s", name, privateKey)
+		}
+		if _, err := x509.ParsePKCS1PrivateKey(privateKeyBlock.Bytes); err != nil {
+			t.Errorf("expected a valid RSA private key in the %s keystore, got %s", name, err)
+		}
+
+		certificateBlock, _ := pem.Decode([]byte(certificate))
+		if certificateBlock == nil || certificateBlock.Type != "CERTIFICATE" {
+			t.Fatalf("expected a PEM encoded certificate in the %s keystore, got %s", name, certificate)
+		}
+		parsedCertificate, err := x509.ParseCertificate(certificateBlock.Bytes)
+		if err != nil {
+			t.Fatalf("expected a valid certificate in the %s keystore, got %s", name, err)
+		}
+		if parsedCertificate.Subject.CommonName != "terraform-provider-keycloak" {
+			t.Errorf("expected the certificate of the %s keystore, got %s", name, parsedCertificate.Subject)
+		}
+	}
+}
+
+func TestDecodePkcs12KeystoreErrors(t *testing.T) {
+	t.Parallel()
+
+	if _, _, err := decodePkcs12Keystore("not base64!", ""); err == nil || !strings.Contains(err.Error(), "base64") {
+		t.Errorf("expected an error for content that isn't base64, got %v", err)
+	}
+
+	if _, _, err := decodePkcs12Keystore(testPkcs12Keystore, "wrong"); err == nil || !strings.Contains(err.Error(), "unable to decode") {
+		t.Errorf("expected an error for a wrong password, got %v", err)
+	}
+}
+
+func TestValidateRealmKeystorePkcs12Algorithm(t *testing.T) {
+	t.Parallel()
+
+	for _, valid := range [][2]string{{"rsa", "RS256"}, {"rsa", "PS512"}, {"rsa-enc", "RSA-OAEP"}, {"rsa-enc", "RSA-OAEP-256"}} {
+		if err := validateRealmKeystorePkcs12Algorithm(valid[0], valid[1]); err != nil {
+			t.Errorf("expected %s to be accepted for %s, got %s", valid[1], valid[0], err)
+		}
+	}
+
+	for _, invalid := range [][2]string{{"rsa", "RSA-OAEP"}, {"rsa", "RSA1_5"}, {"rsa-enc", "RS256"}, {"rsa-enc", "PS256"}} {
+		if err := validateRealmKeystorePkcs12Algorithm(invalid[0], invalid[1]); err == nil || !strings.Contains(err.Error(), "can't be used with provider_id") {
+			t.Errorf("expected %s to be rejected for %s, got %v", invalid[1], invalid[0], err)
+		}
+	}
+}
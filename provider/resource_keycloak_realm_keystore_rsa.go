@@ -120,12 +120,12 @@ func resourceKeycloakRealmKeystoreRsaCreate(ctx context.Context, data *schema.Re
 
 	err := keycloakClient.NewRealmKeystoreRsa(ctx, realmKey)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	setRealmKeystoreRsaData(data, realmKey)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	return resourceKeycloakRealmKeystoreRsaRead(ctx, data, meta)
@@ -144,7 +144,7 @@ func resourceKeycloakRealmKeystoreRsaRead(ctx context.Context, data *schema.Reso
 
 	setRealmKeystoreRsaData(data, realmKey)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	return nil
@@ -157,12 +157,12 @@ func resourceKeycloakRealmKeystoreRsaUpdate(ctx context.Context, data *schema.Re
 
 	err := keycloakClient.UpdateRealmKeystoreRsa(ctx, realmKey)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	setRealmKeystoreRsaData(data, realmKey)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	return nil
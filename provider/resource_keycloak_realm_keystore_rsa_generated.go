@@ -105,17 +105,17 @@ func resourceKeycloakRealmKeystoreRsaGeneratedCreate(ctx context.Context, data *
 
 	realmKey, err := getRealmKeystoreRsaGeneratedFromData(data)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	err = keycloakClient.NewRealmKeystoreRsaGenerated(ctx, realmKey)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	err = setRealmKeystoreRsaGeneratedData(data, realmKey)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	return resourceKeycloakRealmKeystoreRsaGeneratedRead(ctx, data, meta)
@@ -134,7 +134,7 @@ func resourceKeycloakRealmKeystoreRsaGeneratedRead(ctx context.Context, data *sc
 
 	err = setRealmKeystoreRsaGeneratedData(data, realmKey)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	return nil
@@ -145,17 +145,17 @@ func resourceKeycloakRealmKeystoreRsaGeneratedUpdate(ctx context.Context, data *
 
 	realmKey, err := getRealmKeystoreRsaGeneratedFromData(data)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	err = keycloakClient.UpdateRealmKeystoreRsaGenerated(ctx, realmKey)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	err = setRealmKeystoreRsaGeneratedData(data, realmKey)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	return nil
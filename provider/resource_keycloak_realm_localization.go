@@ -0,0 +1,160 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/keycloak/terraform-provider-keycloak/keycloak"
+)
+
+func resourceKeycloakRealmLocalization() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceKeycloakRealmLocalizationCreate,
+		ReadContext:   resourceKeycloakRealmLocalizationRead,
+		DeleteContext: resourceKeycloakRealmLocalizationDelete,
+		UpdateContext: resourceKeycloakRealmLocalizationUpdate,
+		// This resource can be imported using {{realm}}/{{locale}}
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceKeycloakRealmLocalizationImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"realm_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"locale": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"texts": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The realm level message overrides for this locale, keyed by message key.",
+			},
+		},
+	}
+}
+
+func getRealmLocalizationTextsFromData(texts map[string]interface{}) map[string]string {
+	result := make(map[string]string, len(texts))
+	for key, value := range texts {
+		result[key] = value.(string)
+	}
+
+	return result
+}
+
+func resourceKeycloakRealmLocalizationCreate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	realmId := data.Get("realm_id").(string)
+	locale := data.Get("locale").(string)
+	texts := getRealmLocalizationTextsFromData(data.Get("texts").(map[string]interface{}))
+
+	// the locale is owned by this resource, so any texts which already exist need to be removed first
+	existingTexts, err := keycloakClient.GetRealmLocalizationTexts(ctx, realmId, locale)
+	if err != nil {
+		return diagnosticsFromError(err, data)
+	}
+
+	for key := range existingTexts {
+		if _, ok := texts[key]; !ok {
+			err = keycloakClient.DeleteRealmLocalizationText(ctx, realmId, locale, key)
+			if err != nil {
+				return diagnosticsFromError(err, data)
+			}
+		}
+	}
+
+	err = keycloakClient.UpdateRealmLocalizationTexts(ctx, realmId, locale, texts)
+	if err != nil {
+		return diagnosticsFromError(err, data)
+	}
+
+	data.SetId(realmLocalizationId(realmId, locale))
+
+	return resourceKeycloakRealmLocalizationRead(ctx, data, meta)
+}
+
+func resourceKeycloakRealmLocalizationRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	realmId := data.Get("realm_id").(string)
+	locale := data.Get("locale").(string)
+
+	texts, err := keycloakClient.GetRealmLocalizationTexts(ctx, realmId, locale)
+	if err != nil {
+		return handleNotFoundError(ctx, err, data)
+	}
+
+	data.Set("texts", texts)
+
+	return nil
+}
+
+func resourceKeycloakRealmLocalizationUpdate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	realmId := data.Get("realm_id").(string)
+	locale := data.Get("locale").(string)
+
+	oldTextsData, newTextsData := data.GetChange("texts")
+	oldTexts := getRealmLocalizationTextsFromData(oldTextsData.(map[string]interface{}))
+	newTexts := getRealmLocalizationTextsFromData(newTextsData.(map[string]interface{}))
+
+	// only send the texts that were added or changed, so large locales don't need to be uploaded in full on every apply
+	changedTexts := make(map[string]string)
+	for key, value := range newTexts {
+		if oldValue, ok := oldTexts[key]; !ok || oldValue != value {
+			changedTexts[key] = value
+		}
+	}
+
+	for key := range oldTexts {
+		if _, ok := newTexts[key]; !ok {
+			err := keycloakClient.DeleteRealmLocalizationText(ctx, realmId, locale, key)
+			if err != nil && !keycloak.ErrorIs404(err) {
+				return diagnosticsFromError(err, data)
+			}
+		}
+	}
+
+	err := keycloakClient.UpdateRealmLocalizationTexts(ctx, realmId, locale, changedTexts)
+	if err != nil {
+		return diagnosticsFromError(err, data)
+	}
+
+	return resourceKeycloakRealmLocalizationRead(ctx, data, meta)
+}
+
+func resourceKeycloakRealmLocalizationDelete(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	realmId := data.Get("realm_id").(string)
+	locale := data.Get("locale").(string)
+
+	return diag.FromErr(keycloakClient.DeleteRealmLocalizationTexts(ctx, realmId, locale))
+}
+
+func resourceKeycloakRealmLocalizationImport(_ context.Context, d *schema.ResourceData, _ interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.Split(d.Id(), "/")
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("Invalid import. Supported import formats: {{realmId}}/{{locale}}")
+	}
+
+	d.Set("realm_id", parts[0])
+	d.Set("locale", parts[1])
+	d.SetId(realmLocalizationId(parts[0], parts[1]))
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func realmLocalizationId(realmId, locale string) string {
+	return fmt.Sprintf("%s/%s", realmId, locale)
+}
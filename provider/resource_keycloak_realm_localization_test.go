@@ -0,0 +1,173 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccKeycloakRealmLocalization_basic(t *testing.T) {
+	realmName := acctest.RandomWithPrefix("tf-acc")
+
+	texts := map[string]string{
+		"loginTitle":       "Welcome to " + realmName,
+		"doForgotPassword": "Lost your password?",
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		PreCheck:          func() { testAccPreCheck(t) },
+		CheckDestroy:      testAccCheckKeycloakRealmLocalizationDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config: testKeycloakRealmLocalization_basic(realmName, texts),
+				Check:  testAccCheckKeycloakRealmLocalizationTexts("keycloak_realm_localization.localization", texts),
+			},
+			{
+				ResourceName:      "keycloak_realm_localization.localization",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccKeycloakRealmLocalization_update(t *testing.T) {
+	realmName := acctest.RandomWithPrefix("tf-acc")
+
+	before := map[string]string{
+		"loginTitle":       "Welcome",
+		"doForgotPassword": "Lost your password?",
+		"doRegister":       "Sign up",
+	}
+
+	after := map[string]string{
+		"loginTitle":       "Welcome back",
+		"doForgotPassword": "Lost your password?",
+		"doLogIn":          "Sign in",
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		PreCheck:          func() { testAccPreCheck(t) },
+		CheckDestroy:      testAccCheckKeycloakRealmLocalizationDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config: testKeycloakRealmLocalization_basic(realmName, before),
+				Check:  testAccCheckKeycloakRealmLocalizationTexts("keycloak_realm_localization.localization", before),
+			},
+			{
+				Config: testKeycloakRealmLocalization_basic(realmName, after),
+				Check:  testAccCheckKeycloakRealmLocalizationTexts("keycloak_realm_localization.localization", after),
+			},
+		},
+	})
+}
+
+func TestAccKeycloakRealmLocalization_authoritative(t *testing.T) {
+	realmName := acctest.RandomWithPrefix("tf-acc")
+
+	texts := map[string]string{
+		"loginTitle": "Welcome",
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		PreCheck:          func() { testAccPreCheck(t) },
+		CheckDestroy:      testAccCheckKeycloakRealmLocalizationDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config: testKeycloakRealmLocalization_basic(realmName, texts),
+				Check:  testAccCheckKeycloakRealmLocalizationTexts("keycloak_realm_localization.localization", texts),
+			},
+			{
+				PreConfig: func() {
+					err := keycloakClient.UpdateRealmLocalizationTexts(testCtx, realmName, "en", map[string]string{
+						"doRegister": "Added outside of terraform",
+					})
+					if err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config: testKeycloakRealmLocalization_basic(realmName, texts),
+				Check:  testAccCheckKeycloakRealmLocalizationTexts("keycloak_realm_localization.localization", texts),
+			},
+		},
+	})
+}
+
+func testAccCheckKeycloakRealmLocalizationTexts(resourceName string, expectedTexts map[string]string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("resource not found: %s", resourceName)
+		}
+
+		realmId := rs.Primary.Attributes["realm_id"]
+		locale := rs.Primary.Attributes["locale"]
+
+		texts, err := keycloakClient.GetRealmLocalizationTexts(testCtx, realmId, locale)
+		if err != nil {
+			return err
+		}
+
+		if len(texts) != len(expectedTexts) {
+			return fmt.Errorf("expected locale %s to have %d texts, but found %d", locale, len(expectedTexts), len(texts))
+		}
+
+		for key, value := range expectedTexts {
+			if texts[key] != value {
+				return fmt.Errorf("expected text %s to be %s, but was %s", key, value, texts[key])
+			}
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckKeycloakRealmLocalizationDestroy() resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		for _, rs := range s.RootModule().Resources {
+			if rs.Type != "keycloak_realm_localization" {
+				continue
+			}
+
+			realmId := rs.Primary.Attributes["realm_id"]
+			locale := rs.Primary.Attributes["locale"]
+
+			texts, _ := keycloakClient.GetRealmLocalizationTexts(testCtx, realmId, locale)
+			if len(texts) != 0 {
+				return fmt.Errorf("localization texts for locale %s still exist", locale)
+			}
+		}
+
+		return nil
+	}
+}
+
+func testKeycloakRealmLocalization_basic(realm string, texts map[string]string) string {
+	var textsHcl string
+	for key, value := range texts {
+		textsHcl += fmt.Sprintf("\t\t%s = \"%s\"\n", key, value)
+	}
+
+	return fmt.Sprintf(`
+resource "keycloak_realm" "realm" {
+	realm                       = "%s"
+	internationalization_enabled = true
+	supported_locales            = ["en", "de"]
+	default_locale               = "en"
+}
+
+resource "keycloak_realm_localization" "localization" {
+	realm_id = keycloak_realm.realm.id
+	locale   = "en"
+
+	texts = {
+%s	}
+}
+	`, realm, textsHcl)
+}
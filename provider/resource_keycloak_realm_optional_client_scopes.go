@@ -0,0 +1,107 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/keycloak/terraform-provider-keycloak/keycloak"
+)
+
+func resourceKeycloakRealmOptionalClientScopes() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceKeycloakRealmOptionalClientScopesReconcile,
+		ReadContext:   resourceKeycloakRealmOptionalClientScopesRead,
+		DeleteContext: resourceKeycloakRealmOptionalClientScopesDelete,
+		UpdateContext: resourceKeycloakRealmOptionalClientScopesReconcile,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceKeycloakRealmClientScopesImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"realm_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"optional_scopes": {
+				Type:     schema.TypeSet,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Required: true,
+				Set:      schema.HashString,
+			},
+		},
+	}
+}
+
+func resourceKeycloakRealmOptionalClientScopesRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	realmId := data.Get("realm_id").(string)
+
+	clientScopes, err := keycloakClient.GetRealmOptionalClientScopes(ctx, realmId)
+	if err != nil {
+		return handleNotFoundError(ctx, err, data)
+	}
+
+	var optionalScopes []string
+	for _, clientScope := range clientScopes {
+		optionalScopes = append(optionalScopes, clientScope.Name)
+	}
+
+	data.Set("optional_scopes", optionalScopes)
+	data.SetId(realmId)
+
+	return nil
+}
+
+func resourceKeycloakRealmOptionalClientScopesReconcile(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	realmId := data.Get("realm_id").(string)
+	tfRealmOptionalClientScopes := data.Get("optional_scopes").(*schema.Set)
+
+	keycloakRealmOptionalClientScopes, err := keycloakClient.GetRealmOptionalClientScopes(ctx, realmId)
+	if err != nil {
+		return diagnosticsFromError(err, data)
+	}
+
+	var realmOptionalClientScopesToDetach []string
+	for _, keycloakRealmOptionalClientScope := range keycloakRealmOptionalClientScopes {
+		// scopes that are attached in keycloak and tf state don't need to be updated
+		if tfRealmOptionalClientScopes.Contains(keycloakRealmOptionalClientScope.Name) {
+			tfRealmOptionalClientScopes.Remove(keycloakRealmOptionalClientScope.Name)
+		} else {
+			realmOptionalClientScopesToDetach = append(realmOptionalClientScopesToDetach, keycloakRealmOptionalClientScope.Name)
+		}
+	}
+
+	// detach scopes that aren't in tf state
+	err = keycloakClient.DetachRealmOptionalClientScopes(ctx, realmId, realmOptionalClientScopesToDetach)
+	if err != nil {
+		return diagnosticsFromError(err, data)
+	}
+
+	// attach scopes that exist in tf state but not in keycloak
+	err = keycloakClient.AttachRealmOptionalClientScopes(ctx, realmId, interfaceSliceToStringSlice(tfRealmOptionalClientScopes.List()))
+	if err != nil {
+		return diagnosticsFromError(err, data)
+	}
+
+	data.SetId(realmId)
+
+	return resourceKeycloakRealmOptionalClientScopesRead(ctx, data, meta)
+}
+
+func resourceKeycloakRealmOptionalClientScopesDelete(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	realmId := data.Get("realm_id").(string)
+	optionalScopes := data.Get("optional_scopes").(*schema.Set)
+
+	err := keycloakClient.DetachRealmOptionalClientScopes(ctx, realmId, interfaceSliceToStringSlice(optionalScopes.List()))
+	if err != nil && !keycloak.ErrorIs404(err) {
+		return diagnosticsFromError(err, data)
+	}
+
+	return nil
+}
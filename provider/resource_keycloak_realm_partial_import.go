@@ -0,0 +1,132 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/id"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/keycloak/terraform-provider-keycloak/keycloak"
+)
+
+func resourceKeycloakRealmPartialImport() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceKeycloakRealmPartialImportCreate,
+		ReadContext:   resourceKeycloakRealmPartialImportRead,
+		DeleteContext: resourceKeycloakRealmPartialImportDelete,
+		Schema: map[string]*schema.Schema{
+			"realm_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"if_resource_exists": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				Default:      keycloak.PartialImportIfResourceExistsFail,
+				ValidateFunc: validation.StringInSlice([]string{keycloak.PartialImportIfResourceExistsFail, keycloak.PartialImportIfResourceExistsSkip, keycloak.PartialImportIfResourceExistsOverwrite}, false),
+				Description:  "What to do when an imported object already exists in the realm. Can be one of FAIL, SKIP or OVERWRITE.",
+			},
+			"realm_json": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateFunc:     validation.StringIsJSON,
+				DiffSuppressFunc: suppressJsonDiff,
+				Description:      "A realm representation (or a subset of one) containing the users, groups, clients, roles and identity providers to import.",
+			},
+			"added": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"skipped": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"overwritten": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"results": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"action": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"resource_type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"resource_name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func setRealmPartialImportResultsData(data *schema.ResourceData, partialImportResults *keycloak.RealmPartialImportResults) {
+	var results []interface{}
+	for _, result := range partialImportResults.Results {
+		results = append(results, map[string]interface{}{
+			"action":        result.Action,
+			"resource_type": result.ResourceType,
+			"resource_name": result.ResourceName,
+			"id":            result.Id,
+		})
+	}
+
+	data.Set("added", partialImportResults.Added)
+	data.Set("skipped", partialImportResults.Skipped)
+	data.Set("overwritten", partialImportResults.Overwritten)
+	data.Set("results", results)
+}
+
+func resourceKeycloakRealmPartialImportCreate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	realmId := data.Get("realm_id").(string)
+	ifResourceExists := data.Get("if_resource_exists").(string)
+	realmJson := data.Get("realm_json").(string)
+
+	partialImportResults, err := keycloakClient.NewRealmPartialImport(ctx, realmId, ifResourceExists, []byte(realmJson))
+	if err != nil {
+		return diagnosticsFromError(err, data)
+	}
+
+	// a realm can have several partial imports, and nothing identifies an import within keycloak
+	data.SetId(fmt.Sprintf("%s/%s", realmId, id.UniqueId()))
+	setRealmPartialImportResultsData(data, partialImportResults)
+
+	return resourceKeycloakRealmPartialImportRead(ctx, data, meta)
+}
+
+func resourceKeycloakRealmPartialImportRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	// the imported objects are not tracked by this resource, so the only thing that can drift is the realm itself
+	_, err := keycloakClient.GetRealm(ctx, data.Get("realm_id").(string))
+	if err != nil {
+		return handleNotFoundError(ctx, err, data)
+	}
+
+	return nil
+}
+
+func resourceKeycloakRealmPartialImportDelete(_ context.Context, _ *schema.ResourceData, _ interface{}) diag.Diagnostics {
+	// the partialImport endpoint has no inverse, so the imported objects are left in place
+	return nil
+}
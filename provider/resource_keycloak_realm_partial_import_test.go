@@ -0,0 +1,153 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccKeycloakRealmPartialImport_basic(t *testing.T) {
+	realmName := acctest.RandomWithPrefix("tf-acc")
+	username := acctest.RandomWithPrefix("tf-acc")
+	groupName := acctest.RandomWithPrefix("tf-acc")
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		PreCheck:          func() { testAccPreCheck(t) },
+		CheckDestroy:      testAccCheckKeycloakRealmDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config: testKeycloakRealmPartialImport_basic(realmName, username, groupName, "FAIL"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckKeycloakRealmPartialImportObjectsExist("keycloak_realm_partial_import.import", username, groupName),
+					resource.TestCheckResourceAttr("keycloak_realm_partial_import.import", "added", "2"),
+					resource.TestCheckResourceAttr("keycloak_realm_partial_import.import", "skipped", "0"),
+					resource.TestCheckResourceAttr("keycloak_realm_partial_import.import", "results.#", "2"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccKeycloakRealmPartialImport_ifResourceExists(t *testing.T) {
+	realmName := acctest.RandomWithPrefix("tf-acc")
+	username := acctest.RandomWithPrefix("tf-acc")
+	groupName := acctest.RandomWithPrefix("tf-acc")
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		PreCheck:          func() { testAccPreCheck(t) },
+		CheckDestroy:      testAccCheckKeycloakRealmDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config: testKeycloakRealmPartialImport_basic(realmName, username, groupName, "FAIL"),
+				Check:  resource.TestCheckResourceAttr("keycloak_realm_partial_import.import", "added", "2"),
+			},
+			{
+				Config: testKeycloakRealmPartialImport_basic(realmName, username, groupName, "SKIP"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("keycloak_realm_partial_import.import", "added", "0"),
+					resource.TestCheckResourceAttr("keycloak_realm_partial_import.import", "skipped", "2"),
+				),
+			},
+			{
+				Config: testKeycloakRealmPartialImport_basic(realmName, username, groupName, "OVERWRITE"),
+				Check:  resource.TestCheckResourceAttr("keycloak_realm_partial_import.import", "overwritten", "2"),
+			},
+		},
+	})
+}
+
+func TestAccKeycloakRealmPartialImport_validation(t *testing.T) {
+	realmName := acctest.RandomWithPrefix("tf-acc")
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		PreCheck:          func() { testAccPreCheck(t) },
+		CheckDestroy:      testAccCheckKeycloakRealmDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config:      testKeycloakRealmPartialImport_userWithoutUsername(realmName),
+				ExpectError: regexp.MustCompile("validation error: every user must have a username"),
+			},
+		},
+	})
+}
+
+func testAccCheckKeycloakRealmPartialImportObjectsExist(resourceName, username, groupName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("resource not found: %s", resourceName)
+		}
+
+		realmId := rs.Primary.Attributes["realm_id"]
+
+		user, err := keycloakClient.GetUserByUsername(testCtx, realmId, username)
+		if err != nil {
+			return err
+		}
+		if user == nil {
+			return fmt.Errorf("expected user %s to have been imported into realm %s", username, realmId)
+		}
+
+		_, err = keycloakClient.GetGroupByName(testCtx, realmId, groupName)
+		if err != nil {
+			return fmt.Errorf("expected group %s to have been imported into realm %s: %s", groupName, realmId, err)
+		}
+
+		return nil
+	}
+}
+
+func testKeycloakRealmPartialImport_basic(realm, username, groupName, ifResourceExists string) string {
+	return fmt.Sprintf(`
+resource "keycloak_realm" "realm" {
+	realm = "%s"
+}
+
+resource "keycloak_realm_partial_import" "import" {
+	realm_id           = keycloak_realm.realm.id
+	if_resource_exists = "%s"
+
+	realm_json = jsonencode({
+		users = [
+			{
+				username  = "%s"
+				enabled   = true
+				firstName = "Imported"
+			}
+		]
+		groups = [
+			{
+				name = "%s"
+			}
+		]
+	})
+}
+	`, realm, ifResourceExists, username, groupName)
+}
+
+func testKeycloakRealmPartialImport_userWithoutUsername(realm string) string {
+	return fmt.Sprintf(`
+resource "keycloak_realm" "realm" {
+	realm = "%s"
+}
+
+resource "keycloak_realm_partial_import" "import" {
+	realm_id = keycloak_realm.realm.id
+
+	realm_json = jsonencode({
+		users = [
+			{
+				email = "nobody@example.com"
+			}
+		]
+	})
+}
+	`, realm)
+}
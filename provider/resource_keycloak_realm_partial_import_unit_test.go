@@ -0,0 +1,43 @@
+package provider
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/keycloak/terraform-provider-keycloak/keycloak"
+)
+
+func TestRealmPartialImportResourceIds(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	keycloakClient := newFakeKeycloakClient(t)
+
+	if err := keycloakClient.NewRealm(ctx, &keycloak.Realm{Realm: "test", Enabled: true}); err != nil {
+		t.Fatal(err)
+	}
+
+	var ids []string
+	for _, realmJson := range []string{`{"users":[{"username":"alice"}]}`, `{"users":[{"username":"bob"}]}`} {
+		data := schema.TestResourceDataRaw(t, resourceKeycloakRealmPartialImport().Schema, map[string]interface{}{
+			"realm_id":   "test",
+			"realm_json": realmJson,
+		})
+		if diags := resourceKeycloakRealmPartialImportCreate(ctx, data, keycloakClient); diags.HasError() {
+			t.Fatal(diags)
+		}
+
+		if added := data.Get("added").(int); added != 1 {
+			t.Errorf("expected a user to be added, got %d", added)
+		}
+
+		ids = append(ids, data.Id())
+	}
+
+	// both imports go into the same realm, so they need ids of their own
+	if !strings.HasPrefix(ids[0], "test/") || ids[0] == ids[1] {
+		t.Errorf("expected unique ids within the realm, got %v", ids)
+	}
+}
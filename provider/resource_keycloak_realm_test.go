@@ -111,6 +111,29 @@ func TestAccKeycloakRealm_OTP(t *testing.T) {
 	})
 }
 
+func TestAccKeycloakRealm_CibaAndParPolicies(t *testing.T) {
+	skipIfFeatureIsDisabled(testCtx, t, keycloakClient, keycloak.FeatureCiba)
+	skipIfFeatureIsDisabled(testCtx, t, keycloakClient, keycloak.FeaturePar)
+
+	realm := acctest.RandomWithPrefix("tf-acc")
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		PreCheck:          func() { testAccPreCheck(t) },
+		CheckDestroy:      testAccCheckKeycloakRealmDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config: testKeycloakRealm_WithCibaAndParPolicies(realm, "poll", 120, "1m"),
+				Check:  testAccCheckKeycloakRealmCibaAndParPolicies("keycloak_realm.realm", "poll", 120, 60),
+			},
+			{
+				Config: testKeycloakRealm_WithCibaAndParPolicies(realm, "ping", 300, "5m"),
+				Check:  testAccCheckKeycloakRealmCibaAndParPolicies("keycloak_realm.realm", "ping", 300, 300),
+			},
+		},
+	})
+}
+
 func TestAccKeycloakRealm_SmtpServer(t *testing.T) {
 	realm := acctest.RandomWithPrefix("tf-acc")
 	realmDisplayNameHtml := acctest.RandomWithPrefix("tf-acc")
@@ -152,6 +175,56 @@ func TestAccKeycloakRealm_SmtpServerUpdate(t *testing.T) {
 	})
 }
 
+func TestAccKeycloakRealm_SmtpServerVerifyConnection(t *testing.T) {
+	realm := acctest.RandomWithPrefix("tf-acc")
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		PreCheck:          func() { testAccPreCheck(t) },
+		CheckDestroy:      testAccCheckKeycloakRealmDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config: testKeycloakRealm_WithSmtpServer(realm, "myhost.com", "My Host", "user"),
+				Check:  testAccCheckKeycloakRealmSmtp("keycloak_realm.realm", "myhost.com", "My Host", "user"),
+			},
+			// nothing listens on this host, so the test email can't be sent and the settings aren't saved
+			{
+				Config:      testKeycloakRealm_WithVerifiedSmtpServer(realm, "localhost.invalid"),
+				ExpectError: regexp.MustCompile("unable to send a test email with the smtp settings of realm"),
+			},
+			{
+				Config: testKeycloakRealm_WithSmtpServer(realm, "myhost.com", "My Host", "user"),
+				Check:  testAccCheckKeycloakRealmSmtp("keycloak_realm.realm", "myhost.com", "My Host", "user"),
+			},
+		},
+	})
+}
+
+func TestAccKeycloakRealm_SmtpServerTokenAuth(t *testing.T) {
+	skipIfFeatureIsDisabled(testCtx, t, keycloakClient, keycloak.FeatureSmtpTokenAuth)
+
+	realm := acctest.RandomWithPrefix("tf-acc")
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		PreCheck:          func() { testAccPreCheck(t) },
+		CheckDestroy:      testAccCheckKeycloakRealmDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config: testKeycloakRealm_WithSmtpServerTokenAuth(realm, "smtp-client"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckKeycloakRealmSmtp("keycloak_realm.realm", "myhost.com", "My Host", "user"),
+					resource.TestCheckResourceAttr("keycloak_realm.realm", "smtp_server.0.token_auth.0.client_id", "smtp-client"),
+				),
+			},
+			{
+				Config: testKeycloakRealm_WithSmtpServerTokenAuth(realm, "other-smtp-client"),
+				Check:  resource.TestCheckResourceAttr("keycloak_realm.realm", "smtp_server.0.token_auth.0.client_id", "other-smtp-client"),
+			},
+		},
+	})
+}
+
 func TestAccKeycloakRealm_SmtpServerInvalid(t *testing.T) {
 	realm := acctest.RandomWithPrefix("tf-acc")
 
@@ -670,6 +743,41 @@ func TestAccKeycloakRealm_passwordPolicy(t *testing.T) {
 	})
 }
 
+func TestAccKeycloakRealm_passwordPolicies(t *testing.T) {
+	realmName := acctest.RandomWithPrefix("tf-acc")
+	realmDisplayName := acctest.RandomWithPrefix("tf-acc")
+	realmDisplayNameHtml := acctest.RandomWithPrefix("tf-acc")
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		PreCheck:          func() { testAccPreCheck(t) },
+		CheckDestroy:      testAccCheckKeycloakRealmDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config: testKeycloakRealm_passwordPolicy(realmName, realmDisplayName, "upperCase(1) and length(8) and notUsername"),
+				Check:  testAccCheckKeycloakRealmPasswordPolicy("keycloak_realm.realm", "upperCase(1) and length(8) and notUsername"),
+			},
+			// moving from the password_policy argument to the block
+			{
+				Config: testKeycloakRealm_passwordPolicies(realmName, realmDisplayName, 8),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckKeycloakRealmPasswordPolicy("keycloak_realm.realm", "hashIterations(27500) and length(8) and notUsername and upperCase(1)"),
+					resource.TestCheckResourceAttr("keycloak_realm.realm", "password_policies.0.length", "8"),
+					resource.TestCheckResourceAttr("keycloak_realm.realm", "password_policy", ""),
+				),
+			},
+			{
+				Config: testKeycloakRealm_passwordPolicies(realmName, realmDisplayName, 12),
+				Check:  testAccCheckKeycloakRealmPasswordPolicy("keycloak_realm.realm", "hashIterations(27500) and length(12) and notUsername and upperCase(1)"),
+			},
+			{
+				Config: testKeycloakRealm_basic(realmName, realmDisplayName, realmDisplayNameHtml),
+				Check:  testAccCheckKeycloakRealmPasswordPolicy("keycloak_realm.realm", ""),
+			},
+		},
+	})
+}
+
 func TestAccKeycloakRealm_browserFlow(t *testing.T) {
 	realmName := acctest.RandomWithPrefix("tf-acc")
 	realmDisplayName := acctest.RandomWithPrefix("tf-acc")
@@ -1124,6 +1232,31 @@ func testAccCheckKeycloakRealmOTP(resourceName, otpType, algorithm string, perio
 	}
 }
 
+func testAccCheckKeycloakRealmCibaAndParPolicies(resourceName, deliveryMode string, expiresIn, requestUriLifespan int) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		realm, err := getRealmFromState(s, resourceName)
+		if err != nil {
+			return err
+		}
+
+		cibaPolicy := realm.GetCibaPolicy()
+		if cibaPolicy.BackchannelTokenDeliveryMode != deliveryMode || cibaPolicy.ExpiresIn != expiresIn {
+			return fmt.Errorf("expected realm %s to have a CIBA policy with delivery mode %s and expiry %d, but was %+v", realm.Realm, deliveryMode, expiresIn, cibaPolicy)
+		}
+
+		if parPolicy := realm.GetParPolicy(); parPolicy.RequestUriLifespan != requestUriLifespan {
+			return fmt.Errorf("expected realm %s to have a PAR request uri lifespan of %d, but was %d", realm.Realm, requestUriLifespan, parPolicy.RequestUriLifespan)
+		}
+
+		// the policies are kept next to the attributes managed by the attributes argument
+		if realm.Attributes["frontendUrl"] != "https://sso.example.com" {
+			return fmt.Errorf("expected realm %s to keep its frontendUrl attribute, but was %v", realm.Realm, realm.Attributes["frontendUrl"])
+		}
+
+		return nil
+	}
+}
+
 func testAccCheckKeycloakRealmInternationalizationIsEnabled(resourceName string, defaultLocale string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		realm, err := getRealmFromState(s, resourceName)
@@ -1344,6 +1477,49 @@ resource "keycloak_realm" "realm" {
 	`, realm, realm, host, from, user)
 }
 
+func testKeycloakRealm_WithVerifiedSmtpServer(realm, host string) string {
+	return fmt.Sprintf(`
+resource "keycloak_realm" "realm" {
+	realm = "%s"
+	enabled = true
+	display_name = "%s"
+	smtp_server {
+		host = "%s"
+		port = 25
+		from = "My Host"
+		verify_connection = true
+		auth {
+			username = "user"
+			password = "tom"
+		}
+	}
+}
+	`, realm, realm, host)
+}
+
+func testKeycloakRealm_WithSmtpServerTokenAuth(realm, clientId string) string {
+	return fmt.Sprintf(`
+resource "keycloak_realm" "realm" {
+	realm = "%s"
+	enabled = true
+	display_name = "%s"
+	smtp_server {
+		host = "myhost.com"
+		port = 587
+		from = "My Host"
+		starttls = true
+		token_auth {
+			username      = "user"
+			url           = "https://login.example.com/oauth2/v2.0/token"
+			client_id     = "%s"
+			client_secret = "secret"
+			scope         = "https://outlook.office365.com/.default"
+		}
+	}
+}
+	`, realm, realm, clientId)
+}
+
 func testKeycloakRealm_WithOTP(realm, otpType, algorithm string, period int) string {
 	return fmt.Sprintf(`
 resource "keycloak_realm" "realm" {
@@ -1359,6 +1535,28 @@ resource "keycloak_realm" "realm" {
 	`, realm, otpType, algorithm, period)
 }
 
+func testKeycloakRealm_WithCibaAndParPolicies(realm, deliveryMode string, expiresIn int, requestUriLifespan string) string {
+	return fmt.Sprintf(`
+resource "keycloak_realm" "realm" {
+	realm   = "%s"
+	enabled = true
+
+	attributes = {
+		frontendUrl = "https://sso.example.com"
+	}
+
+	ciba_policy {
+		backchannel_token_delivery_mode = "%s"
+		expires_in                      = %d
+	}
+
+	par_policy {
+		request_uri_lifespan = "%s"
+	}
+}
+	`, realm, deliveryMode, expiresIn, requestUriLifespan)
+}
+
 func testKeycloakRealm_WithSmtpServerWithoutHost(realm, from string) string {
 	return fmt.Sprintf(`
 resource "keycloak_realm" "realm" {
@@ -1667,6 +1865,23 @@ resource "keycloak_realm" "realm" {
 	`, realm, realmDisplayName, passwordPolicy)
 }
 
+func testKeycloakRealm_passwordPolicies(realm, realmDisplayName string, length int) string {
+	return fmt.Sprintf(`
+resource "keycloak_realm" "realm" {
+	realm        = "%s"
+	enabled      = true
+	display_name = "%s"
+
+	password_policies {
+		upper_case      = 1
+		length          = %d
+		not_username    = true
+		hash_iterations = 27500
+	}
+}
+	`, realm, realmDisplayName, length)
+}
+
 func testKeycloakRealm_browserFlow(realm, realmDisplayName, browserFlow string) string {
 	return fmt.Sprintf(`
 resource "keycloak_realm" "realm" {
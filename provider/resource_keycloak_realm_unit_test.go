@@ -0,0 +1,408 @@
+package provider
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/keycloak/terraform-provider-keycloak/keycloak/keycloaktest"
+)
+
+func TestRealmResourceCibaAndParPolicies(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	keycloakClient := newFakeKeycloakClient(t)
+
+	data := schema.TestResourceDataRaw(t, resourceKeycloakRealm().Schema, map[string]interface{}{
+		"realm": "test",
+		"attributes": map[string]interface{}{
+			"frontendUrl": "https://sso.example.com",
+		},
+		"ciba_policy": []interface{}{
+			map[string]interface{}{
+				"backchannel_token_delivery_mode": "ping",
+				"expires_in":                      300,
+				"interval":                        10,
+			},
+		},
+		"par_policy": []interface{}{
+			map[string]interface{}{
+				"request_uri_lifespan": "2m",
+			},
+		},
+	})
+	if diags := resourceKeycloakRealmCreate(ctx, data, keycloakClient); diags.HasError() {
+		t.Fatal(diags)
+	}
+
+	if mode := data.Get("ciba_policy.0.backchannel_token_delivery_mode").(string); mode != "ping" {
+		t.Errorf("expected the ping delivery mode, got %s", mode)
+	}
+	if hint := data.Get("ciba_policy.0.auth_requested_user_hint").(string); hint != "login_hint" {
+		t.Errorf("expected the default user hint, got %s", hint)
+	}
+	if lifespan := data.Get("par_policy.0.request_uri_lifespan").(string); lifespan != "2m0s" {
+		t.Errorf("expected a request uri lifespan of 2m0s, got %s", lifespan)
+	}
+
+	// the policies don't show up in attributes, and the attributes argument doesn't remove them
+	if attributes := data.Get("attributes").(map[string]interface{}); len(attributes) != 1 {
+		t.Errorf("expected only frontendUrl in attributes, got %v", attributes)
+	}
+
+	realm, err := keycloakClient.GetRealm(ctx, "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if realm.Attributes["cibaExpiresIn"] != "300" || realm.Attributes["parRequestUriLifespan"] != "120" || realm.Attributes["frontendUrl"] != "https://sso.example.com" {
+		t.Errorf("unexpected attributes %v", realm.Attributes)
+	}
+
+	realm.Attributes = map[string]interface{}{"cibaInterval": "20"}
+	if err := keycloakClient.UpdateRealm(ctx, realm); err != nil {
+		t.Fatal(err)
+	}
+
+	if diags := resourceKeycloakRealmRead(ctx, data, keycloakClient); diags.HasError() {
+		t.Fatal(diags)
+	}
+	if interval := data.Get("ciba_policy.0.interval").(int); interval != 20 {
+		t.Errorf("expected the changed interval to be read, got %d", interval)
+	}
+}
+
+func TestRealmResourceCibaPolicyConflictsWithAttributes(t *testing.T) {
+	t.Parallel()
+
+	data := schema.TestResourceDataRaw(t, resourceKeycloakRealm().Schema, map[string]interface{}{
+		"realm": "test",
+		"attributes": map[string]interface{}{
+			"cibaInterval": "10",
+		},
+		"ciba_policy": []interface{}{
+			map[string]interface{}{
+				"interval": 10,
+			},
+		},
+	})
+
+	_, err := getRealmFromData(data)
+	if err == nil || !strings.Contains(err.Error(), "cibaInterval attribute is managed by ciba_policy") {
+		t.Errorf("expected the attribute to conflict with ciba_policy, got %v", err)
+	}
+}
+
+func TestRealmResourceCibaPolicyRequiresFeature(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	server := keycloaktest.NewServer()
+	t.Cleanup(server.Close)
+	server.SetFeature("CIBA", false)
+
+	keycloakClient := newFakeKeycloakClientForServer(t, server)
+
+	data := schema.TestResourceDataRaw(t, resourceKeycloakRealm().Schema, map[string]interface{}{
+		"realm": "test",
+		"ciba_policy": []interface{}{
+			map[string]interface{}{
+				"backchannel_token_delivery_mode": "poll",
+			},
+		},
+	})
+
+	diags := resourceKeycloakRealmCreate(ctx, data, keycloakClient)
+	if len(diags) != 1 || diags[0].Summary != "feature CIBA is not supported by the server" {
+		t.Errorf("expected a diagnostic for the disabled feature, got %v", diags)
+	}
+}
+
+func TestRealmResourcePasswordPolicies(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	keycloakClient := newFakeKeycloakClient(t)
+
+	data := schema.TestResourceDataRaw(t, resourceKeycloakRealm().Schema, map[string]interface{}{
+		"realm": "test",
+		"password_policies": []interface{}{
+			map[string]interface{}{
+				"upper_case":     1,
+				"length":         12,
+				"not_username":   true,
+				"regex_pattern":  "^[a-z]+$",
+				"hash_algorithm": "pbkdf2-sha512",
+				"password_age":   30,
+			},
+		},
+	})
+	if diags := resourceKeycloakRealmCreate(ctx, data, keycloakClient); diags.HasError() {
+		t.Fatal(diags)
+	}
+
+	realm, err := keycloakClient.GetRealm(ctx, "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if realm.PasswordPolicy != "hashAlgorithm(pbkdf2-sha512) and length(12) and notUsername and passwordAge(30) and regexPattern(^[a-z]+$) and upperCase(1)" {
+		t.Errorf("expected the policies to be sorted by id, got %s", realm.PasswordPolicy)
+	}
+
+	if extraPolicies := data.Get("password_policies.0.extra_policies").(map[string]interface{}); len(extraPolicies) != 0 {
+		t.Errorf("expected no extra policies, got %v", extraPolicies)
+	}
+	if passwordPolicy := data.Get("password_policy").(string); passwordPolicy != "" {
+		t.Errorf("expected password_policy to be left unset, got %s", passwordPolicy)
+	}
+
+	realm.PasswordPolicy = "notUsername(undefined) and length(16) and upperCase(1) and regexPattern(^[a-z]+$) and hashAlgorithm(pbkdf2-sha512) and passwordAge(30)"
+	if err := keycloakClient.UpdateRealm(ctx, realm); err != nil {
+		t.Fatal(err)
+	}
+
+	if diags := resourceKeycloakRealmRead(ctx, data, keycloakClient); diags.HasError() {
+		t.Fatal(diags)
+	}
+	if length := data.Get("password_policies.0.length").(int); length != 16 {
+		t.Errorf("expected the changed length to be read, got %d", length)
+	}
+	if !data.Get("password_policies.0.not_username").(bool) {
+		t.Error("expected notUsername(undefined) to be read as not_username")
+	}
+}
+
+func TestRealmResourcePasswordPoliciesUnknownPolicy(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	keycloakClient := newFakeKeycloakClient(t)
+
+	data := schema.TestResourceDataRaw(t, resourceKeycloakRealm().Schema, map[string]interface{}{
+		"realm": "test",
+		"password_policies": []interface{}{
+			map[string]interface{}{
+				"extra_policies": map[string]interface{}{
+					"notRecentlyUsed": "3",
+				},
+			},
+		},
+	})
+
+	diags := resourceKeycloakRealmCreate(ctx, data, keycloakClient)
+	if !diags.HasError() || !strings.Contains(diags[0].Summary, `password-policy "notRecentlyUsed" does not exist on the server`) {
+		t.Errorf("expected the unknown policy to be rejected, got %v", diags)
+	}
+}
+
+func TestRealmResourcePasswordPoliciesExtraPolicyWithArgument(t *testing.T) {
+	t.Parallel()
+
+	data := schema.TestResourceDataRaw(t, resourceKeycloakRealm().Schema, map[string]interface{}{
+		"realm": "test",
+		"password_policies": []interface{}{
+			map[string]interface{}{
+				"extra_policies": map[string]interface{}{
+					"passwordAge": "30",
+				},
+			},
+		},
+	})
+
+	_, err := getRealmFromData(data)
+	if err == nil || !strings.Contains(err.Error(), "use the password_age argument instead") {
+		t.Errorf("expected passwordAge to be rejected in extra_policies, got %v", err)
+	}
+}
+
+func TestRealmResourcePasswordPolicyOrderDiff(t *testing.T) {
+	t.Parallel()
+
+	if !suppressPasswordPolicyOrderDiff("password_policy", "length(12) and notUsername", "notUsername and length(12)", nil) {
+		t.Error("expected a reordering not to be a diff")
+	}
+	if suppressPasswordPolicyOrderDiff("password_policy", "length(12) and notUsername", "length(14) and notUsername", nil) {
+		t.Error("expected a changed policy to be a diff")
+	}
+}
+
+func TestRealmResourceOrganizationsCanBeDisabled(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	keycloakClient := newFakeKeycloakClient(t)
+
+	data := schema.TestResourceDataRaw(t, resourceKeycloakRealm().Schema, map[string]interface{}{
+		"realm":                 "test",
+		"organizations_enabled": true,
+	})
+	if diags := resourceKeycloakRealmCreate(ctx, data, keycloakClient); diags.HasError() {
+		t.Fatal(diags)
+	}
+
+	data.Set("organizations_enabled", false)
+	if diags := resourceKeycloakRealmUpdate(ctx, data, keycloakClient); diags.HasError() {
+		t.Fatal(diags)
+	}
+
+	realm, err := keycloakClient.GetRealm(ctx, "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if realm.OrganizationsEnabled == nil || *realm.OrganizationsEnabled {
+		t.Errorf("expected organizations to be disabled, got %v", realm.OrganizationsEnabled)
+	}
+	if data.Get("organizations_enabled").(bool) {
+		t.Error("expected organizations_enabled to be read as false")
+	}
+}
+
+func TestRealmResourceOrganizationsBeforeKeycloak25(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	server := keycloaktest.NewServer()
+	t.Cleanup(server.Close)
+	server.Version = "24.0.5"
+
+	keycloakClient := newFakeKeycloakClientForServer(t, server)
+
+	data := schema.TestResourceDataRaw(t, resourceKeycloakRealm().Schema, map[string]interface{}{
+		"realm": "test",
+	})
+	if diags := resourceKeycloakRealmCreate(ctx, data, keycloakClient); diags.HasError() {
+		t.Fatal(diags)
+	}
+
+	realm, err := keycloakClient.GetRealm(ctx, "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if realm.OrganizationsEnabled != nil {
+		t.Errorf("expected organizationsEnabled not to be sent, got %v", *realm.OrganizationsEnabled)
+	}
+
+	data.Set("organizations_enabled", true)
+	diags := resourceKeycloakRealmUpdate(ctx, data, keycloakClient)
+	if !diags.HasError() || !strings.Contains(diags[0].Summary, "feature ORGANIZATION is not supported by the server") {
+		t.Errorf("expected enabling organizations to be rejected, got %v", diags)
+	}
+}
+
+func TestRealmResourceOrganizationsFeatureDisabled(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	server := keycloaktest.NewServer()
+	t.Cleanup(server.Close)
+	server.SetFeature("ORGANIZATION", false)
+
+	keycloakClient := newFakeKeycloakClientForServer(t, server)
+
+	data := schema.TestResourceDataRaw(t, resourceKeycloakRealm().Schema, map[string]interface{}{
+		"realm": "test",
+	})
+	if diags := resourceKeycloakRealmCreate(ctx, data, keycloakClient); diags.HasError() {
+		t.Fatal(diags)
+	}
+
+	realm, err := keycloakClient.GetRealm(ctx, "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if realm.OrganizationsEnabled != nil {
+		t.Errorf("expected organizationsEnabled not to be sent, got %v", *realm.OrganizationsEnabled)
+	}
+
+	data.Set("organizations_enabled", true)
+	diags := resourceKeycloakRealmUpdate(ctx, data, keycloakClient)
+	if !diags.HasError() || !strings.Contains(diags[0].Summary, "feature ORGANIZATION is not supported by the server") {
+		t.Errorf("expected enabling organizations to be rejected, got %v", diags)
+	}
+}
+
+func TestRealmResourceVerifiesSmtpServer(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	server := keycloaktest.NewServer()
+	t.Cleanup(server.Close)
+	keycloakClient := newFakeKeycloakClientForServer(t, server)
+
+	config := map[string]interface{}{
+		"realm": "test",
+		"smtp_server": []interface{}{
+			map[string]interface{}{
+				"host":              "smtp.example.com",
+				"from":              "keycloak@example.com",
+				"verify_connection": true,
+			},
+		},
+	}
+
+	// the user the provider is logged in as has no email address to send the test email to
+	data := schema.TestResourceDataRaw(t, resourceKeycloakRealm().Schema, config)
+	diags := resourceKeycloakRealmCreate(ctx, data, keycloakClient)
+	if !diags.HasError() || !strings.Contains(diags[0].Summary, "has no email address") {
+		t.Fatalf("expected the missing email address to be reported, got %v", diags)
+	}
+	if !diags[0].AttributePath.Equals(cty.GetAttrPath("smtp_server").IndexInt(0).GetAttr("verify_connection")) {
+		t.Errorf("expected the diagnostic to point at verify_connection, got %#v", diags[0].AttributePath)
+	}
+
+	user, err := keycloakClient.GetUser(ctx, "master", keycloaktest.AdminUserId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	user.Email = "admin@example.com"
+	if err := keycloakClient.UpdateUser(ctx, user); err != nil {
+		t.Fatal(err)
+	}
+
+	data = schema.TestResourceDataRaw(t, resourceKeycloakRealm().Schema, config)
+	if diags := resourceKeycloakRealmCreate(ctx, data, keycloakClient); diags.HasError() {
+		t.Fatal(diags)
+	}
+
+	if sent := server.SentEmails(); len(sent) != 1 || sent[0] != "admin@example.com" {
+		t.Errorf("expected the test email to be sent to the logged in user, got %v", sent)
+	}
+}
+
+func TestRealmResourceIsNotCreatedWhenSmtpServerFails(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	server := keycloaktest.NewServer()
+	t.Cleanup(server.Close)
+	keycloakClient := newFakeKeycloakClientForServer(t, server)
+
+	server.SetSMTPError("Failed to send email")
+
+	data := schema.TestResourceDataRaw(t, resourceKeycloakRealm().Schema, map[string]interface{}{
+		"realm": "test",
+		"smtp_server": []interface{}{
+			map[string]interface{}{
+				"host":              "smtp.example.com",
+				"from":              "keycloak@example.com",
+				"verify_connection": true,
+			},
+		},
+	})
+	diags := resourceKeycloakRealmCreate(ctx, data, keycloakClient)
+	if !diags.HasError() || !strings.Contains(diags[0].Summary, "unable to send a test email with the smtp settings: Failed to send email") {
+		t.Fatalf("expected the error of the server, got %v", diags)
+	}
+
+	if data.Id() != "" {
+		t.Errorf("expected no realm in state, got %s", data.Id())
+	}
+	if _, err := keycloakClient.GetRealm(ctx, "test"); err == nil {
+		t.Error("expected the realm not to be created")
+	}
+}
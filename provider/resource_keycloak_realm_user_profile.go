@@ -7,9 +7,12 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/keycloak/terraform-provider-keycloak/keycloak"
 )
 
+var keycloakRealmUserProfileUnmanagedAttributePolicies = []string{"ENABLED", "ADMIN_VIEW", "ADMIN_EDIT"}
+
 func resourceKeycloakRealmUserProfile() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: resourceKeycloakRealmUserProfileCreate,
@@ -22,118 +25,143 @@ func resourceKeycloakRealmUserProfile() *schema.Resource {
 				Required: true,
 				ForceNew: true,
 			},
+			"unmanaged_attribute_policy": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice(keycloakRealmUserProfileUnmanagedAttributePolicies, false),
+			},
 			"attribute": {
 				Type:     schema.TypeList,
 				Optional: true,
 				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"name": {
-							Type:     schema.TypeString,
-							Required: true,
-						},
-						"display_name": {
-							Type:     schema.TypeString,
-							Optional: true,
-						},
-						"group": {
-							Type:     schema.TypeString,
-							Optional: true,
-						},
-						"enabled_when_scope": {
-							Type:     schema.TypeSet,
-							Optional: true,
-							Elem:     &schema.Schema{Type: schema.TypeString},
-						},
-						"required_for_roles": {
-							Type:     schema.TypeSet,
-							Optional: true,
-							Elem:     &schema.Schema{Type: schema.TypeString},
-						},
-						"required_for_scopes": {
-							Type:     schema.TypeSet,
-							Optional: true,
-							Elem:     &schema.Schema{Type: schema.TypeString},
-						},
-						"permissions": {
-							Type:     schema.TypeList,
-							Optional: true,
-							MaxItems: 1,
-							Elem: &schema.Resource{
-								Schema: map[string]*schema.Schema{
-									"view": {
-										Type:     schema.TypeSet,
-										Set:      schema.HashString,
-										Required: true,
-										Elem:     &schema.Schema{Type: schema.TypeString},
-									},
-									"edit": {
-										Type:     schema.TypeSet,
-										Set:      schema.HashString,
-										Required: true,
-										Elem:     &schema.Schema{Type: schema.TypeString},
-									},
-								},
-							},
-						},
-						"validator": {
-							Type:     schema.TypeSet,
-							Optional: true,
-							Elem: &schema.Resource{
-								Schema: map[string]*schema.Schema{
-									"name": {
-										Type:     schema.TypeString,
-										Required: true,
-									},
-									"config": {
-										Type:     schema.TypeMap,
-										Optional: true,
-										Elem:     &schema.Schema{Type: schema.TypeString},
-									},
-								},
-							},
-						},
-						"annotations": {
-							Type:     schema.TypeMap,
-							Optional: true,
-							Elem:     &schema.Schema{Type: schema.TypeString},
-						},
-					},
+					Schema: realmUserProfileAttributeSchema(),
 				},
 			},
 			"group": {
 				Type:     schema.TypeSet,
 				Optional: true,
 				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"name": {
-							Type:     schema.TypeString,
-							Required: true,
-						},
-						"display_header": {
-							Type:     schema.TypeString,
-							Optional: true,
-						},
-						"display_description": {
-							Type:     schema.TypeString,
-							Optional: true,
-						},
-						"annotations": {
-							Type:     schema.TypeMap,
-							Optional: true,
-							Elem:     &schema.Schema{Type: schema.TypeString},
-						},
+					Schema: realmUserProfileGroupSchema(),
+				},
+			},
+		},
+	}
+}
+
+// realmUserProfileAttributeSchema is the schema of an attribute, which keycloak_realm_user_profile_attribute shares
+func realmUserProfileAttributeSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"name": {
+			Type:     schema.TypeString,
+			Required: true,
+		},
+		"display_name": {
+			Type:     schema.TypeString,
+			Optional: true,
+		},
+		"group": {
+			Type:     schema.TypeString,
+			Optional: true,
+		},
+		"multivalued": {
+			Type:     schema.TypeBool,
+			Optional: true,
+		},
+		"default_value": {
+			Type:     schema.TypeString,
+			Optional: true,
+		},
+		"enabled_when_scope": {
+			Type:     schema.TypeSet,
+			Optional: true,
+			Elem:     &schema.Schema{Type: schema.TypeString},
+		},
+		"required_for_roles": {
+			Type:     schema.TypeSet,
+			Optional: true,
+			Elem:     &schema.Schema{Type: schema.TypeString},
+		},
+		"required_for_scopes": {
+			Type:     schema.TypeSet,
+			Optional: true,
+			Elem:     &schema.Schema{Type: schema.TypeString},
+		},
+		"permissions": {
+			Type:     schema.TypeList,
+			Optional: true,
+			MaxItems: 1,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"view": {
+						Type:     schema.TypeSet,
+						Set:      schema.HashString,
+						Required: true,
+						Elem:     &schema.Schema{Type: schema.TypeString},
+					},
+					"edit": {
+						Type:     schema.TypeSet,
+						Set:      schema.HashString,
+						Required: true,
+						Elem:     &schema.Schema{Type: schema.TypeString},
 					},
 				},
 			},
 		},
+		"validator": {
+			Type:     schema.TypeSet,
+			Optional: true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"name": {
+						Type:     schema.TypeString,
+						Required: true,
+					},
+					"config": {
+						Type:     schema.TypeMap,
+						Optional: true,
+						Elem:     &schema.Schema{Type: schema.TypeString},
+					},
+				},
+			},
+		},
+		"annotations": {
+			Type:     schema.TypeMap,
+			Optional: true,
+			Elem:     &schema.Schema{Type: schema.TypeString},
+		},
+	}
+}
+
+// realmUserProfileGroupSchema is the schema of a group, which keycloak_realm_user_profile_group shares
+func realmUserProfileGroupSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"name": {
+			Type:     schema.TypeString,
+			Required: true,
+		},
+		"display_header": {
+			Type:     schema.TypeString,
+			Optional: true,
+		},
+		"display_description": {
+			Type:     schema.TypeString,
+			Optional: true,
+		},
+		"annotations": {
+			Type:     schema.TypeMap,
+			Optional: true,
+			Elem:     &schema.Schema{Type: schema.TypeString},
+		},
 	}
 }
 
 func getRealmUserProfileAttributeFromData(m map[string]interface{}) *keycloak.RealmUserProfileAttribute {
 	attribute := &keycloak.RealmUserProfileAttribute{
-		Name:        m["name"].(string),
-		DisplayName: m["display_name"].(string),
-		Group:       m["group"].(string),
+		Name:         m["name"].(string),
+		DisplayName:  m["display_name"].(string),
+		Group:        m["group"].(string),
+		Multivalued:  m["multivalued"].(bool),
+		DefaultValue: m["default_value"].(string),
 	}
 
 	if v, ok := m["permissions"]; ok && len(v.([]interface{})) > 0 {
@@ -288,7 +316,9 @@ func getRealmUserProfileGroupsFromData(lst []interface{}) []*keycloak.RealmUserP
 }
 
 func getRealmUserProfileFromData(data *schema.ResourceData) *keycloak.RealmUserProfile {
-	realmUserProfile := &keycloak.RealmUserProfile{}
+	realmUserProfile := &keycloak.RealmUserProfile{
+		UnmanagedAttributePolicy: data.Get("unmanaged_attribute_policy").(string),
+	}
 
 	realmUserProfile.Attributes = getRealmUserProfileAttributesFromData(data.Get("attribute").([]interface{}))
 	realmUserProfile.Groups = getRealmUserProfileGroupsFromData(data.Get("group").(*schema.Set).List())
@@ -303,6 +333,8 @@ func getRealmUserProfileAttributeData(attr *keycloak.RealmUserProfileAttribute)
 
 	attributeData["display_name"] = attr.DisplayName
 	attributeData["group"] = attr.Group
+	attributeData["multivalued"] = attr.Multivalued
+	attributeData["default_value"] = attr.DefaultValue
 	if attr.Selector != nil && len(attr.Selector.Scopes) != 0 {
 		attributeData["enabled_when_scope"] = attr.Selector.Scopes
 	}
@@ -389,6 +421,8 @@ func getRealmUserProfileGroupData(group *keycloak.RealmUserProfileGroup) map[str
 }
 
 func setRealmUserProfileData(data *schema.ResourceData, realmUserProfile *keycloak.RealmUserProfile) {
+	data.Set("unmanaged_attribute_policy", realmUserProfile.UnmanagedAttributePolicy)
+
 	attributes := make([]interface{}, 0)
 	for _, attr := range realmUserProfile.Attributes {
 		attributes = append(attributes, getRealmUserProfileAttributeData(attr))
@@ -411,7 +445,7 @@ func resourceKeycloakRealmUserProfileCreate(ctx context.Context, data *schema.Re
 
 	err := keycloakClient.UpdateRealmUserProfile(ctx, realmId, realmUserProfile)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	return resourceKeycloakRealmUserProfileRead(ctx, data, meta)
@@ -444,7 +478,7 @@ func resourceKeycloakRealmUserProfileDelete(ctx context.Context, data *schema.Re
 
 	err := keycloakClient.UpdateRealmUserProfile(ctx, realmId, realmUserProfile)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	return nil
@@ -458,7 +492,7 @@ func resourceKeycloakRealmUserProfileUpdate(ctx context.Context, data *schema.Re
 
 	err := keycloakClient.UpdateRealmUserProfile(ctx, realmId, realmUserProfile)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	setRealmUserProfileData(data, realmUserProfile)
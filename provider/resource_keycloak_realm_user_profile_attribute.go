@@ -0,0 +1,149 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/keycloak/terraform-provider-keycloak/keycloak"
+)
+
+// resourceKeycloakRealmUserProfileAttribute manages a single attribute of the user profile, leaving the other attributes
+// as they are. It shouldn't be combined with keycloak_realm_user_profile for the same realm, which manages all of them.
+func resourceKeycloakRealmUserProfileAttribute() *schema.Resource {
+	attributeSchema := realmUserProfileAttributeSchema()
+	attributeSchema["name"].ForceNew = true
+	attributeSchema["realm_id"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Required: true,
+		ForceNew: true,
+	}
+
+	return &schema.Resource{
+		CreateContext: resourceKeycloakRealmUserProfileAttributeCreate,
+		ReadContext:   resourceKeycloakRealmUserProfileAttributeRead,
+		UpdateContext: resourceKeycloakRealmUserProfileAttributeUpdate,
+		DeleteContext: resourceKeycloakRealmUserProfileAttributeDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceKeycloakRealmUserProfileAttributeImport,
+		},
+		Schema: attributeSchema,
+	}
+}
+
+func realmUserProfileElementId(realmId, name string) string {
+	return fmt.Sprintf("%s/%s", realmId, name)
+}
+
+// getRealmUserProfileAttributeResourceFromData reuses the conversion of the attribute blocks of keycloak_realm_user_profile
+func getRealmUserProfileAttributeResourceFromData(data *schema.ResourceData) *keycloak.RealmUserProfileAttribute {
+	m := make(map[string]interface{})
+	for key := range realmUserProfileAttributeSchema() {
+		m[key] = data.Get(key)
+	}
+
+	return getRealmUserProfileAttributeFromData(m)
+}
+
+func setRealmUserProfileAttributeResourceData(data *schema.ResourceData, realmId string, attribute *keycloak.RealmUserProfileAttribute) {
+	data.SetId(realmUserProfileElementId(realmId, attribute.Name))
+
+	data.Set("realm_id", realmId)
+
+	// keys that the attribute doesn't have are cleared
+	attributeData := getRealmUserProfileAttributeData(attribute)
+	for key := range realmUserProfileAttributeSchema() {
+		data.Set(key, attributeData[key])
+	}
+}
+
+func resourceKeycloakRealmUserProfileAttributeCreate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	realmId := data.Get("realm_id").(string)
+	attribute := getRealmUserProfileAttributeResourceFromData(data)
+
+	existing, err := keycloakClient.GetRealmUserProfileAttribute(ctx, realmId, attribute.Name)
+	if err != nil {
+		return diagnosticsFromError(err, data)
+	}
+	if existing != nil {
+		return diag.Errorf("the user profile of realm %s already has an attribute named %s, import it instead", realmId, attribute.Name)
+	}
+
+	err = keycloakClient.PutRealmUserProfileAttribute(ctx, realmId, attribute)
+	if err != nil {
+		return diagnosticsFromError(err, data)
+	}
+
+	data.SetId(realmUserProfileElementId(realmId, attribute.Name))
+
+	return resourceKeycloakRealmUserProfileAttributeRead(ctx, data, meta)
+}
+
+func resourceKeycloakRealmUserProfileAttributeRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	realmId := data.Get("realm_id").(string)
+	name := data.Get("name").(string)
+
+	attribute, err := keycloakClient.GetRealmUserProfileAttribute(ctx, realmId, name)
+	if err != nil {
+		return handleNotFoundError(ctx, err, data)
+	}
+	if attribute == nil {
+		tflog.Warn(ctx, "Removing resource from state as the user profile no longer has the attribute", map[string]interface{}{
+			"id": data.Id(),
+		})
+		data.SetId("")
+		return nil
+	}
+
+	setRealmUserProfileAttributeResourceData(data, realmId, attribute)
+
+	return nil
+}
+
+func resourceKeycloakRealmUserProfileAttributeUpdate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	realmId := data.Get("realm_id").(string)
+	attribute := getRealmUserProfileAttributeResourceFromData(data)
+
+	err := keycloakClient.PutRealmUserProfileAttribute(ctx, realmId, attribute)
+	if err != nil {
+		return diagnosticsFromError(err, data)
+	}
+
+	return resourceKeycloakRealmUserProfileAttributeRead(ctx, data, meta)
+}
+
+func resourceKeycloakRealmUserProfileAttributeDelete(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	realmId := data.Get("realm_id").(string)
+	name := data.Get("name").(string)
+
+	err := keycloakClient.DeleteRealmUserProfileAttribute(ctx, realmId, name)
+	if err != nil && !keycloak.ErrorIs404(err) {
+		return diagnosticsFromError(err, data)
+	}
+
+	return nil
+}
+
+func resourceKeycloakRealmUserProfileAttributeImport(_ context.Context, d *schema.ResourceData, _ interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.Split(d.Id(), "/")
+
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("Invalid import. Supported import formats: {{realmId}}/{{attributeName}}")
+	}
+
+	d.Set("realm_id", parts[0])
+	d.Set("name", parts[1])
+
+	return []*schema.ResourceData{d}, nil
+}
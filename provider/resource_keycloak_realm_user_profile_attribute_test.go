@@ -0,0 +1,161 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/keycloak/terraform-provider-keycloak/keycloak"
+)
+
+func TestAccKeycloakRealmUserProfileAttribute_basic(t *testing.T) {
+	skipIfVersionIsLessThanOrEqualTo(testCtx, t, keycloakClient, keycloak.Version_24)
+
+	realmName := acctest.RandomWithPrefix("tf-acc")
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		PreCheck:          func() { testAccPreCheck(t) },
+		CheckDestroy:      testAccCheckKeycloakRealmUserProfileAttributeDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config: testKeycloakRealmUserProfileAttribute_basic(realmName, "Department", "sales"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckKeycloakRealmUserProfileAttributeExists("keycloak_realm_user_profile_attribute.department"),
+					testAccCheckKeycloakRealmUserProfileAttributeExists("keycloak_realm_user_profile_attribute.phone"),
+					resource.TestCheckResourceAttr("keycloak_realm_user_profile_attribute.department", "default_value", "sales"),
+					resource.TestCheckResourceAttr("keycloak_realm_user_profile_attribute.phone", "multivalued", "true"),
+					testAccCheckKeycloakRealmUserProfileAttributeExistsInRealm(realmName, "email"),
+				),
+			},
+			{
+				Config: testKeycloakRealmUserProfileAttribute_basic(realmName, "Team", "support"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("keycloak_realm_user_profile_attribute.department", "display_name", "Team"),
+					resource.TestCheckResourceAttr("keycloak_realm_user_profile_attribute.department", "default_value", "support"),
+					testAccCheckKeycloakRealmUserProfileAttributeExists("keycloak_realm_user_profile_attribute.phone"),
+				),
+			},
+			{
+				ResourceName:      "keycloak_realm_user_profile_attribute.department",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccKeycloakRealmUserProfileAttribute_createAfterManualDestroy(t *testing.T) {
+	skipIfVersionIsLessThanOrEqualTo(testCtx, t, keycloakClient, keycloak.Version_24)
+
+	realmName := acctest.RandomWithPrefix("tf-acc")
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		PreCheck:          func() { testAccPreCheck(t) },
+		CheckDestroy:      testAccCheckKeycloakRealmUserProfileAttributeDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config: testKeycloakRealmUserProfileAttribute_basic(realmName, "Department", "sales"),
+				Check:  testAccCheckKeycloakRealmUserProfileAttributeExists("keycloak_realm_user_profile_attribute.department"),
+			},
+			{
+				PreConfig: func() {
+					err := keycloakClient.DeleteRealmUserProfileAttribute(testCtx, realmName, "department")
+					if err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config: testKeycloakRealmUserProfileAttribute_basic(realmName, "Department", "sales"),
+				Check:  testAccCheckKeycloakRealmUserProfileAttributeExists("keycloak_realm_user_profile_attribute.department"),
+			},
+		},
+	})
+}
+
+func testAccCheckKeycloakRealmUserProfileAttributeExists(resourceName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("resource not found: %s", resourceName)
+		}
+
+		return testAccCheckKeycloakRealmUserProfileAttributeExistsInRealm(rs.Primary.Attributes["realm_id"], rs.Primary.Attributes["name"])(s)
+	}
+}
+
+func testAccCheckKeycloakRealmUserProfileAttributeExistsInRealm(realm, name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		attribute, err := keycloakClient.GetRealmUserProfileAttribute(testCtx, realm, name)
+		if err != nil {
+			return fmt.Errorf("error getting user profile attribute %s: %s", name, err)
+		}
+		if attribute == nil {
+			return fmt.Errorf("user profile of realm %s has no attribute %s", realm, name)
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckKeycloakRealmUserProfileAttributeDestroy() resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		for _, rs := range s.RootModule().Resources {
+			if rs.Type != "keycloak_realm_user_profile_attribute" {
+				continue
+			}
+
+			realm := rs.Primary.Attributes["realm_id"]
+			name := rs.Primary.Attributes["name"]
+
+			attribute, _ := keycloakClient.GetRealmUserProfileAttribute(testCtx, realm, name)
+			if attribute != nil {
+				return fmt.Errorf("user profile attribute %s still exists", name)
+			}
+		}
+
+		return nil
+	}
+}
+
+func testKeycloakRealmUserProfileAttribute_basic(realm, displayName, defaultValue string) string {
+	return fmt.Sprintf(`
+resource "keycloak_realm" "realm" {
+	realm = "%s"
+}
+
+resource "keycloak_realm_user_profile_group" "work" {
+	realm_id       = keycloak_realm.realm.id
+	name           = "work"
+	display_header = "Work"
+}
+
+resource "keycloak_realm_user_profile_attribute" "department" {
+	realm_id      = keycloak_realm.realm.id
+	name          = "department"
+	display_name  = "%s"
+	group         = keycloak_realm_user_profile_group.work.name
+	default_value = "%s"
+
+	permissions {
+		view = ["admin", "user"]
+		edit = ["admin"]
+	}
+
+	validator {
+		name   = "options"
+		config = {
+			options = jsonencode(["sales", "support"])
+		}
+	}
+}
+
+resource "keycloak_realm_user_profile_attribute" "phone" {
+	realm_id    = keycloak_realm.realm.id
+	name        = "phone"
+	multivalued = true
+}
+	`, realm, displayName, defaultValue)
+}
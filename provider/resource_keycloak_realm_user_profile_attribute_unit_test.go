@@ -0,0 +1,104 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/keycloak/terraform-provider-keycloak/keycloak"
+)
+
+func TestRealmUserProfileAttributeResourceLifecycle(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	keycloakClient := newFakeKeycloakClient(t)
+
+	if err := keycloakClient.NewRealm(ctx, &keycloak.Realm{Realm: "test", Enabled: true}); err != nil {
+		t.Fatal(err)
+	}
+
+	group := schema.TestResourceDataRaw(t, resourceKeycloakRealmUserProfileGroup().Schema, map[string]interface{}{
+		"realm_id":       "test",
+		"name":           "work",
+		"display_header": "Work",
+	})
+	if diags := resourceKeycloakRealmUserProfileGroupCreate(ctx, group, keycloakClient); diags.HasError() {
+		t.Fatal(diags)
+	}
+
+	attribute := schema.TestResourceDataRaw(t, resourceKeycloakRealmUserProfileAttribute().Schema, map[string]interface{}{
+		"realm_id":      "test",
+		"name":          "department",
+		"group":         "work",
+		"multivalued":   true,
+		"default_value": "sales",
+		"validator": []interface{}{
+			map[string]interface{}{
+				"name":   "options",
+				"config": map[string]interface{}{"options": `["sales","support"]`},
+			},
+		},
+	})
+	if diags := resourceKeycloakRealmUserProfileAttributeCreate(ctx, attribute, keycloakClient); diags.HasError() {
+		t.Fatal(diags)
+	}
+
+	if attribute.Id() != "test/department" {
+		t.Errorf("expected id test/department, got %s", attribute.Id())
+	}
+	if !attribute.Get("multivalued").(bool) || attribute.Get("default_value").(string) != "sales" {
+		t.Errorf("expected multivalued and default_value to be read back, got %v and %q", attribute.Get("multivalued"), attribute.Get("default_value"))
+	}
+
+	// another attribute with the same name is refused instead of silently taken over
+	duplicate := schema.TestResourceDataRaw(t, resourceKeycloakRealmUserProfileAttribute().Schema, map[string]interface{}{
+		"realm_id": "test",
+		"name":     "department",
+	})
+	if diags := resourceKeycloakRealmUserProfileAttributeCreate(ctx, duplicate, keycloakClient); !diags.HasError() {
+		t.Error("expected an existing attribute to be refused")
+	}
+
+	realmUserProfile, err := keycloakClient.GetRealmUserProfile(ctx, "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the default attributes and group of the realm are left alone
+	if len(realmUserProfile.Attributes) != 5 || len(realmUserProfile.Groups) != 2 {
+		t.Errorf("expected 5 attributes and 2 groups, got %d and %d", len(realmUserProfile.Attributes), len(realmUserProfile.Groups))
+	}
+
+	if diags := resourceKeycloakRealmUserProfileAttributeDelete(ctx, attribute, keycloakClient); diags.HasError() {
+		t.Fatal(diags)
+	}
+	if diags := resourceKeycloakRealmUserProfileAttributeRead(ctx, attribute, keycloakClient); diags.HasError() {
+		t.Fatal(diags)
+	}
+	if attribute.Id() != "" {
+		t.Errorf("expected the deleted attribute to be removed from state, got id %s", attribute.Id())
+	}
+
+	if diags := resourceKeycloakRealmUserProfileGroupDelete(ctx, group, keycloakClient); diags.HasError() {
+		t.Fatal(diags)
+	}
+}
+
+func TestRealmUserProfileAttributeResourceImport(t *testing.T) {
+	t.Parallel()
+
+	data := resourceKeycloakRealmUserProfileAttribute().Data(nil)
+	data.SetId("test/department")
+
+	if _, err := resourceKeycloakRealmUserProfileAttributeImport(context.Background(), data, nil); err != nil {
+		t.Fatal(err)
+	}
+	if data.Get("realm_id").(string) != "test" || data.Get("name").(string) != "department" {
+		t.Errorf("unexpected realm_id %q and name %q", data.Get("realm_id"), data.Get("name"))
+	}
+
+	data.SetId("department")
+	if _, err := resourceKeycloakRealmUserProfileAttributeImport(context.Background(), data, nil); err == nil {
+		t.Error("expected an id without realm to be rejected")
+	}
+}
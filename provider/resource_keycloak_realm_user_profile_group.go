@@ -0,0 +1,142 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/keycloak/terraform-provider-keycloak/keycloak"
+)
+
+// resourceKeycloakRealmUserProfileGroup manages a single group of the user profile, like
+// keycloak_realm_user_profile_attribute does for attributes.
+func resourceKeycloakRealmUserProfileGroup() *schema.Resource {
+	groupSchema := realmUserProfileGroupSchema()
+	groupSchema["name"].ForceNew = true
+	groupSchema["realm_id"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Required: true,
+		ForceNew: true,
+	}
+
+	return &schema.Resource{
+		CreateContext: resourceKeycloakRealmUserProfileGroupCreate,
+		ReadContext:   resourceKeycloakRealmUserProfileGroupRead,
+		UpdateContext: resourceKeycloakRealmUserProfileGroupUpdate,
+		DeleteContext: resourceKeycloakRealmUserProfileGroupDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceKeycloakRealmUserProfileGroupImport,
+		},
+		Schema: groupSchema,
+	}
+}
+
+func getRealmUserProfileGroupResourceFromData(data *schema.ResourceData) *keycloak.RealmUserProfileGroup {
+	m := make(map[string]interface{})
+	for key := range realmUserProfileGroupSchema() {
+		m[key] = data.Get(key)
+	}
+
+	return getRealmUserProfileGroupFromData(m)
+}
+
+func setRealmUserProfileGroupResourceData(data *schema.ResourceData, realmId string, group *keycloak.RealmUserProfileGroup) {
+	data.SetId(realmUserProfileElementId(realmId, group.Name))
+
+	data.Set("realm_id", realmId)
+
+	for key, value := range getRealmUserProfileGroupData(group) {
+		data.Set(key, value)
+	}
+}
+
+func resourceKeycloakRealmUserProfileGroupCreate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	realmId := data.Get("realm_id").(string)
+	group := getRealmUserProfileGroupResourceFromData(data)
+
+	existing, err := keycloakClient.GetRealmUserProfileGroup(ctx, realmId, group.Name)
+	if err != nil {
+		return diagnosticsFromError(err, data)
+	}
+	if existing != nil {
+		return diag.Errorf("the user profile of realm %s already has a group named %s, import it instead", realmId, group.Name)
+	}
+
+	err = keycloakClient.PutRealmUserProfileGroup(ctx, realmId, group)
+	if err != nil {
+		return diagnosticsFromError(err, data)
+	}
+
+	data.SetId(realmUserProfileElementId(realmId, group.Name))
+
+	return resourceKeycloakRealmUserProfileGroupRead(ctx, data, meta)
+}
+
+func resourceKeycloakRealmUserProfileGroupRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	realmId := data.Get("realm_id").(string)
+	name := data.Get("name").(string)
+
+	group, err := keycloakClient.GetRealmUserProfileGroup(ctx, realmId, name)
+	if err != nil {
+		return handleNotFoundError(ctx, err, data)
+	}
+	if group == nil {
+		tflog.Warn(ctx, "Removing resource from state as the user profile no longer has the group", map[string]interface{}{
+			"id": data.Id(),
+		})
+		data.SetId("")
+		return nil
+	}
+
+	setRealmUserProfileGroupResourceData(data, realmId, group)
+
+	return nil
+}
+
+func resourceKeycloakRealmUserProfileGroupUpdate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	realmId := data.Get("realm_id").(string)
+	group := getRealmUserProfileGroupResourceFromData(data)
+
+	err := keycloakClient.PutRealmUserProfileGroup(ctx, realmId, group)
+	if err != nil {
+		return diagnosticsFromError(err, data)
+	}
+
+	return resourceKeycloakRealmUserProfileGroupRead(ctx, data, meta)
+}
+
+func resourceKeycloakRealmUserProfileGroupDelete(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	realmId := data.Get("realm_id").(string)
+	name := data.Get("name").(string)
+
+	err := keycloakClient.DeleteRealmUserProfileGroup(ctx, realmId, name)
+	if err != nil && !keycloak.ErrorIs404(err) {
+		return diagnosticsFromError(err, data)
+	}
+
+	return nil
+}
+
+func resourceKeycloakRealmUserProfileGroupImport(_ context.Context, d *schema.ResourceData, _ interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.Split(d.Id(), "/")
+
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("Invalid import. Supported import formats: {{realmId}}/{{groupName}}")
+	}
+
+	d.Set("realm_id", parts[0])
+	d.Set("name", parts[1])
+
+	return []*schema.ResourceData{d}, nil
+}
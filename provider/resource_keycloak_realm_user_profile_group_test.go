@@ -0,0 +1,106 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/keycloak/terraform-provider-keycloak/keycloak"
+)
+
+func TestAccKeycloakRealmUserProfileGroup_basic(t *testing.T) {
+	skipIfVersionIsLessThanOrEqualTo(testCtx, t, keycloakClient, keycloak.Version_24)
+
+	realmName := acctest.RandomWithPrefix("tf-acc")
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		PreCheck:          func() { testAccPreCheck(t) },
+		CheckDestroy:      testAccCheckKeycloakRealmUserProfileGroupDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config: testKeycloakRealmUserProfileGroup_basic(realmName, "Work"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckKeycloakRealmUserProfileGroupExists("keycloak_realm_user_profile_group.work"),
+					resource.TestCheckResourceAttr("keycloak_realm_user_profile_group.work", "display_header", "Work"),
+					resource.TestCheckResourceAttr("keycloak_realm_user_profile_group.work", "annotations.foo", "bar"),
+				),
+			},
+			{
+				Config: testKeycloakRealmUserProfileGroup_basic(realmName, "Job"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckKeycloakRealmUserProfileGroupExists("keycloak_realm_user_profile_group.work"),
+					resource.TestCheckResourceAttr("keycloak_realm_user_profile_group.work", "display_header", "Job"),
+				),
+			},
+			{
+				ResourceName:      "keycloak_realm_user_profile_group.work",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckKeycloakRealmUserProfileGroupExists(resourceName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("resource not found: %s", resourceName)
+		}
+
+		realm := rs.Primary.Attributes["realm_id"]
+		name := rs.Primary.Attributes["name"]
+
+		group, err := keycloakClient.GetRealmUserProfileGroup(testCtx, realm, name)
+		if err != nil {
+			return fmt.Errorf("error getting user profile group %s: %s", name, err)
+		}
+		if group == nil {
+			return fmt.Errorf("user profile of realm %s has no group %s", realm, name)
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckKeycloakRealmUserProfileGroupDestroy() resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		for _, rs := range s.RootModule().Resources {
+			if rs.Type != "keycloak_realm_user_profile_group" {
+				continue
+			}
+
+			realm := rs.Primary.Attributes["realm_id"]
+			name := rs.Primary.Attributes["name"]
+
+			group, _ := keycloakClient.GetRealmUserProfileGroup(testCtx, realm, name)
+			if group != nil {
+				return fmt.Errorf("user profile group %s still exists", name)
+			}
+		}
+
+		return nil
+	}
+}
+
+func testKeycloakRealmUserProfileGroup_basic(realm, displayHeader string) string {
+	return fmt.Sprintf(`
+resource "keycloak_realm" "realm" {
+	realm = "%s"
+}
+
+resource "keycloak_realm_user_profile_group" "work" {
+	realm_id            = keycloak_realm.realm.id
+	name                = "work"
+	display_header      = "%s"
+	display_description = "Where the user works"
+
+	annotations = {
+		foo = "bar"
+	}
+}
+	`, realm, displayHeader)
+}
@@ -346,6 +346,50 @@ func TestAccKeycloakRealmUserProfile_attributePermissions(t *testing.T) {
 	})
 }
 
+func TestAccKeycloakRealmUserProfile_unmanagedAttributePolicy(t *testing.T) {
+	skipIfVersionIsLessThanOrEqualTo(testCtx, t, keycloakClient, keycloak.Version_24)
+
+	realmName := acctest.RandomWithPrefix("tf-acc")
+
+	withPolicy := &keycloak.RealmUserProfile{
+		Attributes: []*keycloak.RealmUserProfileAttribute{
+			{Name: "username"},
+			{Name: "email"},
+			{Name: "phone", Multivalued: true},
+			{Name: "locale", DefaultValue: "en"},
+		},
+		UnmanagedAttributePolicy: "ADMIN_EDIT",
+	}
+
+	withoutPolicy := &keycloak.RealmUserProfile{
+		Attributes: []*keycloak.RealmUserProfileAttribute{
+			{Name: "username"},
+			{Name: "email"},
+			{Name: "phone"},
+		},
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		PreCheck:          func() { testAccPreCheck(t) },
+		CheckDestroy:      testAccCheckKeycloakRealmUserProfileDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config: testKeycloakRealmUserProfile_template(realmName, withPolicy),
+				Check: testAccCheckKeycloakRealmUserProfileStateEqual(
+					"keycloak_realm_user_profile.realm_user_profile", withPolicy,
+				),
+			},
+			{
+				Config: testKeycloakRealmUserProfile_template(realmName, withoutPolicy),
+				Check: testAccCheckKeycloakRealmUserProfileStateEqual(
+					"keycloak_realm_user_profile.realm_user_profile", withoutPolicy,
+				),
+			},
+		},
+	})
+}
+
 func testKeycloakRealmUserProfile_featureDisabled(realm string) string {
 	return fmt.Sprintf(`
 resource "keycloak_realm" "realm" {
@@ -370,6 +414,10 @@ resource "keycloak_realm" "realm" {
 resource "keycloak_realm_user_profile" "realm_user_profile" {
 	realm_id = keycloak_realm.realm.id
 
+	{{- if .userProfile.UnmanagedAttributePolicy }}
+	unmanaged_attribute_policy = "{{ .userProfile.UnmanagedAttributePolicy }}"
+	{{- end }}
+
 	{{- range $_, $attribute := .userProfile.Attributes }}
 	attribute {
         name = "{{ $attribute.Name }}"
@@ -381,6 +429,14 @@ resource "keycloak_realm_user_profile" "realm_user_profile" {
         group = "{{ $attribute.Group }}"
 		{{- end }}
 
+		{{- if $attribute.Multivalued }}
+        multivalued = true
+		{{- end }}
+
+		{{- if $attribute.DefaultValue }}
+        default_value = "{{ $attribute.DefaultValue }}"
+		{{- end }}
+
 		{{- if $attribute.Selector }}
 		{{- if $attribute.Selector.Scopes }}
         enabled_when_scope = ["{{ StringsJoin $attribute.Selector.Scopes "\", \"" }}"]
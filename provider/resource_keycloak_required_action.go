@@ -81,17 +81,17 @@ func resourceKeycloakRequiredActionsCreate(ctx context.Context, data *schema.Res
 
 	action, err := getRequiredActionFromData(data)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	unregisteredRequiredActions, err := keycloakClient.GetUnregisteredRequiredActions(ctx, action.RealmId)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 	for _, unregisteredRequiredAction := range unregisteredRequiredActions {
 		if unregisteredRequiredAction.ProviderId == action.Alias {
 			if err := keycloakClient.RegisterRequiredAction(ctx, unregisteredRequiredAction); err != nil {
-				return diag.FromErr(err)
+				return diagnosticsFromError(err, data)
 			}
 			break
 		}
@@ -99,7 +99,7 @@ func resourceKeycloakRequiredActionsCreate(ctx context.Context, data *schema.Res
 
 	err = keycloakClient.CreateRequiredAction(ctx, action)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	setRequiredActionData(data, action)
@@ -125,12 +125,12 @@ func resourceKeycloakRequiredActionsUpdate(ctx context.Context, data *schema.Res
 
 	action, err := getRequiredActionFromData(data)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	err = keycloakClient.UpdateRequiredAction(ctx, action)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	setRequiredActionData(data, action)
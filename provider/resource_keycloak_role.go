@@ -102,7 +102,7 @@ func resourceKeycloakRoleCreate(ctx context.Context, data *schema.ResourceData,
 		for _, compositeRoleId := range compositeRolesTf {
 			compositeRoleToAdd, err := keycloakClient.GetRole(ctx, role.RealmId, compositeRoleId.(string))
 			if err != nil {
-				return diag.FromErr(err)
+				return diagnosticsFromError(err, data)
 			}
 
 			compositeRoles = append(compositeRoles, compositeRoleToAdd)
@@ -115,13 +115,13 @@ func resourceKeycloakRoleCreate(ctx context.Context, data *schema.ResourceData,
 
 	err := keycloakClient.CreateRole(ctx, role)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	if role.Composite {
 		err = keycloakClient.AddCompositesToRole(ctx, role, compositeRoles)
 		if err != nil {
-			return diag.FromErr(err)
+			return diagnosticsFromError(err, data)
 		}
 	}
 
@@ -146,7 +146,7 @@ func resourceKeycloakRoleRead(ctx context.Context, data *schema.ResourceData, me
 	if role.Composite {
 		composites, err := keycloakClient.GetRoleComposites(ctx, role)
 		if err != nil {
-			return diag.FromErr(err)
+			return diagnosticsFromError(err, data)
 		}
 
 		var compositeRoleIds []string
@@ -168,12 +168,12 @@ func resourceKeycloakRoleUpdate(ctx context.Context, data *schema.ResourceData,
 
 	err := keycloakClient.UpdateRole(ctx, role)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	keycloakComposites, err := keycloakClient.GetRoleComposites(ctx, role)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	if v, ok := data.GetOk("composite_roles"); ok {
@@ -198,7 +198,7 @@ func resourceKeycloakRoleUpdate(ctx context.Context, data *schema.ResourceData,
 		if len(keycloakCompositesToRemove) != 0 {
 			err = keycloakClient.RemoveCompositesFromRole(ctx, role, keycloakCompositesToRemove)
 			if err != nil {
-				return diag.FromErr(err)
+				return diagnosticsFromError(err, data)
 			}
 		}
 
@@ -207,7 +207,7 @@ func resourceKeycloakRoleUpdate(ctx context.Context, data *schema.ResourceData,
 			for _, tfCompositeId := range tfCompositeIds.List() {
 				compositeToAdd, err := keycloakClient.GetRole(ctx, role.RealmId, tfCompositeId.(string))
 				if err != nil {
-					return diag.FromErr(err)
+					return diagnosticsFromError(err, data)
 				}
 
 				compositesToAdd = append(compositesToAdd, compositeToAdd)
@@ -215,7 +215,7 @@ func resourceKeycloakRoleUpdate(ctx context.Context, data *schema.ResourceData,
 
 			err = keycloakClient.AddCompositesToRole(ctx, role, compositesToAdd)
 			if err != nil {
-				return diag.FromErr(err)
+				return diagnosticsFromError(err, data)
 			}
 		}
 	} else {
@@ -223,7 +223,7 @@ func resourceKeycloakRoleUpdate(ctx context.Context, data *schema.ResourceData,
 		if len(keycloakComposites) != 0 {
 			err = keycloakClient.RemoveCompositesFromRole(ctx, role, keycloakComposites)
 			if err != nil {
-				return diag.FromErr(err)
+				return diagnosticsFromError(err, data)
 			}
 		}
 	}
@@ -417,7 +417,7 @@ func resourceKeycloakSamlClientCreate(ctx context.Context, data *schema.Resource
 
 	err := keycloakClient.NewSamlClient(ctx, client)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	data.SetId(client.Id)
@@ -438,7 +438,7 @@ func resourceKeycloakSamlClientRead(ctx context.Context, data *schema.ResourceDa
 
 	err = mapToDataFromSamlClient(ctx, data, client)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	return nil
@@ -451,12 +451,12 @@ func resourceKeycloakSamlClientUpdate(ctx context.Context, data *schema.Resource
 
 	err := keycloakClient.UpdateSamlClient(ctx, client)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	err = mapToDataFromSamlClient(ctx, data, client)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	return nil
@@ -94,7 +94,7 @@ func resourceKeycloakSamlClientScopeCreate(ctx context.Context, data *schema.Res
 
 	err := keycloakClient.NewSamlClientScope(ctx, clientScope)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	setSamlClientScopeData(data, clientScope)
@@ -125,7 +125,7 @@ func resourceKeycloakSamlClientScopeUpdate(ctx context.Context, data *schema.Res
 
 	err := keycloakClient.UpdateSamlClientScope(ctx, clientScope)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	setSamlClientScopeData(data, clientScope)
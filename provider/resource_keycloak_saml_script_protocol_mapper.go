@@ -111,12 +111,12 @@ func resourceKeycloakSamlScriptProtocolMapperCreate(ctx context.Context, data *s
 
 	err := keycloakClient.ValidateSamlScriptProtocolMapper(ctx, samlScriptMapper)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	err = keycloakClient.NewSamlScriptProtocolMapper(ctx, samlScriptMapper)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	mapFromSamlScriptMapperToData(samlScriptMapper, data)
@@ -148,12 +148,12 @@ func resourceKeycloakSamlScriptProtocolMapperUpdate(ctx context.Context, data *s
 
 	err := keycloakClient.ValidateSamlScriptProtocolMapper(ctx, samlScriptMapper)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	err = keycloakClient.UpdateSamlScriptProtocolMapper(ctx, samlScriptMapper)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	return resourceKeycloakSamlScriptProtocolMapperRead(ctx, data, meta)
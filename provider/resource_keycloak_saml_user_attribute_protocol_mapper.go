@@ -105,12 +105,12 @@ func resourceKeycloakSamlUserAttributeProtocolMapperCreate(ctx context.Context,
 
 	err := keycloakClient.ValidateSamlUserAttributeProtocolMapper(ctx, samlUserAttributeMapper)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	err = keycloakClient.NewSamlUserAttributeProtocolMapper(ctx, samlUserAttributeMapper)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	mapFromSamlUserAttributeMapperToData(samlUserAttributeMapper, data)
@@ -142,12 +142,12 @@ func resourceKeycloakSamlUserAttributeProtocolMapperUpdate(ctx context.Context,
 
 	err := keycloakClient.ValidateSamlUserAttributeProtocolMapper(ctx, samlUserAttributeMapper)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	err = keycloakClient.UpdateSamlUserAttributeProtocolMapper(ctx, samlUserAttributeMapper)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	return resourceKeycloakSamlUserAttributeProtocolMapperRead(ctx, data, meta)
@@ -103,12 +103,12 @@ func resourceKeycloakSamlUserPropertyProtocolMapperCreate(ctx context.Context, d
 
 	err := keycloakClient.ValidateSamlUserPropertyProtocolMapper(ctx, samlUserPropertyMapper)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	err = keycloakClient.NewSamlUserPropertyProtocolMapper(ctx, samlUserPropertyMapper)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	mapFromSamlUserPropertyProtocolMapperToData(samlUserPropertyMapper, data)
@@ -140,12 +140,12 @@ func resourceKeycloakSamlUserPropertyProtocolMapperUpdate(ctx context.Context, d
 
 	err := keycloakClient.ValidateSamlUserPropertyProtocolMapper(ctx, samlUserPropertyMapper)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	err = keycloakClient.UpdateSamlUserPropertyProtocolMapper(ctx, samlUserPropertyMapper)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	return resourceKeycloakSamlUserPropertyProtocolMapperRead(ctx, data, meta)
@@ -206,7 +206,7 @@ func resourceKeycloakUserCreate(ctx context.Context, data *schema.ResourceData,
 
 	err := keycloakClient.NewUser(ctx, user)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	v, isInitialPasswordSet := data.GetOk("initial_password")
@@ -216,7 +216,7 @@ func resourceKeycloakUserCreate(ctx context.Context, data *schema.ResourceData,
 		isPasswordTemporary := passwordBlock["temporary"].(bool)
 		err := keycloakClient.ResetUserPassword(ctx, user.RealmId, user.Id, passwordValue, isPasswordTemporary)
 		if err != nil {
-			return diag.FromErr(err)
+			return diagnosticsFromError(err, data)
 		}
 	}
 
@@ -248,7 +248,7 @@ func resourceKeycloakUserUpdate(ctx context.Context, data *schema.ResourceData,
 
 	err := keycloakClient.UpdateUser(ctx, user)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	mapFromUserToData(data, user)
@@ -88,13 +88,13 @@ func resourceKeycloakUserGroupsReconcile(ctx context.Context, data *schema.Resou
 		remove := interfaceSliceToStringSlice(os.Difference(ns).List())
 
 		if err := keycloakClient.RemoveUserFromGroups(ctx, remove, userId, realmId); err != nil {
-			return diag.FromErr(err)
+			return diagnosticsFromError(err, data)
 		}
 	}
 
 	userGroups, err := keycloakClient.GetUserGroups(ctx, realmId, userId)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	var userGroupsIds []string
@@ -106,12 +106,12 @@ func resourceKeycloakUserGroupsReconcile(ctx context.Context, data *schema.Resou
 	add := stringArrayDifference(groupIds, userGroupsIds)
 
 	if err := keycloakClient.AddUserToGroups(ctx, add, userId, realmId); err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	if exhaustive {
 		if err := keycloakClient.RemoveUserFromGroups(ctx, remove, userId, realmId); err != nil {
-			return diag.FromErr(err)
+			return diagnosticsFromError(err, data)
 		}
 	}
 
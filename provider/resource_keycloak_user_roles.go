@@ -100,7 +100,7 @@ func resourceKeycloakUserRolesReconcile(ctx context.Context, data *schema.Resour
 
 	user, err := keycloakClient.GetUser(ctx, realmId, userId)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	if data.HasChange("role_ids") && !data.IsNewResource() {
@@ -111,17 +111,17 @@ func resourceKeycloakUserRolesReconcile(ctx context.Context, data *schema.Resour
 
 		tfRolesToRemove, err := getExtendedRoleMapping(ctx, keycloakClient, realmId, remove)
 		if err != nil {
-			return diag.FromErr(err)
+			return diagnosticsFromError(err, data)
 		}
 
 		if err = removeRolesFromUser(ctx, keycloakClient, tfRolesToRemove.clientRoles, tfRolesToRemove.realmRoles, user); err != nil {
-			return diag.FromErr(err)
+			return diagnosticsFromError(err, data)
 		}
 	}
 
 	tfRoles, err := getExtendedRoleMapping(ctx, keycloakClient, realmId, roleIds)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	// get the list of currently assigned roles. Due to default realm and client roles
@@ -134,14 +134,14 @@ func resourceKeycloakUserRolesReconcile(ctx context.Context, data *schema.Resour
 	// add roles
 	err = addRolesToUser(ctx, keycloakClient, updates.clientRolesToAdd, updates.realmRolesToAdd, user)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	// remove roles if exhaustive (authoritative)
 	if exhaustive {
 		err = removeRolesFromUser(ctx, keycloakClient, updates.clientRolesToRemove, updates.realmRolesToRemove, user)
 		if err != nil {
-			return diag.FromErr(err)
+			return diagnosticsFromError(err, data)
 		}
 	}
 
@@ -164,7 +164,7 @@ func resourceKeycloakUserRolesRead(ctx context.Context, data *schema.ResourceDat
 
 	roles, err := keycloakClient.GetUserRoleMappings(ctx, realmId, userId)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	var roleIds []string
@@ -200,12 +200,12 @@ func resourceKeycloakUserRolesDelete(ctx context.Context, data *schema.ResourceD
 	roleIds := interfaceSliceToStringSlice(data.Get("role_ids").(*schema.Set).List())
 	rolesToRemove, err := getExtendedRoleMapping(ctx, keycloakClient, realmId, roleIds)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	err = removeRolesFromUser(ctx, keycloakClient, rolesToRemove.clientRoles, rolesToRemove.realmRoles, user)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(err, data)
 	}
 
 	return nil
@@ -90,6 +90,18 @@ func skipIfVersionIsGreaterThanOrEqualTo(ctx context.Context, t *testing.T, keyc
 	}
 }
 
+// Skips the test if a feature isn't available on the keycloak server, because it is too old or the feature is disabled
+func skipIfFeatureIsDisabled(ctx context.Context, t *testing.T, keycloakClient *keycloak.KeycloakClient, feature keycloak.Feature) {
+	ok, err := keycloakClient.FeatureIsEnabled(ctx, feature)
+	if err != nil {
+		t.Errorf("error checking keycloak features: %v", err)
+	}
+
+	if !ok {
+		t.Skipf("keycloak server does not support feature %s, skipping...", feature)
+	}
+}
+
 func TestCheckResourceAttrNot(name, key, value string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		err := resource.TestCheckResourceAttr(name, key, value)(s)
@@ -2,8 +2,10 @@ package provider
 
 import (
 	"context"
+	"encoding/json"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"reflect"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -76,7 +78,7 @@ func handleNotFoundError(ctx context.Context, err error, data *schema.ResourceDa
 		return nil
 	}
 
-	return diag.FromErr(err)
+	return diagnosticsFromError(err, data)
 }
 
 func interfaceSliceToStringSlice(iv []interface{}) []string {
@@ -112,3 +114,52 @@ func stringSliceContains(s []string, e string) bool {
 func stringPointer(s string) *string {
 	return &s
 }
+
+func boolPointer(b bool) *bool {
+	return &b
+}
+
+// This will suppress the Terraform diff when comparing JSON strings.
+// Keycloak does not preserve key ordering or whitespace, so only semantic differences should produce a diff
+func suppressJsonDiff(_, old, new string, _ *schema.ResourceData) bool {
+	if old == "" || new == "" {
+		return false
+	}
+
+	var oldValue, newValue interface{}
+	if err := json.Unmarshal([]byte(old), &oldValue); err != nil {
+		return false
+	}
+	if err := json.Unmarshal([]byte(new), &newValue); err != nil {
+		return false
+	}
+
+	return reflect.DeepEqual(oldValue, newValue)
+}
+
+func getJsonObjectFromString(s string) (map[string]interface{}, error) {
+	jsonObject := make(map[string]interface{})
+	if s == "" {
+		return jsonObject, nil
+	}
+
+	err := json.Unmarshal([]byte(s), &jsonObject)
+	if err != nil {
+		return nil, err
+	}
+
+	return jsonObject, nil
+}
+
+func getJsonStringFromObject(jsonObject map[string]interface{}) (string, error) {
+	if jsonObject == nil {
+		jsonObject = make(map[string]interface{})
+	}
+
+	b, err := json.Marshal(jsonObject)
+	if err != nil {
+		return "", err
+	}
+
+	return string(b), nil
+}